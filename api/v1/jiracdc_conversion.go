@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/chambridge/atlassian-cdc-git/api/v1beta1"
+)
+
+// extraProjectKeysAnnotation preserves v1beta1 project keys beyond the
+// first when a multi-project resource is read through the v1 API, so
+// converting back to v1beta1 is lossless.
+const extraProjectKeysAnnotation = "cdc.atlassian.io/extra-project-keys"
+
+// copyStringMap returns a copy of m, or nil when m is empty, so
+// conversions never share map storage between objects.
+func copyStringMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// ConvertTo converts this JiraCDC (v1) to the Hub version (v1beta1).
+func (src *JiraCDC) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta1.JiraCDC)
+	if !ok {
+		return fmt.Errorf("expected v1beta1.JiraCDC but got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Jira.BaseURL = src.Spec.Jira.BaseURL
+	dst.Spec.Jira.AuthMethod = src.Spec.Jira.AuthMethod
+	dst.Spec.Jira.CredentialsSecret = src.Spec.Jira.CredentialsSecret
+	dst.Spec.Jira.BoardID = src.Spec.Jira.BoardID
+	dst.Spec.Jira.FilterID = src.Spec.Jira.FilterID
+	dst.Spec.Jira.TLS = v1beta1.TLSConfig(src.Spec.Jira.TLS)
+	dst.Spec.Jira.Proxy = v1beta1.ProxyConfig(src.Spec.Jira.Proxy)
+	dst.Spec.Jira.APIVersion = src.Spec.Jira.APIVersion
+	if keys := src.Spec.Jira.ProjectKeys; len(keys) > 0 {
+		dst.Spec.Jira.ProjectKeys = append([]string{}, keys...)
+	} else {
+		dst.Spec.Jira.ProjectKeys = []string{src.Spec.Jira.ProjectKey}
+		if extra, ok := src.Annotations[extraProjectKeysAnnotation]; ok && extra != "" {
+			dst.Spec.Jira.ProjectKeys = append(dst.Spec.Jira.ProjectKeys, strings.Split(extra, ",")...)
+		}
+	}
+	if _, ok := src.Annotations[extraProjectKeysAnnotation]; ok {
+		// Drop the carrier annotation without mutating the source object.
+		annotations := make(map[string]string, len(src.Annotations))
+		for k, v := range src.Annotations {
+			if k != extraProjectKeysAnnotation {
+				annotations[k] = v
+			}
+		}
+		dst.Annotations = annotations
+	}
+
+	dst.Spec.GitRepository = v1beta1.GitRepositoryConfig{
+		URL:                        src.Spec.GitRepository.URL,
+		Branch:                     src.Spec.GitRepository.Branch,
+		FileLayout:                 src.Spec.GitRepository.FileLayout,
+		CredentialsSecret:          src.Spec.GitRepository.CredentialsSecret,
+		ConflictResolutionStrategy: src.Spec.GitRepository.ConflictResolutionStrategy,
+		AuthorStrategy:             src.Spec.GitRepository.AuthorStrategy,
+		UseLFS:                     src.Spec.GitRepository.UseLFS,
+		CommitBatchSize:            src.Spec.GitRepository.CommitBatchSize,
+		SquashOnReconcile:          src.Spec.GitRepository.SquashOnReconcile,
+		CommitMessageTemplate:      src.Spec.GitRepository.CommitMessageTemplate,
+		CommitMessagePattern:       src.Spec.GitRepository.CommitMessagePattern,
+		AddCommitTrailers:          src.Spec.GitRepository.AddCommitTrailers,
+		IssueTemplate:              src.Spec.GitRepository.IssueTemplate,
+		TypeTemplates:              copyStringMap(src.Spec.GitRepository.TypeTemplates),
+		TypeLayouts:                copyStringMap(src.Spec.GitRepository.TypeLayouts),
+		EpicChildIndex:             src.Spec.GitRepository.EpicChildIndex,
+		MirrorRemotes:              append([]string(nil), src.Spec.GitRepository.MirrorRemotes...),
+		MirrorCredentialsSecrets:   copyStringMap(src.Spec.GitRepository.MirrorCredentialsSecrets),
+		RequireMirrors:             src.Spec.GitRepository.RequireMirrors,
+		TLS:                        v1beta1.TLSConfig(src.Spec.GitRepository.TLS),
+		Proxy:                      v1beta1.ProxyConfig(src.Spec.GitRepository.Proxy),
+		RedactSecrets:              src.Spec.GitRepository.RedactSecrets,
+		DriftPolicy:                src.Spec.GitRepository.DriftPolicy,
+	}
+	for _, rule := range src.Spec.GitRepository.RedactionRules {
+		dst.Spec.GitRepository.RedactionRules = append(dst.Spec.GitRepository.RedactionRules, v1beta1.RedactionRule(rule))
+	}
+	dst.Spec.Storage = v1beta1.StorageConfig{
+		Backend: src.Spec.Storage.Backend,
+		S3:      v1beta1.S3StorageConfig(src.Spec.Storage.S3),
+	}
+	dst.Spec.Sync = v1beta1.SyncConfig{
+		Interval:                 src.Spec.Sync.Interval,
+		Schedule:                 src.Spec.Sync.Schedule,
+		BatchSize:                src.Spec.Sync.BatchSize,
+		MaxProjectConcurrency:    src.Spec.Sync.MaxProjectConcurrency,
+		ResumeFromCheckpoint:     src.Spec.Sync.ResumeFromCheckpoint,
+		Incremental:              src.Spec.Sync.Incremental,
+		IncrementalOverlap:       src.Spec.Sync.IncrementalOverlap,
+		PrioritizeByJiraPriority: src.Spec.Sync.PrioritizeByJiraPriority,
+		Paused:                   src.Spec.Sync.Paused,
+		SyncChangelog:            src.Spec.Sync.SyncChangelog,
+		SyncWorklogs:             src.Spec.Sync.SyncWorklogs,
+		SyncRemoteLinks:          src.Spec.Sync.SyncRemoteLinks,
+		SyncAttachments:          src.Spec.Sync.SyncAttachments,
+		AttachmentConcurrency:    src.Spec.Sync.AttachmentConcurrency,
+		LabelFilter:              append([]string(nil), src.Spec.Sync.LabelFilter...),
+		ComponentFilter:          append([]string(nil), src.Spec.Sync.ComponentFilter...),
+		OperationTimeout:         src.Spec.Sync.OperationTimeout,
+		IgnoreFields:             append([]string(nil), src.Spec.Sync.IgnoreFields...),
+		IgnoreStatuses:           append([]string(nil), src.Spec.Sync.IgnoreStatuses...),
+		RemovedProjectPolicy:     src.Spec.Sync.RemovedProjectPolicy,
+		MaxIssuesPerOperation:    src.Spec.Sync.MaxIssuesPerOperation,
+		AllowLargeSync:           src.Spec.Sync.AllowLargeSync,
+	}
+	for _, fm := range src.Spec.Sync.FieldMapping {
+		dst.Spec.Sync.FieldMapping = append(dst.Spec.Sync.FieldMapping, v1beta1.FieldMap(fm))
+	}
+	dst.Spec.Notifications = nil
+	for _, nc := range src.Spec.Notifications {
+		dst.Spec.Notifications = append(dst.Spec.Notifications, v1beta1.NotificationConfig{
+			Type:            nc.Type,
+			URLSecret:       nc.URLSecret,
+			Events:          append([]string{}, nc.Events...),
+			MessageTemplate: nc.MessageTemplate,
+		})
+	}
+	dst.Spec.Hooks = nil
+	for _, hc := range src.Spec.Hooks {
+		dst.Spec.Hooks = append(dst.Spec.Hooks, v1beta1.HookConfig{
+			Type:      hc.Type,
+			Scope:     hc.Scope,
+			URLSecret: hc.URLSecret,
+			Command:   append([]string{}, hc.Command...),
+		})
+	}
+	dst.Spec.RequireHooks = src.Spec.RequireHooks
+
+	dst.Status = v1beta1.JiraCDCStatus{
+		Phase:               string(src.Status.Phase),
+		Message:             src.Status.Message,
+		LastSyncTime:        src.Status.LastSyncTime,
+		NextScheduledSync:   src.Status.NextScheduledSync,
+		SyncedIssues:        src.Status.SyncedIssues,
+		DiscoveredIssues:    src.Status.DiscoveredIssues,
+		ConsecutiveFailures: src.Status.ConsecutiveFailures,
+		ObservedGeneration:  src.Status.ObservedGeneration,
+		Components:          v1beta1.ComponentStatus(src.Status.Components),
+		DegradedProjects:    src.Status.DegradedProjects,
+		Conditions:          src.Status.Conditions,
+	}
+	for project, ps := range src.Status.ProjectStatus {
+		if dst.Status.ProjectStatus == nil {
+			dst.Status.ProjectStatus = make(map[string]v1beta1.ProjectSyncStatus, len(src.Status.ProjectStatus))
+		}
+		dst.Status.ProjectStatus[project] = v1beta1.ProjectSyncStatus(ps)
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version (v1).
+func (dst *JiraCDC) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta1.JiraCDC)
+	if !ok {
+		return fmt.Errorf("expected v1beta1.JiraCDC but got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Jira.BaseURL = src.Spec.Jira.BaseURL
+	dst.Spec.Jira.AuthMethod = src.Spec.Jira.AuthMethod
+	dst.Spec.Jira.CredentialsSecret = src.Spec.Jira.CredentialsSecret
+	dst.Spec.Jira.BoardID = src.Spec.Jira.BoardID
+	dst.Spec.Jira.FilterID = src.Spec.Jira.FilterID
+	dst.Spec.Jira.TLS = TLSConfig(src.Spec.Jira.TLS)
+	dst.Spec.Jira.Proxy = ProxyConfig(src.Spec.Jira.Proxy)
+	dst.Spec.Jira.APIVersion = src.Spec.Jira.APIVersion
+	if len(src.Spec.Jira.ProjectKeys) > 0 {
+		dst.Spec.Jira.ProjectKey = src.Spec.Jira.ProjectKeys[0]
+	}
+	if len(src.Spec.Jira.ProjectKeys) > 1 {
+		dst.Spec.Jira.ProjectKeys = append([]string{}, src.Spec.Jira.ProjectKeys...)
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[extraProjectKeysAnnotation] = strings.Join(src.Spec.Jira.ProjectKeys[1:], ",")
+	}
+
+	dst.Spec.GitRepository = GitRepositoryConfig{
+		URL:                        src.Spec.GitRepository.URL,
+		Branch:                     src.Spec.GitRepository.Branch,
+		FileLayout:                 src.Spec.GitRepository.FileLayout,
+		CredentialsSecret:          src.Spec.GitRepository.CredentialsSecret,
+		ConflictResolutionStrategy: src.Spec.GitRepository.ConflictResolutionStrategy,
+		AuthorStrategy:             src.Spec.GitRepository.AuthorStrategy,
+		UseLFS:                     src.Spec.GitRepository.UseLFS,
+		CommitBatchSize:            src.Spec.GitRepository.CommitBatchSize,
+		SquashOnReconcile:          src.Spec.GitRepository.SquashOnReconcile,
+		CommitMessageTemplate:      src.Spec.GitRepository.CommitMessageTemplate,
+		CommitMessagePattern:       src.Spec.GitRepository.CommitMessagePattern,
+		AddCommitTrailers:          src.Spec.GitRepository.AddCommitTrailers,
+		IssueTemplate:              src.Spec.GitRepository.IssueTemplate,
+		TypeTemplates:              copyStringMap(src.Spec.GitRepository.TypeTemplates),
+		TypeLayouts:                copyStringMap(src.Spec.GitRepository.TypeLayouts),
+		EpicChildIndex:             src.Spec.GitRepository.EpicChildIndex,
+		MirrorRemotes:              append([]string(nil), src.Spec.GitRepository.MirrorRemotes...),
+		MirrorCredentialsSecrets:   copyStringMap(src.Spec.GitRepository.MirrorCredentialsSecrets),
+		RequireMirrors:             src.Spec.GitRepository.RequireMirrors,
+		TLS:                        TLSConfig(src.Spec.GitRepository.TLS),
+		Proxy:                      ProxyConfig(src.Spec.GitRepository.Proxy),
+		RedactSecrets:              src.Spec.GitRepository.RedactSecrets,
+		DriftPolicy:                src.Spec.GitRepository.DriftPolicy,
+	}
+	for _, rule := range src.Spec.GitRepository.RedactionRules {
+		dst.Spec.GitRepository.RedactionRules = append(dst.Spec.GitRepository.RedactionRules, RedactionRule(rule))
+	}
+	dst.Spec.Storage = StorageConfig{
+		Backend: src.Spec.Storage.Backend,
+		S3:      S3StorageConfig(src.Spec.Storage.S3),
+	}
+	dst.Spec.Sync = SyncConfig{
+		Interval:                 src.Spec.Sync.Interval,
+		Schedule:                 src.Spec.Sync.Schedule,
+		BatchSize:                src.Spec.Sync.BatchSize,
+		MaxProjectConcurrency:    src.Spec.Sync.MaxProjectConcurrency,
+		ResumeFromCheckpoint:     src.Spec.Sync.ResumeFromCheckpoint,
+		Incremental:              src.Spec.Sync.Incremental,
+		IncrementalOverlap:       src.Spec.Sync.IncrementalOverlap,
+		PrioritizeByJiraPriority: src.Spec.Sync.PrioritizeByJiraPriority,
+		Paused:                   src.Spec.Sync.Paused,
+		SyncChangelog:            src.Spec.Sync.SyncChangelog,
+		SyncWorklogs:             src.Spec.Sync.SyncWorklogs,
+		SyncRemoteLinks:          src.Spec.Sync.SyncRemoteLinks,
+		SyncAttachments:          src.Spec.Sync.SyncAttachments,
+		AttachmentConcurrency:    src.Spec.Sync.AttachmentConcurrency,
+		LabelFilter:              append([]string(nil), src.Spec.Sync.LabelFilter...),
+		ComponentFilter:          append([]string(nil), src.Spec.Sync.ComponentFilter...),
+		OperationTimeout:         src.Spec.Sync.OperationTimeout,
+		IgnoreFields:             append([]string(nil), src.Spec.Sync.IgnoreFields...),
+		IgnoreStatuses:           append([]string(nil), src.Spec.Sync.IgnoreStatuses...),
+		RemovedProjectPolicy:     src.Spec.Sync.RemovedProjectPolicy,
+		MaxIssuesPerOperation:    src.Spec.Sync.MaxIssuesPerOperation,
+		AllowLargeSync:           src.Spec.Sync.AllowLargeSync,
+	}
+	for _, fm := range src.Spec.Sync.FieldMapping {
+		dst.Spec.Sync.FieldMapping = append(dst.Spec.Sync.FieldMapping, FieldMap(fm))
+	}
+	dst.Spec.Notifications = nil
+	for _, nc := range src.Spec.Notifications {
+		dst.Spec.Notifications = append(dst.Spec.Notifications, NotificationConfig{
+			Type:            nc.Type,
+			URLSecret:       nc.URLSecret,
+			Events:          append([]string{}, nc.Events...),
+			MessageTemplate: nc.MessageTemplate,
+		})
+	}
+	dst.Spec.Hooks = nil
+	for _, hc := range src.Spec.Hooks {
+		dst.Spec.Hooks = append(dst.Spec.Hooks, HookConfig{
+			Type:      hc.Type,
+			Scope:     hc.Scope,
+			URLSecret: hc.URLSecret,
+			Command:   append([]string{}, hc.Command...),
+		})
+	}
+	dst.Spec.RequireHooks = src.Spec.RequireHooks
+
+	dst.Status = JiraCDCStatus{
+		Phase:               SyncPhase(src.Status.Phase),
+		Message:             src.Status.Message,
+		LastSyncTime:        src.Status.LastSyncTime,
+		NextScheduledSync:   src.Status.NextScheduledSync,
+		SyncedIssues:        src.Status.SyncedIssues,
+		DiscoveredIssues:    src.Status.DiscoveredIssues,
+		ConsecutiveFailures: src.Status.ConsecutiveFailures,
+		ObservedGeneration:  src.Status.ObservedGeneration,
+		Components:          ComponentStatus(src.Status.Components),
+		DegradedProjects:    src.Status.DegradedProjects,
+		Conditions:          src.Status.Conditions,
+	}
+	for project, ps := range src.Status.ProjectStatus {
+		if dst.Status.ProjectStatus == nil {
+			dst.Status.ProjectStatus = make(map[string]ProjectSyncStatus, len(src.Status.ProjectStatus))
+		}
+		dst.Status.ProjectStatus[project] = ProjectSyncStatus(ps)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/chambridge/atlassian-cdc-git/api/v1beta1"
+)
+
+func fullyPopulatedV1() *JiraCDC {
+	now := metav1.Now()
+	return &JiraCDC{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sample",
+			Namespace:   "default",
+			Labels:      map[string]string{"app": "jira-cdc"},
+			Annotations: map[string]string{"team": "platform"},
+		},
+		Spec: JiraCDCSpec{
+			Jira: JiraConfig{
+				BaseURL:           "https://issues.example.com",
+				ProjectKey:        "PROJ",
+				CredentialsSecret: "jira-creds",
+			},
+			GitRepository: GitRepositoryConfig{
+				URL:               "https://git.example.com/mirror.git",
+				Branch:            "main",
+				FileLayout:        "{project}/{type}/{key}.md",
+				CredentialsSecret: "git-creds",
+			},
+			Sync: SyncConfig{
+				Interval:             "10m",
+				BatchSize:            25,
+				ResumeFromCheckpoint: true,
+			},
+		},
+		Status: JiraCDCStatus{
+			Phase:              PhaseSyncing,
+			Message:            "last sync cycle succeeded",
+			LastSyncTime:       &now,
+			SyncedIssues:       42,
+			ObservedGeneration: 3,
+		},
+	}
+}
+
+func TestConversionRoundTripV1(t *testing.T) {
+	src := fullyPopulatedV1()
+
+	hub := &v1beta1.JiraCDC{}
+	if err := src.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	if got, want := hub.Spec.Jira.ProjectKeys, []string{"PROJ"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ProjectKeys = %v, want %v", got, want)
+	}
+
+	back := &JiraCDC{}
+	if err := back.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if !reflect.DeepEqual(src, back) {
+		t.Errorf("round trip mismatch:\n src=%+v\nback=%+v", src, back)
+	}
+}
+
+func TestConversionRoundTripMultiProject(t *testing.T) {
+	hub := &v1beta1.JiraCDC{
+		ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "default"},
+		Spec: v1beta1.JiraCDCSpec{
+			Jira: v1beta1.JiraConfig{
+				BaseURL:           "https://issues.example.com",
+				ProjectKeys:       []string{"PROJ", "OPS", "DOCS"},
+				CredentialsSecret: "jira-creds",
+			},
+		},
+	}
+
+	spoke := &JiraCDC{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if spoke.Spec.Jira.ProjectKey != "PROJ" {
+		t.Fatalf("ProjectKey = %q, want PROJ", spoke.Spec.Jira.ProjectKey)
+	}
+	if got := spoke.Annotations[extraProjectKeysAnnotation]; got != "OPS,DOCS" {
+		t.Fatalf("extra keys annotation = %q, want OPS,DOCS", got)
+	}
+
+	back := &v1beta1.JiraCDC{}
+	if err := spoke.ConvertTo(back); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	if !reflect.DeepEqual(hub.Spec.Jira.ProjectKeys, back.Spec.Jira.ProjectKeys) {
+		t.Errorf("ProjectKeys round trip = %v, want %v", back.Spec.Jira.ProjectKeys, hub.Spec.Jira.ProjectKeys)
+	}
+	if _, ok := back.Annotations[extraProjectKeysAnnotation]; ok {
+		t.Errorf("carrier annotation leaked into hub object")
+	}
+}
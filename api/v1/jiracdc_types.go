@@ -0,0 +1,699 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JiraConfig describes how to reach the source JIRA instance.
+type JiraConfig struct {
+	// BaseURL is the root URL of the JIRA instance, e.g. https://issues.example.com.
+	BaseURL string `json:"baseURL"`
+
+	// ProjectKey is the JIRA project to mirror, e.g. "PROJ".
+	//
+	// Deprecated: use ProjectKeys. A value here is treated as the first
+	// entry of ProjectKeys.
+	// +optional
+	ProjectKey string `json:"projectKey,omitempty"`
+
+	// ProjectKeys lists the JIRA projects to mirror into the repository.
+	// +optional
+	ProjectKeys []string `json:"projectKeys,omitempty"`
+
+	// BoardID selects a JIRA agile board whose issues are mirrored in
+	// addition to (or instead of) whole projects. Boards select issues
+	// by filter and can span several projects; files are namespaced per
+	// project in that case.
+	// +optional
+	BoardID int `json:"boardID,omitempty"`
+
+	// FilterID selects a saved JIRA filter whose matching issues are
+	// mirrored in addition to (or instead of) whole projects. The
+	// filter's JQL is resolved at sync time and re-resolved
+	// periodically, so edits to the saved filter take effect without a
+	// spec change. The configured credentials must be able to view the
+	// filter.
+	// +optional
+	FilterID int `json:"filterID,omitempty"`
+
+	// AuthMethod selects how the client authenticates: "basic" (default,
+	// JIRA Cloud email + API token) or "pat" (JIRA Server/Data Center
+	// personal access token sent as a Bearer header).
+	// +optional
+	AuthMethod string `json:"authMethod,omitempty"`
+
+	// CredentialsSecret names a Secret in the same namespace holding
+	// "email" and "apiToken" keys for basic auth, or a "token" key for
+	// pat auth.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// TLS controls certificate trust for connections to a self-hosted
+	// instance behind an internal CA.
+	// +optional
+	TLS TLSConfig `json:"tls,omitempty"`
+
+	// Proxy routes JIRA requests through an egress proxy, for clusters
+	// whose network policy blocks direct internet access. Unset falls
+	// back to the operator's HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	// environment.
+	// +optional
+	Proxy ProxyConfig `json:"proxy,omitempty"`
+
+	// APIVersion selects the JIRA REST API generation: 2 (default) or
+	// 3 (JIRA Cloud, rich text bodies in ADF).
+	// +optional
+	APIVersion int `json:"apiVersion,omitempty"`
+}
+
+// TLSConfig controls certificate trust for one outbound connection.
+type TLSConfig struct {
+	// CASecret names a Secret in the same namespace holding a "ca.crt"
+	// key with a PEM bundle of CA certificates trusted in addition to
+	// the system roots. Rotating the secret takes effect on the next
+	// reconcile.
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
+
+	// InsecureSkipVerify disables certificate verification entirely.
+	// Strongly discouraged outside throwaway test environments; the
+	// validating webhook warns when it is set.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ProxyConfig routes one outbound connection through an HTTP egress
+// proxy. Credentials may be embedded in the proxy URLs; they are
+// redacted wherever the URLs are echoed in warnings or logs.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL used for plain-HTTP requests, e.g.
+	// "http://squid.corp.example:3128".
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy URL used for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hosts, domain suffixes, and
+	// CIDRs that bypass the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// Projects returns the normalized list of project keys, mapping a
+// legacy single ProjectKey onto the list form.
+func (c *JiraConfig) Projects() []string {
+	if len(c.ProjectKeys) > 0 {
+		return c.ProjectKeys
+	}
+	if c.ProjectKey != "" {
+		return []string{c.ProjectKey}
+	}
+	return nil
+}
+
+// GitRepositoryConfig describes the target git repository for mirrored issues.
+type GitRepositoryConfig struct {
+	// URL is the clone/push URL of the target repository.
+	URL string `json:"url"`
+
+	// Branch is the branch issue files are committed to. Defaults to "main".
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// FileLayout is a path template controlling where issue files are
+	// written, e.g. "{project}/{type}/{key}.md". Supported placeholders
+	// are {project}, {type}, {status}, {statusCategory} and {key}.
+	// Defaults to a flat "{key}.md" layout.
+	// +optional
+	FileLayout string `json:"fileLayout,omitempty"`
+
+	// CredentialsSecret names a Secret holding "username" and "token" keys
+	// used to push to the repository.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// ConflictResolutionStrategy selects how diverged issue files are
+	// reconciled when the remote advances concurrently: "prefer-jira"
+	// (default) rewrites them from JIRA, "prefer-git" keeps the repo
+	// version, "merge" merges front-matter field by field.
+	// +optional
+	ConflictResolutionStrategy string `json:"conflictResolutionStrategy,omitempty"`
+
+	// UseLFS routes attachment paths through Git LFS in the managed
+	// .gitattributes section, keeping large binaries out of the main
+	// object store.
+	// +optional
+	UseLFS bool `json:"useLFS,omitempty"`
+
+	// AuthorStrategy selects whose identity appears as the author of
+	// single-issue commits: "operator" (default, the bot account),
+	// "reporter" or "assignee" takes the JIRA user's display name and
+	// email so git blame reflects who changed the issue. The committer
+	// stays the operator identity for traceability.
+	// +optional
+	AuthorStrategy string `json:"authorStrategy,omitempty"`
+
+	// CommitBatchSize groups this many issue writes into one summarized
+	// commit during sync cycles, keeping commit counts manageable on
+	// large projects. Zero commits each cycle as a single batch.
+	// +optional
+	CommitBatchSize int `json:"commitBatchSize,omitempty"`
+
+	// SquashOnReconcile collapses all commits a sync cycle produced into
+	// one summarized commit before pushing, keeping one commit per
+	// reconcile. Only local commits made during that cycle are rewritten;
+	// anything already pushed stands.
+	// +optional
+	SquashOnReconcile bool `json:"squashOnReconcile,omitempty"`
+
+	// CommitMessageTemplate is a Go text/template rendering the message
+	// of each single-issue commit, e.g. a conventional-commits form like
+	// "{{.Type}}({{.Key}}): {{.ChangeSummary}}". The template executes
+	// with the issue key, project, type, summary, operation and field
+	// changes as its context. Empty uses the built-in messages.
+	// +optional
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty"`
+
+	// CommitMessagePattern is a regular expression every rendered
+	// commit message must match before it is committed, guarding a
+	// custom template against producing messages that violate repository
+	// conventions. Empty disables the check.
+	// +optional
+	CommitMessagePattern string `json:"commitMessagePattern,omitempty"`
+
+	// AddCommitTrailers appends Jira-Issue and Jira-URL trailers to
+	// mirrored commit messages, linking each commit back to the issues
+	// it touched for tools that parse trailers (git interpret-trailers,
+	// CI integrations). Batched commits carry one trailer pair per
+	// issue.
+	// +optional
+	AddCommitTrailers bool `json:"addCommitTrailers,omitempty"`
+
+	// IssueTemplate is a Go text/template rendering each issue file
+	// from its issue data, replacing the built-in markdown layout.
+	// Templates that drop the YAML front-matter lose change diffing and
+	// API read-back for the affected files. Empty uses the built-in
+	// layout.
+	// +optional
+	IssueTemplate string `json:"issueTemplate,omitempty"`
+
+	// TypeTemplates overrides IssueTemplate per JIRA issue type name,
+	// e.g. a dedicated layout for "Epic" files. Issue types without an
+	// entry fall back to IssueTemplate or the built-in layout.
+	// +optional
+	TypeTemplates map[string]string `json:"typeTemplates,omitempty"`
+
+	// TypeLayouts overrides FileLayout per JIRA issue type name, routing
+	// e.g. bugs and epics into different subdirectories. An issue whose
+	// type changes is moved to its new location on the next sync.
+	// +optional
+	TypeLayouts map[string]string `json:"typeLayouts,omitempty"`
+
+	// EpicChildIndex generates an index file next to each epic linking
+	// the issues assigned to it, regenerated as children change.
+	// +optional
+	EpicChildIndex bool `json:"epicChildIndex,omitempty"`
+
+	// MirrorRemotes lists additional push URLs the branch is mirrored
+	// to after each primary push, e.g. a backup remote.
+	// +optional
+	MirrorRemotes []string `json:"mirrorRemotes,omitempty"`
+
+	// MirrorCredentialsSecrets maps a mirror URL to a Secret holding
+	// "username" and "token" keys for that remote. Mirrors without an
+	// entry reuse the primary CredentialsSecret.
+	// +optional
+	MirrorCredentialsSecrets map[string]string `json:"mirrorCredentialsSecrets,omitempty"`
+
+	// RequireMirrors fails the sync when a mirror push fails, instead
+	// of recording it as a warning.
+	// +optional
+	RequireMirrors bool `json:"requireMirrors,omitempty"`
+
+	// TLS controls certificate trust for connections to a self-hosted
+	// git server behind an internal CA.
+	// +optional
+	TLS TLSConfig `json:"tls,omitempty"`
+
+	// Proxy routes clone, fetch, and push traffic through an egress
+	// proxy. Only applies to http(s) remotes; unset falls back to the
+	// operator's HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment.
+	// +optional
+	Proxy ProxyConfig `json:"proxy,omitempty"`
+
+	// RedactSecrets applies built-in redaction rules for common secret
+	// shapes (cloud keys, service tokens, private key blocks) to issue
+	// content before it is committed.
+	// +optional
+	RedactSecrets bool `json:"redactSecrets,omitempty"`
+
+	// RedactionRules lists custom redaction patterns applied to issue
+	// content after the built-in rules, in order.
+	// +optional
+	RedactionRules []RedactionRule `json:"redactionRules,omitempty"`
+
+	// DriftPolicy selects how manually edited issue files are handled
+	// at the start of a sync cycle: "overwrite" (default, re-sync with a
+	// warning), "skip" (leave untouched with a warning) or "fail"
+	// (abort the cycle until resolved).
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+}
+
+// RedactionRule replaces matches of a regular expression in issue
+// content before it is committed.
+type RedactionRule struct {
+	// Pattern is an RE2 regular expression. Patterns that match the
+	// empty string are rejected.
+	Pattern string `json:"pattern"`
+
+	// Replacement is substituted for each match, supporting $1-style
+	// group references. Empty uses "[REDACTED]".
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// FieldMap maps a JIRA field ID onto a front-matter key with a value
+// type used for coercion.
+type FieldMap struct {
+	// ID is the JIRA field ID, e.g. "customfield_10016".
+	ID string `json:"id"`
+	// Name is the front-matter key the value is emitted under.
+	Name string `json:"name"`
+	// Type is the value type: "string", "number", "array" or "date".
+	Type string `json:"type"`
+}
+
+// Supported FieldMap types.
+const (
+	FieldTypeString = "string"
+	FieldTypeNumber = "number"
+	FieldTypeArray  = "array"
+	FieldTypeDate   = "date"
+)
+
+// SyncConfig tunes the synchronization behaviour.
+type SyncConfig struct {
+	// Interval between polling sync cycles, e.g. "5m". Defaults to "5m".
+	// Ignored when Schedule is set.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Schedule is a five-field cron expression controlling when sync
+	// cycles run, e.g. "*/15 9-17 * * 1-5". When set it takes precedence
+	// over Interval.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// BatchSize is the page size used when fetching issues from JIRA.
+	// Defaults to 50.
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// MaxProjectConcurrency bounds how many projects sync concurrently
+	// during a full cycle. Defaults to 1 (serial). Values above 1
+	// disable checkpoint/resume, which encodes a serial scan order.
+	// +optional
+	MaxProjectConcurrency int `json:"maxProjectConcurrency,omitempty"`
+
+	// ResumeFromCheckpoint resumes an interrupted bootstrap from the
+	// last persisted checkpoint instead of rescanning the project.
+	// +optional
+	ResumeFromCheckpoint bool `json:"resumeFromCheckpoint,omitempty"`
+
+	// Incremental scopes sync cycles after the initial bootstrap to
+	// issues updated since the previous cycle's watermark, instead of
+	// rescanning every project in full. The watermark is the highest
+	// updated timestamp JIRA reported, not wall-clock time, and each
+	// window re-covers incrementalOverlap before it so updates landing
+	// while the previous scan ran are not lost. Deleted-issue cleanup
+	// only runs on full cycles.
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+
+	// IncrementalOverlap is how far each incremental window reaches
+	// back before the previous watermark, as a Go duration string, e.g.
+	// "2m". Defaults to "1m".
+	// +optional
+	IncrementalOverlap string `json:"incrementalOverlap,omitempty"`
+
+	// PrioritizeByJiraPriority processes project scans through a
+	// priority queue ordered by each issue's JIRA priority, so critical
+	// issues reach the mirror before backlog items. Low-priority issues
+	// are aged forward periodically rather than starved. Prioritized
+	// scans are not checkpointed and rescan from the start when
+	// interrupted.
+	// +optional
+	PrioritizeByJiraPriority bool `json:"prioritizeByJiraPriority,omitempty"`
+
+	// Paused suspends sync cycles while leaving the resource and its
+	// mirrored data in place. In-flight work finishes; no new cycles
+	// start until Paused is cleared.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// FieldMapping selects JIRA custom fields to mirror into issue
+	// front-matter. Unmapped custom fields are omitted.
+	// +optional
+	FieldMapping []FieldMap `json:"fieldMapping,omitempty"`
+
+	// SyncChangelog mirrors each issue's field transition history into a
+	// History section of its file, at the cost of one extra JIRA request
+	// per issue.
+	// +optional
+	SyncChangelog bool `json:"syncChangelog,omitempty"`
+
+	// SyncWorklogs mirrors each issue's worklog entries and aggregated
+	// time tracking into a Work Log section of its file, at the cost of
+	// one extra JIRA request per issue.
+	// +optional
+	SyncWorklogs bool `json:"syncWorklogs,omitempty"`
+
+	// SyncRemoteLinks mirrors each issue's remote links, such as
+	// Confluence pages or issues in other JIRA instances, into a Remote
+	// Links section of its file, at the cost of one extra JIRA request
+	// per issue.
+	// +optional
+	SyncRemoteLinks bool `json:"syncRemoteLinks,omitempty"`
+
+	// SyncAttachments mirrors each issue's file attachments into the
+	// repository, at the cost of one download per attachment.
+	// +optional
+	SyncAttachments bool `json:"syncAttachments,omitempty"`
+
+	// AttachmentConcurrency bounds how many of one issue's attachments
+	// download in parallel. Defaults to 4.
+	// +optional
+	AttachmentConcurrency int `json:"attachmentConcurrency,omitempty"`
+
+	// LabelFilter restricts sync to issues carrying at least one of the
+	// listed labels. Issues that stop matching are cleaned up as
+	// orphaned.
+	// +optional
+	LabelFilter []string `json:"labelFilter,omitempty"`
+
+	// ComponentFilter restricts sync to issues in at least one of the
+	// listed components.
+	// +optional
+	ComponentFilter []string `json:"componentFilter,omitempty"`
+
+	// OperationTimeout bounds one full sync operation as a Go duration
+	// string, e.g. "90m". When it elapses the operation's context is
+	// cancelled, every in-flight task stops, and the cycle is reported
+	// failed with a timeout error. Empty uses a generous built-in
+	// default; individual JIRA calls carry their own shorter client
+	// timeout nested inside this one.
+	// +optional
+	OperationTimeout string `json:"operationTimeout,omitempty"`
+
+	// IgnoreFields lists fields excluded from change detection, e.g. a
+	// noisy "last viewed" custom field. An update touching only ignored
+	// fields produces no commit. Entries name standard diff fields
+	// (status, assignee, ...) or mapped custom field names.
+	// +optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+
+	// IgnoreStatuses skips issues in the listed statuses entirely; their
+	// files are removed as orphaned on the next full cycle.
+	// +optional
+	IgnoreStatuses []string `json:"ignoreStatuses,omitempty"`
+
+	// RemovedProjectPolicy selects what happens to the mirrored files
+	// of a project removed from projectKeys: "keep" (default) leaves
+	// them in place, "archive" moves them under the repository's
+	// archive/ directory with a final commit, "delete" removes them.
+	// The validating webhook rejects removing a project key while no
+	// policy is set, guarding against accidental data loss.
+	// +optional
+	RemovedProjectPolicy string `json:"removedProjectPolicy,omitempty"`
+
+	// MaxIssuesPerOperation caps how many issues one sync operation may
+	// cover. The count in scope is checked before any issue bodies are
+	// fetched; exceeding the cap fails the operation with a
+	// ConfigInvalid event, protecting both the operator and JIRA from a
+	// misconfigured query matching far more than intended. Zero
+	// disables the cap.
+	// +optional
+	MaxIssuesPerOperation int `json:"maxIssuesPerOperation,omitempty"`
+
+	// AllowLargeSync lets an operation proceed past
+	// MaxIssuesPerOperation. The discovered count is still reported in
+	// status.
+	// +optional
+	AllowLargeSync bool `json:"allowLargeSync,omitempty"`
+}
+
+// NotificationConfig configures one external notification target for
+// sync lifecycle events.
+type NotificationConfig struct {
+	// Type selects the notifier: "slack" or "webhook".
+	Type string `json:"type"`
+
+	// URLSecret names a Secret in the same namespace holding a "url" key
+	// with the target endpoint, keeping webhook URLs out of the spec.
+	URLSecret string `json:"urlSecret"`
+
+	// Events filters which outcomes are posted: "completed", "failed".
+	// Empty means all.
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// MessageTemplate overrides the default notification text. Only used
+	// by the slack type.
+	// +optional
+	MessageTemplate string `json:"messageTemplate,omitempty"`
+}
+
+// Notification types.
+const (
+	NotifierSlack   = "slack"
+	NotifierWebhook = "webhook"
+)
+
+// HookConfig configures one post-sync processing hook, invoked after
+// issues are mirrored so downstream automation — a CI pipeline, an
+// agent execution environment rebuilding its context — can react
+// without polling the repository. Hooks receive the issue key and the
+// commit hash containing its write.
+type HookConfig struct {
+	// Type selects the hook: "webhook" posts the event as JSON to an
+	// HTTP endpoint, "command" runs a local executable.
+	Type string `json:"type"`
+
+	// Scope selects when the hook fires: "issue" (default) invokes it
+	// once per synced issue, "operation" once per completed sync
+	// operation.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// URLSecret names a Secret in the same namespace holding a "url"
+	// key with the target endpoint; required for the webhook type.
+	// +optional
+	URLSecret string `json:"urlSecret,omitempty"`
+
+	// Command is the argv run for the command type. It is executed
+	// directly without a shell, with a minimal environment carrying the
+	// event in JIRA_CDC_* variables and a throwaway working directory.
+	// +optional
+	Command []string `json:"command,omitempty"`
+}
+
+// Hook types and scopes.
+const (
+	HookWebhook = "webhook"
+	HookCommand = "command"
+
+	HookScopeIssue     = "issue"
+	HookScopeOperation = "operation"
+)
+
+// SyncPhase describes the coarse lifecycle state of a JiraCDC resource.
+type SyncPhase string
+
+const (
+	PhasePending       SyncPhase = "Pending"
+	PhaseBootstrapping SyncPhase = "Bootstrapping"
+	PhaseSyncing       SyncPhase = "Syncing"
+	PhasePaused        SyncPhase = "Paused"
+	PhaseFailed        SyncPhase = "Failed"
+)
+
+// JiraCDCSpec defines the desired state of JiraCDC.
+type JiraCDCSpec struct {
+	Jira          JiraConfig          `json:"jira"`
+	GitRepository GitRepositoryConfig `json:"gitRepository"`
+	// Storage selects the backend mirrored issue files are written to.
+	// Empty defaults to the git repository.
+	// +optional
+	Storage StorageConfig `json:"storage,omitempty"`
+	// +optional
+	Sync SyncConfig `json:"sync,omitempty"`
+	// Notifications lists external targets notified when sync cycles
+	// complete or fail.
+	// +optional
+	Notifications []NotificationConfig `json:"notifications,omitempty"`
+	// Hooks lists post-sync processing hooks invoked after issues are
+	// mirrored.
+	// +optional
+	Hooks []HookConfig `json:"hooks,omitempty"`
+	// RequireHooks escalates hook failures to sync failures; by default
+	// they only surface as warning events.
+	// +optional
+	RequireHooks bool `json:"requireHooks,omitempty"`
+}
+
+// Storage backend names used in StorageConfig.
+const (
+	StorageBackendGit = "git"
+	StorageBackendS3  = "s3"
+)
+
+// StorageConfig selects where mirrored issue files are durably stored.
+// The git repository remains the default; the s3 backend writes each
+// issue as one object instead, for consumers reading from object
+// storage rather than a git checkout.
+type StorageConfig struct {
+	// Backend is the storage backend: "git" (default) or "s3".
+	// +optional
+	Backend string `json:"backend,omitempty"`
+	// S3 configures the s3 backend; required when Backend is "s3".
+	// +optional
+	S3 S3StorageConfig `json:"s3,omitempty"`
+}
+
+// S3StorageConfig configures the s3 storage backend.
+type S3StorageConfig struct {
+	// Bucket is the bucket issue objects are written into.
+	Bucket string `json:"bucket"`
+	// Prefix is an optional key prefix all objects are written under.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the bucket's AWS region, used for request signing.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Endpoint overrides the AWS S3 endpoint for S3-compatible stores.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialsSecret names a secret in the resource's namespace
+	// holding accessKeyId and secretAccessKey keys.
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// ComponentStatus reports the health of the downstream components a
+// sync depends on. Values are "ok" or "degraded".
+type ComponentStatus struct {
+	// +optional
+	JiraConnection string `json:"jiraConnection,omitempty"`
+	// +optional
+	GitRepository string `json:"gitRepository,omitempty"`
+}
+
+// Component health values used in ComponentStatus.
+const (
+	ComponentHealthy  = "ok"
+	ComponentDegraded = "degraded"
+)
+
+// ProjectSyncStatus reports one project's outcome from the last sync
+// cycle. Health values follow ComponentStatus: "ok" or "degraded".
+type ProjectSyncStatus struct {
+	// LastSyncTime is when the project's last scan finished.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// SyncedIssues is the number of issues the last scan wrote.
+	// +optional
+	SyncedIssues int `json:"syncedIssues,omitempty"`
+
+	// Health is "ok" when the last scan completed, "degraded" when it
+	// failed part way.
+	// +optional
+	Health string `json:"health,omitempty"`
+
+	// Message carries the failure text of a degraded scan.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// JiraCDCStatus defines the observed state of JiraCDC.
+type JiraCDCStatus struct {
+	// +optional
+	Phase SyncPhase `json:"phase,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastSyncTime is when the last successful sync cycle finished.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// NextScheduledSync is when the next cron-scheduled sync cycle will
+	// run. Only set when spec.sync.schedule is configured.
+	// +optional
+	NextScheduledSync *metav1.Time `json:"nextScheduledSync,omitempty"`
+
+	// SyncedIssues is the number of issues currently mirrored into git.
+	// +optional
+	SyncedIssues int `json:"syncedIssues,omitempty"`
+
+	// DiscoveredIssues is the issue count the last operation's
+	// pre-flight cap check found in scope. Only set when
+	// spec.sync.maxIssuesPerOperation is configured.
+	// +optional
+	DiscoveredIssues int `json:"discoveredIssues,omitempty"`
+
+	// ConsecutiveFailures counts sync cycles that have failed in a row,
+	// driving the reconciler's widening retry backoff. Reset to zero by
+	// the next successful cycle.
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Components reports per-component connection health.
+	// +optional
+	Components ComponentStatus `json:"components,omitempty"`
+
+	// ProjectStatus reports per-project sync health, keyed by project
+	// key. Projects removed from the spec are pruned on the next cycle.
+	// +optional
+	ProjectStatus map[string]ProjectSyncStatus `json:"projectStatus,omitempty"`
+
+	// DegradedProjects counts projects whose last scan failed, backing
+	// the kubectl Degraded column.
+	// +optional
+	DegradedProjects int `json:"degradedProjects,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.jira.projectKey`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Degraded",type=integer,JSONPath=`.status.degradedProjects`
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+
+// JiraCDC is the Schema for the jiracdcs API.
+type JiraCDC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JiraCDCSpec   `json:"spec,omitempty"`
+	Status JiraCDCStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JiraCDCList contains a list of JiraCDC.
+type JiraCDCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JiraCDC `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JiraCDC{}, &JiraCDCList{})
+}
@@ -0,0 +1,532 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JiraConfig describes how to reach the source JIRA instance.
+type JiraConfig struct {
+	// BaseURL is the root URL of the JIRA instance.
+	BaseURL string `json:"baseURL"`
+
+	// ProjectKeys lists the JIRA projects to mirror. v1beta1 supports
+	// mirroring several projects into one repository; v1 resources map
+	// their single projectKey onto the first entry.
+	ProjectKeys []string `json:"projectKeys"`
+
+	// BoardID selects a JIRA agile board whose issues are mirrored in
+	// addition to (or instead of) whole projects. Boards select issues
+	// by filter and can span several projects; files are namespaced per
+	// project in that case.
+	// +optional
+	BoardID int `json:"boardID,omitempty"`
+
+	// FilterID selects a saved JIRA filter whose matching issues are
+	// mirrored in addition to (or instead of) whole projects.
+	// +optional
+	FilterID int `json:"filterID,omitempty"`
+
+	// AuthMethod selects how the client authenticates: "basic" (default)
+	// or "pat" for JIRA Server/Data Center personal access tokens.
+	// +optional
+	AuthMethod string `json:"authMethod,omitempty"`
+
+	// CredentialsSecret names a Secret in the same namespace holding
+	// "email" and "apiToken" keys for basic auth, or a "token" key for
+	// pat auth.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// TLS controls certificate trust for connections to a self-hosted
+	// instance behind an internal CA.
+	// +optional
+	TLS TLSConfig `json:"tls,omitempty"`
+
+	// Proxy routes JIRA requests through an egress proxy. Unset falls
+	// back to the operator's proxy environment variables.
+	// +optional
+	Proxy ProxyConfig `json:"proxy,omitempty"`
+
+	// APIVersion selects the JIRA REST API generation: 2 (default) or
+	// 3 (JIRA Cloud, rich text bodies in ADF).
+	// +optional
+	APIVersion int `json:"apiVersion,omitempty"`
+}
+
+// TLSConfig controls certificate trust for one outbound connection.
+type TLSConfig struct {
+	// CASecret names a Secret in the same namespace holding a "ca.crt"
+	// key with a PEM bundle of CA certificates trusted in addition to
+	// the system roots. Rotating the secret takes effect on the next
+	// reconcile.
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
+
+	// InsecureSkipVerify disables certificate verification entirely.
+	// Strongly discouraged outside throwaway test environments; the
+	// validating webhook warns when it is set.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ProxyConfig routes one outbound connection through an HTTP egress
+// proxy.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL used for plain-HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy URL used for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hosts, domain suffixes, and
+	// CIDRs that bypass the proxy.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// GitRepositoryConfig describes the target git repository for mirrored issues.
+type GitRepositoryConfig struct {
+	// URL is the clone/push URL of the target repository.
+	URL string `json:"url"`
+
+	// Branch is the branch issue files are committed to. Defaults to "main".
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// FileLayout is a path template controlling where issue files are
+	// written, e.g. "{project}/{type}/{key}.md".
+	// +optional
+	FileLayout string `json:"fileLayout,omitempty"`
+
+	// CredentialsSecret names a Secret holding "username" and "token"
+	// keys used to push to the repository.
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// ConflictResolutionStrategy selects how diverged issue files are
+	// reconciled when the remote advances concurrently: "prefer-jira"
+	// (default), "prefer-git", or "merge".
+	// +optional
+	ConflictResolutionStrategy string `json:"conflictResolutionStrategy,omitempty"`
+
+	// UseLFS routes attachment paths through Git LFS in the managed
+	// .gitattributes section, keeping large binaries out of the main
+	// object store.
+	// +optional
+	UseLFS bool `json:"useLFS,omitempty"`
+
+	// AuthorStrategy selects whose identity appears as the author of
+	// single-issue commits: "operator" (default, the bot account),
+	// "reporter" or "assignee" takes the JIRA user's display name and
+	// email so git blame reflects who changed the issue. The committer
+	// stays the operator identity for traceability.
+	// +optional
+	AuthorStrategy string `json:"authorStrategy,omitempty"`
+
+	// CommitBatchSize groups this many issue writes into one summarized
+	// commit during sync cycles. Zero commits each cycle as a single
+	// batch.
+	// +optional
+	CommitBatchSize int `json:"commitBatchSize,omitempty"`
+
+	// SquashOnReconcile collapses all commits a sync cycle produced into
+	// one summarized commit before pushing.
+	// +optional
+	SquashOnReconcile bool `json:"squashOnReconcile,omitempty"`
+
+	// CommitMessageTemplate is a Go text/template rendering the message
+	// of each single-issue commit, replacing the built-in messages.
+	// +optional
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty"`
+
+	// CommitMessagePattern is a regular expression every rendered
+	// commit message must match. Empty disables the check.
+	// +optional
+	CommitMessagePattern string `json:"commitMessagePattern,omitempty"`
+
+	// AddCommitTrailers appends Jira-Issue and Jira-URL trailers to
+	// mirrored commit messages.
+	// +optional
+	AddCommitTrailers bool `json:"addCommitTrailers,omitempty"`
+
+	// IssueTemplate is a Go text/template rendering each issue file
+	// from its issue data, replacing the built-in markdown layout.
+	// Empty uses the built-in layout.
+	// +optional
+	IssueTemplate string `json:"issueTemplate,omitempty"`
+
+	// TypeTemplates overrides IssueTemplate per JIRA issue type name.
+	// +optional
+	TypeTemplates map[string]string `json:"typeTemplates,omitempty"`
+
+	// TypeLayouts overrides FileLayout per JIRA issue type name.
+	// +optional
+	TypeLayouts map[string]string `json:"typeLayouts,omitempty"`
+
+	// EpicChildIndex generates an index file next to each epic linking
+	// the issues assigned to it.
+	// +optional
+	EpicChildIndex bool `json:"epicChildIndex,omitempty"`
+
+	// MirrorRemotes lists additional push URLs the branch is mirrored
+	// to after each primary push.
+	// +optional
+	MirrorRemotes []string `json:"mirrorRemotes,omitempty"`
+
+	// MirrorCredentialsSecrets maps a mirror URL to a Secret holding
+	// "username" and "token" keys for that remote.
+	// +optional
+	MirrorCredentialsSecrets map[string]string `json:"mirrorCredentialsSecrets,omitempty"`
+
+	// RequireMirrors fails the sync when a mirror push fails.
+	// +optional
+	RequireMirrors bool `json:"requireMirrors,omitempty"`
+
+	// TLS controls certificate trust for connections to a self-hosted
+	// git server behind an internal CA.
+	// +optional
+	TLS TLSConfig `json:"tls,omitempty"`
+
+	// Proxy routes clone, fetch, and push traffic through an egress
+	// proxy. Only applies to http(s) remotes.
+	// +optional
+	Proxy ProxyConfig `json:"proxy,omitempty"`
+
+	// RedactSecrets applies built-in redaction rules for common secret
+	// shapes to issue content before it is committed.
+	// +optional
+	RedactSecrets bool `json:"redactSecrets,omitempty"`
+
+	// RedactionRules lists custom redaction patterns applied to issue
+	// content after the built-in rules, in order.
+	// +optional
+	RedactionRules []RedactionRule `json:"redactionRules,omitempty"`
+
+	// DriftPolicy selects how manually edited issue files are handled
+	// at the start of a sync cycle: "overwrite" (default, re-sync with a
+	// warning), "skip" (leave untouched with a warning) or "fail"
+	// (abort the cycle until resolved).
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+}
+
+// RedactionRule replaces matches of a regular expression in issue
+// content before it is committed.
+type RedactionRule struct {
+	// Pattern is an RE2 regular expression. Patterns that match the
+	// empty string are rejected.
+	Pattern string `json:"pattern"`
+
+	// Replacement is substituted for each match, supporting $1-style
+	// group references. Empty uses "[REDACTED]".
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// FieldMap maps a JIRA field ID onto a front-matter key with a value
+// type used for coercion.
+type FieldMap struct {
+	// ID is the JIRA field ID, e.g. "customfield_10016".
+	ID string `json:"id"`
+	// Name is the front-matter key the value is emitted under.
+	Name string `json:"name"`
+	// Type is the value type: "string", "number", "array" or "date".
+	Type string `json:"type"`
+}
+
+// SyncConfig tunes the synchronization behaviour.
+type SyncConfig struct {
+	// Interval between polling sync cycles, e.g. "5m". Ignored when
+	// Schedule is set.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Schedule is a five-field cron expression controlling when sync
+	// cycles run. When set it takes precedence over Interval.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// BatchSize is the page size used when fetching issues from JIRA.
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// MaxProjectConcurrency bounds how many projects sync concurrently
+	// during a full cycle. Defaults to 1 (serial). Values above 1
+	// disable checkpoint/resume, which encodes a serial scan order.
+	// +optional
+	MaxProjectConcurrency int `json:"maxProjectConcurrency,omitempty"`
+
+	// ResumeFromCheckpoint resumes an interrupted bootstrap from the
+	// last persisted checkpoint instead of rescanning the project.
+	// +optional
+	ResumeFromCheckpoint bool `json:"resumeFromCheckpoint,omitempty"`
+
+	// Incremental scopes cycles after bootstrap to issues updated since
+	// the previous cycle's watermark.
+	// +optional
+	Incremental bool `json:"incremental,omitempty"`
+
+	// IncrementalOverlap is how far each incremental window reaches
+	// back before the watermark, e.g. "2m". Defaults to "1m".
+	// +optional
+	IncrementalOverlap string `json:"incrementalOverlap,omitempty"`
+
+	// PrioritizeByJiraPriority syncs issues most urgent first instead
+	// of in fetch order.
+	// +optional
+	PrioritizeByJiraPriority bool `json:"prioritizeByJiraPriority,omitempty"`
+
+	// Paused suspends sync cycles while leaving the resource and its
+	// mirrored data in place.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// FieldMapping selects JIRA custom fields to mirror into issue
+	// front-matter. Unmapped custom fields are omitted.
+	// +optional
+	FieldMapping []FieldMap `json:"fieldMapping,omitempty"`
+
+	// SyncChangelog mirrors each issue's field transition history into a
+	// History section of its file.
+	// +optional
+	SyncChangelog bool `json:"syncChangelog,omitempty"`
+
+	// SyncWorklogs mirrors each issue's worklog entries and aggregated
+	// time tracking into a Work Log section of its file.
+	// +optional
+	SyncWorklogs bool `json:"syncWorklogs,omitempty"`
+
+	// SyncRemoteLinks mirrors each issue's remote links into a Remote
+	// Links section of its file.
+	// +optional
+	SyncRemoteLinks bool `json:"syncRemoteLinks,omitempty"`
+
+	// SyncAttachments mirrors each issue's file attachments into the
+	// repository.
+	// +optional
+	SyncAttachments bool `json:"syncAttachments,omitempty"`
+
+	// AttachmentConcurrency bounds parallel attachment downloads per
+	// issue.
+	// +optional
+	AttachmentConcurrency int `json:"attachmentConcurrency,omitempty"`
+
+	// LabelFilter restricts sync to issues carrying at least one of the
+	// listed labels. Issues that stop matching are cleaned up as
+	// orphaned.
+	// +optional
+	LabelFilter []string `json:"labelFilter,omitempty"`
+
+	// ComponentFilter restricts sync to issues in at least one of the
+	// listed components.
+	// +optional
+	ComponentFilter []string `json:"componentFilter,omitempty"`
+
+	// OperationTimeout bounds one full sync operation as a Go duration
+	// string. Empty uses a generous built-in default.
+	// +optional
+	OperationTimeout string `json:"operationTimeout,omitempty"`
+
+	// IgnoreFields lists fields excluded from change detection.
+	// +optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+
+	// IgnoreStatuses skips issues in the listed statuses entirely.
+	// +optional
+	IgnoreStatuses []string `json:"ignoreStatuses,omitempty"`
+
+	// RemovedProjectPolicy selects what happens to the mirrored files
+	// of a project removed from projectKeys: "keep" (default),
+	// "archive" or "delete".
+	// +optional
+	RemovedProjectPolicy string `json:"removedProjectPolicy,omitempty"`
+
+	// MaxIssuesPerOperation fails an operation before fetching issue
+	// bodies when more issues than this are in scope. Zero disables.
+	// +optional
+	MaxIssuesPerOperation int `json:"maxIssuesPerOperation,omitempty"`
+
+	// AllowLargeSync proceeds past MaxIssuesPerOperation.
+	// +optional
+	AllowLargeSync bool `json:"allowLargeSync,omitempty"`
+}
+
+// NotificationConfig configures one external notification target for
+// sync lifecycle events.
+type NotificationConfig struct {
+	// Type selects the notifier: "slack" or "webhook".
+	Type string `json:"type"`
+
+	// URLSecret names a Secret holding a "url" key with the target
+	// endpoint.
+	URLSecret string `json:"urlSecret"`
+
+	// Events filters which outcomes are posted. Empty means all.
+	// +optional
+	Events []string `json:"events,omitempty"`
+
+	// MessageTemplate overrides the default notification text.
+	// +optional
+	MessageTemplate string `json:"messageTemplate,omitempty"`
+}
+
+// HookConfig configures one post-sync processing hook.
+type HookConfig struct {
+	// Type selects the hook: "webhook" or "command".
+	Type string `json:"type"`
+
+	// Scope is "issue" (default) or "operation".
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// URLSecret names a Secret holding a "url" key; required for the
+	// webhook type.
+	// +optional
+	URLSecret string `json:"urlSecret,omitempty"`
+
+	// Command is the argv run for the command type, without a shell.
+	// +optional
+	Command []string `json:"command,omitempty"`
+}
+
+// JiraCDCSpec defines the desired state of JiraCDC.
+type JiraCDCSpec struct {
+	Jira          JiraConfig          `json:"jira"`
+	GitRepository GitRepositoryConfig `json:"gitRepository"`
+	// Storage selects the backend issue files are written to; empty
+	// defaults to the git repository.
+	// +optional
+	Storage StorageConfig `json:"storage,omitempty"`
+	// +optional
+	Sync SyncConfig `json:"sync,omitempty"`
+	// Notifications lists external targets notified when sync cycles
+	// complete or fail.
+	// +optional
+	Notifications []NotificationConfig `json:"notifications,omitempty"`
+	// Hooks lists post-sync processing hooks invoked after issues are
+	// mirrored.
+	// +optional
+	Hooks []HookConfig `json:"hooks,omitempty"`
+	// RequireHooks escalates hook failures to sync failures.
+	// +optional
+	RequireHooks bool `json:"requireHooks,omitempty"`
+}
+
+// StorageConfig selects where mirrored issue files are durably stored:
+// the git repository (default) or an S3 bucket.
+type StorageConfig struct {
+	// +optional
+	Backend string `json:"backend,omitempty"`
+	// +optional
+	S3 S3StorageConfig `json:"s3,omitempty"`
+}
+
+// S3StorageConfig configures the s3 storage backend.
+type S3StorageConfig struct {
+	Bucket string `json:"bucket"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// +optional
+	Endpoint          string `json:"endpoint,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret"`
+}
+
+// ComponentStatus reports the health of the downstream components a
+// sync depends on. Values are "ok" or "degraded".
+type ComponentStatus struct {
+	// +optional
+	JiraConnection string `json:"jiraConnection,omitempty"`
+	// +optional
+	GitRepository string `json:"gitRepository,omitempty"`
+}
+
+// ProjectSyncStatus reports one project's outcome from the last sync
+// cycle. Health is "ok" or "degraded".
+type ProjectSyncStatus struct {
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	SyncedIssues int `json:"syncedIssues,omitempty"`
+	// +optional
+	Health string `json:"health,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// JiraCDCStatus defines the observed state of JiraCDC.
+type JiraCDCStatus struct {
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// NextScheduledSync is when the next cron-scheduled sync cycle will
+	// run.
+	// +optional
+	NextScheduledSync *metav1.Time `json:"nextScheduledSync,omitempty"`
+
+	// +optional
+	SyncedIssues int `json:"syncedIssues,omitempty"`
+
+	// DiscoveredIssues is the count the pre-flight cap check found.
+	// +optional
+	DiscoveredIssues int `json:"discoveredIssues,omitempty"`
+
+	// ConsecutiveFailures counts sync cycles that have failed in a row.
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Components reports per-component connection health.
+	// +optional
+	Components ComponentStatus `json:"components,omitempty"`
+
+	// ProjectStatus reports per-project sync health, keyed by project
+	// key.
+	// +optional
+	ProjectStatus map[string]ProjectSyncStatus `json:"projectStatus,omitempty"`
+
+	// DegradedProjects counts projects whose last scan failed.
+	// +optional
+	DegradedProjects int `json:"degradedProjects,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// JiraCDC is the Schema for the jiracdcs API.
+type JiraCDC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JiraCDCSpec   `json:"spec,omitempty"`
+	Status JiraCDCStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JiraCDCList contains a list of JiraCDC.
+type JiraCDCList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JiraCDC `json:"items"`
+}
+
+// Hub marks v1beta1 as the conversion hub.
+func (*JiraCDC) Hub() {}
+
+func init() {
+	SchemeBuilder.Register(&JiraCDC{}, &JiraCDCList{})
+}
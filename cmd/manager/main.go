@@ -0,0 +1,218 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	cdcv1beta1 "github.com/chambridge/atlassian-cdc-git/api/v1beta1"
+	"github.com/chambridge/atlassian-cdc-git/internal/api"
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/controller"
+	"github.com/chambridge/atlassian-cdc-git/internal/cron"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/events"
+	cdcmetrics "github.com/chambridge/atlassian-cdc-git/internal/metrics"
+	cdcsync "github.com/chambridge/atlassian-cdc-git/internal/sync"
+	"github.com/chambridge/atlassian-cdc-git/internal/webhooks"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cdcv1.AddToScheme(scheme))
+	utilruntime.Must(cdcv1beta1.AddToScheme(scheme))
+}
+
+func main() {
+	var metricsAddr string
+	var apiAddr string
+	var probeAddr string
+	var enableLeaderElection bool
+	var enableWebhooks bool
+	var enablePreflight bool
+	var enableAPIAuth bool
+	var maintenanceSchedule string
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&apiAddr, "api-bind-address", ":8090", "The address the REST API binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+		"Enable leader election for controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"Enable admission webhooks (disable for local development).")
+	flag.BoolVar(&enablePreflight, "enable-preflight-validation", false,
+		"Validate JIRA and git connectivity during admission of JiraCDC resources.")
+	flag.BoolVar(&enableAPIAuth, "enable-api-auth", false,
+		"Require bearer tokens (validated via TokenReview) on REST API requests.")
+	flag.StringVar(&maintenanceSchedule, "maintenance-schedule", "",
+		"Cron schedule for automatic task pruning and repository repacking (empty disables).")
+	opts := zap.Options{Development: false}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	// Give runnables enough time on SIGTERM for the task manager to
+	// drain in-flight sync operations to a safe point before the
+	// process exits.
+	gracefulShutdown := 45 * time.Second
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                  scheme,
+		Metrics:                 metricsserver.Options{BindAddress: metricsAddr},
+		WebhookServer:           webhook.NewServer(webhook.Options{Port: 9443}),
+		HealthProbeBindAddress:  probeAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "jira-cdc.cdc.atlassian.io",
+		GracefulShutdownTimeout: &gracefulShutdown,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	breakers := cdcerrors.NewCircuitBreakerManager(5, 30*time.Second)
+	breakers.OnStateChange(func(name string, state cdcerrors.BreakerState) {
+		cdcmetrics.CircuitBreakerState.WithLabelValues(name).
+			Set(cdcmetrics.BreakerStateValue(string(state)))
+	})
+
+	deadLetters := cdcsync.NewDeadLetterStore()
+	health := api.NewHealthChecker(30 * time.Second)
+	engines := cdcsync.NewEngineRegistry()
+	taskManager := cdcsync.NewTaskManager(2, 128)
+
+	// Surface which replica holds the leader lease; standby replicas
+	// export 0 until promoted.
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		podName, _ = os.Hostname()
+	}
+	cdcmetrics.SetLeaderStatus(podName, false)
+	go func() {
+		<-mgr.Elected()
+		cdcmetrics.SetLeaderStatus(podName, true)
+	}()
+
+	auditLog := audit.NewLogger(nil, mgr.GetEventRecorderFor("jiracdc-audit"))
+	// Aggregate controller events so a flapping JIRA or git remote
+	// cannot flood the resource's event stream with identical warnings.
+	recorder := events.NewEventAggregator(mgr.GetEventRecorderFor("jiracdc-controller"), events.Config{})
+	defer recorder.Stop()
+	// Status bus feeding the WebSocket endpoint with project phase
+	// changes and task lifecycle events.
+	statusBus := cdcsync.NewStatusBus()
+	defer statusBus.Shutdown()
+	taskManager.SetStatusBus(statusBus)
+	if err = (&controller.JiraCDCReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    recorder,
+		Bus:         statusBus,
+		Breakers:    breakers,
+		DeadLetters: deadLetters,
+		Health:      health,
+		Engines:     engines,
+		Audit:       auditLog,
+		Elected:     mgr.Elected(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "JiraCDC")
+		os.Exit(1)
+	}
+	if err := mgr.Add(taskManager); err != nil {
+		setupLog.Error(err, "unable to add task manager")
+		os.Exit(1)
+	}
+	// API auth: TokenReview-backed bearer tokens when the flag is on,
+	// plus optional static keys (mounted from a secret via env) for
+	// service-to-service callers. Neither configured leaves the API
+	// open, as before.
+	apiAuth := &api.AuthConfig{}
+	if key := os.Getenv("API_ADMIN_KEY"); key != "" {
+		apiAuth.StaticTokens = map[string]string{key: api.ScopeTrigger}
+	}
+	if key := os.Getenv("API_READ_KEY"); key != "" {
+		if apiAuth.StaticTokens == nil {
+			apiAuth.StaticTokens = map[string]string{}
+		}
+		apiAuth.StaticTokens[key] = api.ScopeRead
+	}
+	if enableAPIAuth {
+		clientset, cerr := kubernetes.NewForConfig(mgr.GetConfig())
+		if cerr != nil {
+			setupLog.Error(cerr, "unable to build clientset for API auth")
+			os.Exit(1)
+		}
+		apiAuth.TokenReview = api.NewKubeTokenReviewer(clientset)
+	}
+
+	apiServer := api.NewServer(api.Options{
+		Addr:          apiAddr,
+		DeadLetters:   deadLetters,
+		Health:        health,
+		Tasks:         taskManager,
+		Engines:       engines,
+		Breakers:      breakers,
+		StatusBus:     statusBus,
+		WebhookSecret: []byte(os.Getenv("JIRA_WEBHOOK_SECRET")),
+		Kube:          mgr.GetClient(),
+		Recorder:      mgr.GetEventRecorderFor("jiracdc-api"),
+		Audit:         auditLog,
+		Auth:          apiAuth,
+	})
+	if err := mgr.Add(apiServer); err != nil {
+		setupLog.Error(err, "unable to add API server")
+		os.Exit(1)
+	}
+	if maintenanceSchedule != "" {
+		schedule, serr := cron.Parse(maintenanceSchedule)
+		if serr != nil {
+			setupLog.Error(serr, "invalid maintenance schedule", "schedule", maintenanceSchedule)
+			os.Exit(1)
+		}
+		if err := mgr.Add(&api.MaintenanceScheduler{Server: apiServer, Schedule: schedule}); err != nil {
+			setupLog.Error(err, "unable to add maintenance scheduler")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err = (&webhooks.JiraCDCWebhook{
+			Client:           mgr.GetClient(),
+			PreflightEnabled: enablePreflight,
+			Tasks:            taskManager,
+			Engines:          engines,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "JiraCDC")
+			os.Exit(1)
+		}
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
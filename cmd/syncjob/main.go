@@ -0,0 +1,164 @@
+// Command syncjob runs a single sync operation to completion and exits,
+// suitable for use as a Kubernetes Job operand. Unlike the long-running
+// manager it performs no reconciliation loop: it builds an engine from
+// flags and environment variables, executes one operation, reports a
+// JSON result summary on stdout, and exits 0 on success or 1 on
+// failure so Job backoff semantics apply cleanly.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// resultSummary is the machine-readable outcome written to stdout when
+// the operation finishes, successfully or not.
+type resultSummary struct {
+	Operation       string         `json:"operation"`
+	Projects        []string       `json:"projects,omitempty"`
+	Issue           string         `json:"issue,omitempty"`
+	Status          string         `json:"status"`
+	SyncedIssues    int            `json:"syncedIssues"`
+	Failed          int            `json:"failed"`
+	Skipped         int            `json:"skipped"`
+	PerProject      map[string]int `json:"perProject,omitempty"`
+	DurationSeconds float64        `json:"durationSeconds"`
+	// TimedOut marks failures caused by the operation deadline rather
+	// than the operation itself.
+	TimedOut bool   `json:"timedOut,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func main() {
+	var operation string
+	var projects string
+	var issueKey string
+	var workDir string
+	var timeout time.Duration
+	flag.StringVar(&operation, "operation", "bootstrap",
+		"Operation to run: bootstrap (full project sync), resync-issue, or delete-issue.")
+	flag.StringVar(&projects, "projects", os.Getenv("JIRA_PROJECT_KEYS"),
+		"Comma-separated JIRA project keys to sync.")
+	flag.StringVar(&issueKey, "issue", "",
+		"Issue key for the resync-issue and delete-issue operations.")
+	flag.StringVar(&workDir, "work-dir", "/tmp/jira-cdc",
+		"Directory the git repository is cloned into.")
+	flag.DurationVar(&timeout, "timeout", 0,
+		"Overall operation deadline. Zero means no deadline.")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	summary := resultSummary{Operation: operation, Issue: issueKey}
+	start := time.Now()
+	err := run(ctx, operation, projects, issueKey, workDir, &summary)
+	summary.DurationSeconds = time.Since(start).Seconds()
+	summary.Status = "succeeded"
+	if err != nil {
+		summary.Status = "failed"
+		summary.TimedOut = errors.Is(err, context.DeadlineExceeded)
+		summary.Error = err.Error()
+	}
+	if encErr := json.NewEncoder(os.Stdout).Encode(summary); encErr != nil {
+		fmt.Fprintln(os.Stderr, encErr)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// run builds the engine and executes one operation, filling the summary
+// from the engine's progress tracker.
+func run(ctx context.Context, operation, projects, issueKey, workDir string, summary *resultSummary) error {
+	projectKeys := splitKeys(projects)
+	if len(projectKeys) == 0 {
+		return fmt.Errorf("no project keys: set --projects or JIRA_PROJECT_KEYS")
+	}
+	summary.Projects = projectKeys
+
+	jiraClient, err := jira.NewClient(jira.Config{
+		BaseURL:    os.Getenv("JIRA_BASE_URL"),
+		AuthMethod: os.Getenv("JIRA_AUTH_METHOD"),
+		Email:      os.Getenv("JIRA_EMAIL"),
+		APIToken:   os.Getenv("JIRA_API_TOKEN"),
+		Token:      os.Getenv("JIRA_TOKEN"),
+		RateLimit:  jira.DefaultRateLimitConfig(),
+	})
+	if err != nil {
+		return err
+	}
+	gitManager, err := git.NewManager(git.Config{
+		URL:        os.Getenv("GIT_URL"),
+		Branch:     os.Getenv("GIT_BRANCH"),
+		FileLayout: os.Getenv("GIT_FILE_LAYOUT"),
+		LocalPath:  workDir,
+		Username:   os.Getenv("GIT_USERNAME"),
+		Token:      os.Getenv("GIT_TOKEN"),
+	})
+	if err != nil {
+		return err
+	}
+	engine, err := sync.NewEngine(jiraClient, gitManager, sync.Options{
+		ProjectKeys:     projectKeys,
+		SyncChangelog:   os.Getenv("SYNC_CHANGELOG") == "true",
+		SyncWorklogs:    os.Getenv("SYNC_WORKLOGS") == "true",
+		SyncAttachments: os.Getenv("SYNC_ATTACHMENTS") == "true",
+	})
+	if err != nil {
+		return err
+	}
+
+	switch operation {
+	case "bootstrap":
+		err = engine.Bootstrap(ctx)
+	case "resync-issue":
+		if issueKey == "" {
+			return fmt.Errorf("operation resync-issue requires --issue")
+		}
+		_, err = engine.ResyncIssue(ctx, issueKey)
+	case "delete-issue":
+		if issueKey == "" {
+			return fmt.Errorf("operation delete-issue requires --issue")
+		}
+		err = engine.DeleteIssue(ctx, issueKey)
+	default:
+		return fmt.Errorf("unknown operation %q: expected bootstrap, resync-issue or delete-issue", operation)
+	}
+
+	progress := engine.Progress().Snapshot()
+	summary.SyncedIssues = progress.Completed
+	summary.Failed = progress.Failed
+	summary.Skipped = progress.Skipped
+	summary.PerProject = progress.PerProject
+	return err
+}
+
+// splitKeys parses a comma-separated project key list, dropping empty
+// entries.
+func splitKeys(projects string) []string {
+	var keys []string
+	for _, key := range strings.Split(projects, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
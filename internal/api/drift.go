@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+)
+
+// getDrift reports managed issue files whose working-tree content no
+// longer matches what the last sync wrote, computed live against the
+// sync state so the report reflects the clone as it is now.
+func (s *Server) getDrift(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+projectKey, nil)
+		return
+	}
+	manager := engine.Git()
+	if !manager.Cloned() {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "repository not cloned yet, retry later",
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+		return
+	}
+
+	drifts := manager.DetectDrift(c.Request.Context())
+	if drifts == nil {
+		drifts = []git.Drift{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"projectKey": projectKey,
+		"drift":      drifts,
+	})
+}
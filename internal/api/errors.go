@@ -0,0 +1,103 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// Stable machine-readable error codes carried in every error response.
+// Clients should branch on these rather than parsing the free-text
+// message, which may change between releases.
+const (
+	CodeInvalidRequest      = "INVALID_REQUEST"
+	CodeInvalidJQL          = "INVALID_JQL"
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeForbidden           = "FORBIDDEN"
+	CodeNotFound            = "NOT_FOUND"
+	CodeJiraAuthFailed      = "JIRA_AUTH_FAILED"
+	CodeUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	CodeOperationInProgress = "OPERATION_IN_PROGRESS"
+	CodeTaskTerminal        = "TASK_TERMINAL"
+	CodeQueueFull           = "QUEUE_FULL"
+	CodeNotReady            = "NOT_READY"
+	CodeInternal            = "INTERNAL"
+)
+
+// defaultRetryAfterSeconds is the retry hint attached to transient and
+// backpressure errors without a more specific value.
+const defaultRetryAfterSeconds = 30
+
+// requestIDKey is the gin context key caching the request ID so every
+// error in one request carries the same value.
+const requestIDKey = "api-request-id"
+
+// requestID returns the correlation ID for this request: the caller's
+// X-Request-ID when supplied, otherwise a generated one. The ID is also
+// echoed as a response header so it appears in client and server logs
+// alike.
+func requestID(c *gin.Context) string {
+	if id, ok := c.Get(requestIDKey); ok {
+		return id.(string)
+	}
+	id := c.GetHeader("X-Request-ID")
+	if id == "" {
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		id = hex.EncodeToString(buf)
+	}
+	c.Set(requestIDKey, id)
+	c.Header("X-Request-ID", id)
+	return id
+}
+
+// jsonError writes the standard error envelope: the human-readable
+// message, a stable code, and a request ID for correlation. Extra
+// fields (e.g. taskId, retryAfter) are merged in.
+func jsonError(c *gin.Context, status int, code, message string, extra gin.H) {
+	body := gin.H{
+		"error":     message,
+		"code":      code,
+		"requestId": requestID(c),
+	}
+	for key, value := range extra {
+		body[key] = value
+	}
+	c.JSON(status, body)
+}
+
+// respondClassified converts an upstream operation error into the
+// standard envelope through the errors package classification, so every
+// handler reports the same code and status for the same failure mode.
+// Transient failures carry a retryAfter hint in seconds.
+func respondClassified(c *gin.Context, err error) {
+	var inProgress *sync.ErrOperationInProgress
+	if errors.As(err, &inProgress) {
+		jsonError(c, http.StatusConflict, CodeOperationInProgress, err.Error(),
+			gin.H{"taskId": inProgress.TaskID})
+		return
+	}
+	switch cdcerrors.Classify(err) {
+	case cdcerrors.ClassAuth:
+		jsonError(c, http.StatusBadGateway, CodeJiraAuthFailed, err.Error(), nil)
+	case cdcerrors.ClassValidation:
+		jsonError(c, http.StatusUnprocessableEntity, CodeInvalidRequest, err.Error(), nil)
+	case cdcerrors.ClassPermanent:
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			jsonError(c, http.StatusNotFound, CodeNotFound, err.Error(), nil)
+			return
+		}
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+	default:
+		jsonError(c, http.StatusServiceUnavailable, CodeUpstreamUnavailable, err.Error(),
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportProject streams an archive of a project's mirrored issue files
+// from the git working tree, for offline snapshots. The archive is
+// written directly to the response so large projects are never
+// buffered in memory. ?format selects zip (default) or tar.gz;
+// ?include=attachments adds files under attachments/ directories.
+func (s *Server) exportProject(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+projectKey, nil)
+		return
+	}
+	manager := engine.Git()
+	if !manager.Cloned() {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "repository not cloned yet, retry later",
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+		return
+	}
+
+	format := c.DefaultQuery("format", "zip")
+	if format != "zip" && format != "tar.gz" {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+			"format must be zip or tar.gz", nil)
+		return
+	}
+	includeAttachments := c.Query("include") == "attachments"
+
+	root := manager.WorkingDirectory()
+	files := collectExportFiles(root, projectKey, includeAttachments)
+	if len(files) == 0 {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no synced files for project "+projectKey, nil)
+		return
+	}
+
+	filename := projectKey + "-export." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	// Streaming errors past this point cannot be reported as JSON; the
+	// truncated archive itself signals the failure.
+	if format == "zip" {
+		c.Header("Content-Type", "application/zip")
+		c.Status(http.StatusOK)
+		writeZip(c.Writer, root, files)
+		return
+	}
+	c.Header("Content-Type", "application/gzip")
+	c.Status(http.StatusOK)
+	writeTarGz(c.Writer, root, files)
+}
+
+// collectExportFiles walks the working tree for a project's issue
+// markdown files — and, when requested, its attachment files — and
+// returns their paths relative to root.
+func collectExportFiles(root, projectKey string, includeAttachments bool) []string {
+	issueFile := regexp.MustCompile(`^` + regexp.QuoteMeta(projectKey) + `-\d+\.md$`)
+	var files []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, rerr := filepath.Rel(root, path)
+		if rerr != nil {
+			return nil
+		}
+		switch {
+		case issueFile.MatchString(d.Name()):
+			files = append(files, rel)
+		case includeAttachments && underAttachments(rel) && strings.Contains(rel, projectKey+"-"):
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files
+}
+
+// underAttachments reports whether a repository-relative path has an
+// "attachments" directory component.
+func underAttachments(rel string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if part == "attachments" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeZip streams the listed files into a zip archive on w. Entry
+// names use forward slashes regardless of platform.
+func writeZip(w io.Writer, root string, files []string) {
+	zw := zip.NewWriter(w)
+	defer zw.Close()
+	for _, rel := range files {
+		entry, err := zw.Create(filepath.ToSlash(rel))
+		if err != nil {
+			return
+		}
+		if err := copyFile(entry, filepath.Join(root, rel)); err != nil {
+			return
+		}
+	}
+}
+
+// writeTarGz streams the listed files into a gzipped tarball on w.
+func writeTarGz(w io.Writer, root string, files []string) {
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+	for _, rel := range files {
+		info, err := os.Stat(filepath.Join(root, rel))
+		if err != nil {
+			return
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return
+		}
+		if err := copyFile(tw, filepath.Join(root, rel)); err != nil {
+			return
+		}
+	}
+}
+
+// copyFile streams one file's content into the archive entry.
+func copyFile(dst io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(dst, f)
+	return err
+}
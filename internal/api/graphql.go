@@ -0,0 +1,346 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// maxGraphQLDepth bounds selection set nesting so a hostile query cannot
+// force pathological work.
+const maxGraphQLDepth = 5
+
+// graphQLRequest is the standard GraphQL HTTP request envelope.
+type graphQLRequest struct {
+	Query string `json:"query"`
+}
+
+// gqlField is one entry in a parsed selection set.
+type gqlField struct {
+	Name       string
+	Args       map[string]string
+	Selections []gqlField
+}
+
+// graphQL serves a small query-only GraphQL surface over the same data
+// the REST endpoints expose: projects, project(key), tasks and task(id).
+// The executor is intentionally hand-rolled; the schema is four fields
+// and does not justify a full GraphQL dependency.
+func (s *Server) graphQL(c *gin.Context) {
+	var req graphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gqlErrors("invalid request body: "+err.Error()))
+		return
+	}
+	selections, err := parseGraphQLQuery(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gqlErrors(err.Error()))
+		return
+	}
+
+	data := gin.H{}
+	var errs []gin.H
+	for _, field := range selections {
+		value, err := s.resolveRootField(field)
+		if err != nil {
+			errs = append(errs, gin.H{"message": err.Error()})
+			data[field.Name] = nil
+			continue
+		}
+		data[field.Name] = value
+	}
+
+	resp := gin.H{"data": data}
+	if len(errs) > 0 {
+		resp["errors"] = errs
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func gqlErrors(messages ...string) gin.H {
+	errs := make([]gin.H, 0, len(messages))
+	for _, msg := range messages {
+		errs = append(errs, gin.H{"message": msg})
+	}
+	return gin.H{"errors": errs}
+}
+
+// resolveRootField dispatches one top-level query field.
+func (s *Server) resolveRootField(field gqlField) (interface{}, error) {
+	switch field.Name {
+	case "projects":
+		var projects []gin.H
+		for _, key := range s.engines.Projects() {
+			projects = append(projects, s.projectModel(key, field.Selections))
+		}
+		return projects, nil
+	case "project":
+		key := field.Args["key"]
+		if key == "" {
+			return nil, fmt.Errorf(`project requires a "key" argument`)
+		}
+		if _, ok := s.engines.Get(key); !ok {
+			return nil, nil
+		}
+		return s.projectModel(key, field.Selections), nil
+	case "tasks":
+		var tasks []gin.H
+		for _, task := range s.tasks.List() {
+			tasks = append(tasks, taskModel(task, field.Selections))
+		}
+		return tasks, nil
+	case "task":
+		id := field.Args["id"]
+		if id == "" {
+			return nil, fmt.Errorf(`task requires an "id" argument`)
+		}
+		task, ok := s.tasks.Get(id)
+		if !ok {
+			return nil, nil
+		}
+		return taskModel(task, field.Selections), nil
+	}
+	return nil, fmt.Errorf("unknown query field %q", field.Name)
+}
+
+func (s *Server) projectModel(key string, selections []gqlField) gin.H {
+	fields := gin.H{
+		"key": key,
+		"failedIssues": func() interface{} {
+			return len(s.deadLetters.List(key))
+		},
+		"syncedIssues": func() interface{} {
+			engine, ok := s.engines.Get(key)
+			if !ok {
+				return 0
+			}
+			return engine.Progress().Snapshot().Completed
+		},
+	}
+	return selectFields(fields, selections)
+}
+
+func taskModel(task sync.ManagedTask, selections []gqlField) gin.H {
+	fields := gin.H{
+		"id":          task.ID,
+		"type":        task.Type,
+		"description": task.Description,
+		"status":      string(task.Status),
+		"error":       task.Error,
+		"createdAt":   task.CreatedAt,
+		"startedAt":   task.StartedAt,
+		"finishedAt":  task.FinishedAt,
+	}
+	return selectFields(fields, selections)
+}
+
+// selectFields projects the requested selections out of the available
+// field set. Lazily computed fields are functions evaluated on demand.
+func selectFields(available gin.H, selections []gqlField) gin.H {
+	out := gin.H{}
+	for _, sel := range selections {
+		value, ok := available[sel.Name]
+		if !ok {
+			out[sel.Name] = nil
+			continue
+		}
+		if lazy, isLazy := value.(func() interface{}); isLazy {
+			value = lazy()
+		}
+		out[sel.Name] = value
+	}
+	return out
+}
+
+// parseGraphQLQuery parses a query document into its top-level selection
+// set, enforcing the depth limit. Only query operations are supported.
+func parseGraphQLQuery(query string) ([]gqlField, error) {
+	p := &gqlParser{input: query}
+	p.skipSpace()
+	// Optional "query" keyword with an optional operation name.
+	if p.peekWord() == "query" {
+		p.readWord()
+		p.skipSpace()
+		if w := p.peekWord(); w != "" {
+			p.readWord()
+		}
+	} else if w := p.peekWord(); w == "mutation" || w == "subscription" {
+		return nil, fmt.Errorf("only query operations are supported")
+	}
+	p.skipSpace()
+	selections, err := p.parseSelectionSet(1)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected trailing input at offset %d", p.pos)
+	}
+	return selections, nil
+}
+
+type gqlParser struct {
+	input string
+	pos   int
+}
+
+func (p *gqlParser) skipSpace() {
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if unicode.IsSpace(rune(c)) || c == ',' {
+			p.pos++
+			continue
+		}
+		// Line comments are part of the GraphQL grammar.
+		if c == '#' {
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+func (p *gqlParser) peekWord() string {
+	start := p.pos
+	word := p.readWord()
+	p.pos = start
+	return word
+}
+
+func (p *gqlParser) readWord() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *gqlParser) parseSelectionSet(depth int) ([]gqlField, error) {
+	if depth > maxGraphQLDepth {
+		return nil, fmt.Errorf("query exceeds maximum depth of %d", maxGraphQLDepth)
+	}
+	if p.pos >= len(p.input) || p.input[p.pos] != '{' {
+		return nil, fmt.Errorf("expected '{' at offset %d", p.pos)
+	}
+	p.pos++
+
+	var selections []gqlField
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		if p.input[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		field, err := p.parseField(depth)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, field)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set")
+	}
+	return selections, nil
+}
+
+func (p *gqlParser) parseField(depth int) (gqlField, error) {
+	name := p.readWord()
+	if name == "" {
+		return gqlField{}, fmt.Errorf("expected field name at offset %d", p.pos)
+	}
+	field := gqlField{Name: name}
+
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		args, err := p.parseArguments()
+		if err != nil {
+			return gqlField{}, err
+		}
+		field.Args = args
+		p.skipSpace()
+	}
+	if p.pos < len(p.input) && p.input[p.pos] == '{' {
+		selections, err := p.parseSelectionSet(depth + 1)
+		if err != nil {
+			return gqlField{}, err
+		}
+		field.Selections = selections
+	}
+	return field, nil
+}
+
+func (p *gqlParser) parseArguments() (map[string]string, error) {
+	p.pos++ // consume '('
+	args := map[string]string{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated argument list")
+		}
+		if p.input[p.pos] == ')' {
+			p.pos++
+			return args, nil
+		}
+		name := p.readWord()
+		if name == "" {
+			return nil, fmt.Errorf("expected argument name at offset %d", p.pos)
+		}
+		p.skipSpace()
+		if p.pos >= len(p.input) || p.input[p.pos] != ':' {
+			return nil, fmt.Errorf("expected ':' after argument %q", name)
+		}
+		p.pos++
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+}
+
+func (p *gqlParser) parseValue() (string, error) {
+	if p.pos >= len(p.input) {
+		return "", fmt.Errorf("expected argument value")
+	}
+	if p.input[p.pos] == '"' {
+		p.pos++
+		var sb strings.Builder
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			if c == '\\' && p.pos+1 < len(p.input) {
+				p.pos++
+				sb.WriteByte(p.input[p.pos])
+				p.pos++
+				continue
+			}
+			if c == '"' {
+				p.pos++
+				return sb.String(), nil
+			}
+			sb.WriteByte(c)
+			p.pos++
+		}
+		return "", fmt.Errorf("unterminated string value")
+	}
+	word := p.readWord()
+	if word == "" {
+		return "", fmt.Errorf("expected argument value at offset %d", p.pos)
+	}
+	return word, nil
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// postGraphQL sends one query and decodes the response envelope.
+func postGraphQL(t *testing.T, s *Server, query string) (int, map[string]interface{}) {
+	t.Helper()
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response %q is not JSON: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+// newGraphQLTestServer returns a server with one registered project,
+// two dead-lettered issues, and one finished task.
+func newGraphQLTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	engine, err := sync.NewEngine(nil, nil, sync.Options{ProjectKeys: []string{"PROJ"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engines := sync.NewEngineRegistry()
+	engines.Register("PROJ", engine)
+
+	deadLetters := sync.NewDeadLetterStore()
+	for _, key := range []string{"PROJ-1", "PROJ-2"} {
+		deadLetters.Record("PROJ", key, "permanent", errors.New("gone"), 1)
+	}
+
+	tasks := sync.NewTaskManager(1, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() { _ = tasks.Start(ctx) }()
+	managed, err := tasks.Submit("reconcile", "test task", func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if task, ok := tasks.Get(managed.ID); ok && task.Status == sync.TaskCompleted {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("submitted task never completed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	return newTestServer(Options{Engines: engines, DeadLetters: deadLetters, Tasks: tasks}), managed.ID
+}
+
+// TestGraphQLQueries verifies the four root fields resolve against the
+// same data the REST endpoints serve, including argument lookups and
+// null results for unknown keys.
+func TestGraphQLQueries(t *testing.T) {
+	s, taskID := newGraphQLTestServer(t)
+
+	code, resp := postGraphQL(t, s, `{ projects { key failedIssues syncedIssues } }`)
+	if code != http.StatusOK {
+		t.Fatalf("projects query got %d", code)
+	}
+	if resp["errors"] != nil {
+		t.Fatalf("projects query returned errors: %v", resp["errors"])
+	}
+	projects := resp["data"].(map[string]interface{})["projects"].([]interface{})
+	if len(projects) != 1 {
+		t.Fatalf("listed %d projects, want 1", len(projects))
+	}
+	project := projects[0].(map[string]interface{})
+	if project["key"] != "PROJ" || project["failedIssues"] != float64(2) {
+		t.Errorf("project resolved as %v, want key PROJ with 2 failed issues", project)
+	}
+	if _, ok := project["syncedIssues"]; !ok {
+		t.Error("syncedIssues missing from the selection")
+	}
+
+	// The optional operation header and arguments parse.
+	code, resp = postGraphQL(t, s, `query Status { project(key: "PROJ") { key } }`)
+	if code != http.StatusOK || resp["errors"] != nil {
+		t.Fatalf("project query got %d, errors %v", code, resp["errors"])
+	}
+	got := resp["data"].(map[string]interface{})["project"].(map[string]interface{})
+	if got["key"] != "PROJ" {
+		t.Errorf("project(key) resolved %v", got)
+	}
+
+	// An unknown project is null data, not an error.
+	_, resp = postGraphQL(t, s, `{ project(key: "MISSING") { key } }`)
+	if resp["errors"] != nil {
+		t.Errorf("unknown project returned errors: %v", resp["errors"])
+	}
+	if resp["data"].(map[string]interface{})["project"] != nil {
+		t.Error("unknown project did not resolve to null")
+	}
+
+	code, resp = postGraphQL(t, s, `{ task(id: "`+taskID+`") { id status } }`)
+	if code != http.StatusOK || resp["errors"] != nil {
+		t.Fatalf("task query got %d, errors %v", code, resp["errors"])
+	}
+	task := resp["data"].(map[string]interface{})["task"].(map[string]interface{})
+	if task["id"] != taskID || task["status"] != string(sync.TaskCompleted) {
+		t.Errorf("task resolved as %v", task)
+	}
+
+	_, resp = postGraphQL(t, s, `{ tasks { id type } }`)
+	tasks := resp["data"].(map[string]interface{})["tasks"].([]interface{})
+	if len(tasks) != 1 || tasks[0].(map[string]interface{})["type"] != "reconcile" {
+		t.Errorf("tasks resolved as %v", tasks)
+	}
+
+	// Fields outside the model resolve to null rather than failing the
+	// whole query.
+	_, resp = postGraphQL(t, s, `{ projects { key nonexistent } }`)
+	project = resp["data"].(map[string]interface{})["projects"].([]interface{})[0].(map[string]interface{})
+	if value, ok := project["nonexistent"]; !ok || value != nil {
+		t.Errorf("unknown selection resolved as %v", value)
+	}
+}
+
+// TestGraphQLRejections verifies malformed documents get 400, while
+// resolver-level problems come back as GraphQL errors alongside 200,
+// per the transport convention.
+func TestGraphQLRejections(t *testing.T) {
+	s, _ := newGraphQLTestServer(t)
+
+	badRequests := []struct {
+		name  string
+		query string
+	}{
+		{"mutation", `mutation { reconcile }`},
+		{"no selection set", `query`},
+		{"empty selection set", `{ }`},
+		{"unterminated set", `{ projects { key`},
+		{"trailing input", `{ projects { key } } extra`},
+		{"excessive depth", `{ a { b { c { d { e { f } } } } } }`},
+	}
+	for _, tc := range badRequests {
+		t.Run(tc.name, func(t *testing.T) {
+			code, resp := postGraphQL(t, s, tc.query)
+			if code != http.StatusBadRequest {
+				t.Errorf("got %d, want 400", code)
+			}
+			if resp["errors"] == nil {
+				t.Error("rejection carries no errors")
+			}
+		})
+	}
+
+	resolverErrors := []struct {
+		name  string
+		query string
+		field string
+	}{
+		{"unknown root field", `{ bogus }`, "bogus"},
+		{"project without key", `{ project { key } }`, "project"},
+		{"task without id", `{ task { id } }`, "task"},
+	}
+	for _, tc := range resolverErrors {
+		t.Run(tc.name, func(t *testing.T) {
+			code, resp := postGraphQL(t, s, tc.query)
+			if code != http.StatusOK {
+				t.Errorf("got %d, want 200 with errors", code)
+			}
+			if resp["errors"] == nil {
+				t.Error("resolver failure carries no errors")
+			}
+			if value, ok := resp["data"].(map[string]interface{})[tc.field]; !ok || value != nil {
+				t.Errorf("failed field resolved as %v, want null", value)
+			}
+		})
+	}
+}
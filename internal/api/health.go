@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// Probe tests connectivity to one downstream component.
+type Probe func(ctx context.Context) error
+
+// ComponentHealth is the cached outcome of a single component probe.
+type ComponentHealth struct {
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// HealthChecker runs registered connectivity probes for readiness,
+// caching results for a TTL so probes do not hammer JIRA or the git
+// remote on every kubelet poll.
+type HealthChecker struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	probes map[string]Probe
+	cache  map[string]ComponentHealth
+}
+
+// NewHealthChecker returns a checker caching probe results for ttl.
+func NewHealthChecker(ttl time.Duration) *HealthChecker {
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	return &HealthChecker{
+		ttl:    ttl,
+		probes: map[string]Probe{},
+		cache:  map[string]ComponentHealth{},
+	}
+}
+
+// RegisterProbe adds or replaces the probe for a component.
+func (h *HealthChecker) RegisterProbe(component string, probe Probe) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.probes[component] = probe
+}
+
+// Check runs every registered probe, serving cached results inside the
+// TTL, and returns per-component health.
+func (h *HealthChecker) Check(ctx context.Context) map[string]ComponentHealth {
+	h.mu.Lock()
+	pending := map[string]Probe{}
+	results := map[string]ComponentHealth{}
+	for component, probe := range h.probes {
+		if cached, ok := h.cache[component]; ok && time.Since(cached.CheckedAt) < h.ttl {
+			results[component] = cached
+			continue
+		}
+		pending[component] = probe
+	}
+	h.mu.Unlock()
+
+	for component, probe := range pending {
+		probeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := probe(probeCtx)
+		cancel()
+
+		health := ComponentHealth{Healthy: err == nil, CheckedAt: time.Now()}
+		if err != nil {
+			health.Error = err.Error()
+		}
+		metrics.RecordHealthCheck(component, health.Healthy)
+		metrics.SetComponentHealth(component, health.Healthy)
+
+		h.mu.Lock()
+		h.cache[component] = health
+		h.mu.Unlock()
+		results[component] = health
+	}
+	return results
+}
+
+// registerHealthRoutes adds the liveness and readiness endpoints.
+func (s *Server) registerHealthRoutes() {
+	// Liveness only confirms the process is serving requests.
+	s.router.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	// Readiness verifies actual JIRA and git connectivity, cached per
+	// the checker's TTL.
+	s.router.GET("/readyz", func(c *gin.Context) {
+		components := s.health.Check(c.Request.Context())
+		var unhealthy []string
+		for component, health := range components {
+			if !health.Healthy {
+				unhealthy = append(unhealthy, component)
+			}
+		}
+		if len(unhealthy) > 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":     "unhealthy",
+				"unhealthy":  unhealthy,
+				"components": components,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":     "ok",
+			"components": components,
+		})
+	})
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+)
+
+// IssueResponse is the structured JSON form of a mirrored issue file.
+type IssueResponse struct {
+	Key            string                 `json:"key"`
+	Project        string                 `json:"project,omitempty"`
+	Summary        string                 `json:"summary"`
+	Type           string                 `json:"type"`
+	Status         string                 `json:"status"`
+	StatusCategory string                 `json:"statusCategory"`
+	Priority       string                 `json:"priority,omitempty"`
+	Assignee       string                 `json:"assignee,omitempty"`
+	Reporter       string                 `json:"reporter,omitempty"`
+	Labels         []string               `json:"labels,omitempty"`
+	Components     []string               `json:"components,omitempty"`
+	Created        string                 `json:"created"`
+	Updated        string                 `json:"updated"`
+	Links          []git.IssueLink        `json:"links,omitempty"`
+	Subtasks       []git.SubtaskRef       `json:"subtasks,omitempty"`
+	Custom         map[string]interface{} `json:"custom,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+}
+
+// getIssue returns a mirrored issue parsed from its committed markdown
+// file, or the raw markdown with ?raw=true.
+func (s *Server) getIssue(c *gin.Context) {
+	key := c.Param("key")
+	engine, ok := s.engines.Get(projectFromIssueKey(key))
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for issue "+key, nil)
+		return
+	}
+	manager := engine.Git()
+	if !manager.Cloned() {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "repository not cloned yet, retry later",
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+		return
+	}
+
+	if c.Query("raw") == "true" {
+		content, err := manager.ReadIssueFile(key)
+		if err != nil {
+			jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+			return
+		}
+		if content == nil {
+			jsonError(c, http.StatusNotFound, CodeNotFound, "issue "+key+" not found", nil)
+			return
+		}
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", content)
+		return
+	}
+
+	data, err := manager.ReadIssueData(key)
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+	if data == nil {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "issue "+key+" not found", nil)
+		return
+	}
+	c.JSON(http.StatusOK, issueResponse(data))
+}
+
+// getIssueHistory returns the commit history of an issue's file, newest
+// first, paginated with ?limit= and ?offset=.
+func (s *Server) getIssueHistory(c *gin.Context) {
+	key := c.Param("key")
+	engine, ok := s.engines.Get(projectFromIssueKey(key))
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for issue "+key, nil)
+		return
+	}
+	manager := engine.Git()
+	if !manager.Cloned() {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "repository not cloned yet, retry later",
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+		return
+	}
+	if !manager.HasIssueFile(key) {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "issue "+key+" not found", nil)
+		return
+	}
+
+	limit := intQuery(c, "limit", 20)
+	offset := intQuery(c, "offset", 0)
+	if limit <= 0 || offset < 0 {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "limit must be positive and offset non-negative", nil)
+		return
+	}
+
+	commits, err := manager.GetFileHistory(c.Request.Context(), key+".md")
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+
+	total := len(commits)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"key":     key,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+		"commits": commits[offset:end],
+	})
+}
+
+// intQuery parses an integer query parameter, falling back to def when
+// absent or malformed.
+func intQuery(c *gin.Context, name string, def int) int {
+	raw := c.Query(name)
+	if raw == "" {
+		return def
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func issueResponse(data *git.IssueData) IssueResponse {
+	return IssueResponse{
+		Key:            data.Key,
+		Project:        data.Project,
+		Summary:        data.Summary,
+		Type:           data.IssueType,
+		Status:         data.Status,
+		StatusCategory: data.StatusCategory,
+		Priority:       data.Priority,
+		Assignee:       data.Assignee,
+		Reporter:       data.Reporter,
+		Labels:         data.Labels,
+		Components:     data.Components,
+		Created:        data.Created,
+		Updated:        data.Updated,
+		Links:          data.Links,
+		Subtasks:       data.Subtasks,
+		Custom:         data.Custom,
+		Description:    data.Description,
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getIssueSyncStatus reports the per-issue sync result recorded in the
+// repository's state file: the last synced JIRA revision, the commit
+// that contained it and when it was mirrored.
+func (s *Server) getIssueSyncStatus(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	key := c.Param("key")
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+projectKey, nil)
+		return
+	}
+	manager := engine.Git()
+	if !manager.Cloned() {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "repository not cloned yet, retry later",
+			gin.H{"retryAfter": defaultRetryAfterSeconds})
+		return
+	}
+
+	result, ok := manager.LoadSyncState(c.Request.Context())[key]
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync result recorded for issue "+key, nil)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"issueKey": key,
+		"revision": result.Revision,
+		"commit":   result.Commit,
+		"syncedAt": result.SyncedAt,
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// jqlValidateRequest is the body of a JQL validation call. ProjectKey
+// selects which configured JIRA instance's credentials run the query.
+type jqlValidateRequest struct {
+	ProjectKey string `json:"projectKey"`
+	JQL        string `json:"jql"`
+}
+
+// validateJQL checks a JQL query against JIRA with maxResults=0 so
+// users get syntax feedback and a matched-issue count before saving a
+// query, without fetching any issue bodies. Requests go through the
+// engine's rate-limited client.
+func (s *Server) validateJQL(c *gin.Context) {
+	var req jqlValidateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed request body", nil)
+		return
+	}
+	if req.JQL == "" {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "jql is required", nil)
+		return
+	}
+	engine, ok := s.engines.Get(req.ProjectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+req.ProjectKey, nil)
+		return
+	}
+
+	total, err := engine.Jira().ValidateJQL(c.Request.Context(), req.JQL)
+	if err != nil {
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			jsonError(c, http.StatusUnprocessableEntity, CodeInvalidJQL, jiraErrorMessage(apiErr),
+				gin.H{"valid": false})
+			return
+		}
+		respondClassified(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"valid": true,
+		"total": total,
+	})
+}
+
+// jiraErrorMessage extracts JIRA's own error messages from an error
+// response body, falling back to the raw body so the caller always sees
+// what JIRA said verbatim.
+func jiraErrorMessage(apiErr *jira.APIError) string {
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(apiErr.Body), &body); err == nil {
+		var messages []string
+		messages = append(messages, body.ErrorMessages...)
+		for _, msg := range body.Errors {
+			messages = append(messages, msg)
+		}
+		if len(messages) > 0 {
+			return strings.Join(messages, "; ")
+		}
+	}
+	return apiErr.Body
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/cron"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// defaultTaskRetention is how long finished task records are kept when
+// a compaction does not specify a retention.
+const defaultTaskRetention = 7 * 24 * time.Hour
+
+// compactRequest is the optional body of POST /maintenance/compact.
+type compactRequest struct {
+	// RetentionDays overrides how many days of finished task records to
+	// keep. Zero or omitted keeps the default week.
+	RetentionDays int `json:"retentionDays"`
+}
+
+// compactMaintenance handles POST /api/v1/maintenance/compact: prune
+// finished task records older than the retention and repack every
+// registered engine's repository, reporting the removed record count
+// and bytes freed. Compaction is rejected with 409 while any task is
+// pending or running or any engine is mid-sync — a repack must not race
+// an in-flight sync's commits — which makes it safe to run while syncs
+// are paused or idle.
+// The operation is idempotent: an immediate second run finds nothing
+// further to prune or fold.
+func (s *Server) compactMaintenance(c *gin.Context) {
+	var req compactRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+				"invalid request body: "+err.Error(), nil)
+			return
+		}
+	}
+	if req.RetentionDays < 0 {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+			"retentionDays must not be negative", nil)
+		return
+	}
+	retention := defaultTaskRetention
+	if req.RetentionDays > 0 {
+		retention = time.Duration(req.RetentionDays) * 24 * time.Hour
+	}
+
+	if task, busy := s.activeTask(); busy {
+		jsonError(c, http.StatusConflict, CodeOperationInProgress,
+			fmt.Sprintf("cannot compact while task %s is %s", task.ID, task.Status),
+			gin.H{"taskId": task.ID})
+		return
+	}
+	if project, syncing := s.syncingProject(); syncing {
+		jsonError(c, http.StatusConflict, CodeOperationInProgress,
+			"cannot compact while a sync is running for project "+project, nil)
+		return
+	}
+	removed, freed, err := s.runMaintenance(c.Request.Context(), retention)
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+	s.audit.Record(audit.Entry{
+		Action: audit.ActionMaintenance,
+		Actor:  actorFromRequest(c),
+		Detail: fmt.Sprintf("removed %d task records, freed %d bytes", removed, freed),
+	})
+	c.JSON(http.StatusOK, gin.H{
+		"removedTasks": removed,
+		"freedBytes":   freed,
+		"requestId":    requestID(c),
+	})
+}
+
+// activeTask returns a pending or running task, if any.
+func (s *Server) activeTask() (sync.ManagedTask, bool) {
+	for _, task := range s.tasks.List() {
+		if task.Status == sync.TaskPending || task.Status == sync.TaskRunning {
+			return task, true
+		}
+	}
+	return sync.ManagedTask{}, false
+}
+
+// syncingProject returns a project whose engine is mid-sync, if any.
+// Controller-scheduled reconciles call the engine directly rather than
+// through the task manager, so the task list alone cannot prove the
+// system is idle.
+func (s *Server) syncingProject() (string, bool) {
+	for _, project := range s.engines.Projects() {
+		if engine, ok := s.engines.Get(project); ok && engine.SyncInProgress() {
+			return project, true
+		}
+	}
+	return "", false
+}
+
+// runMaintenance prunes finished task records older than retention and
+// repacks each registered engine's repository once, returning the
+// pruned record count and total bytes freed.
+func (s *Server) runMaintenance(ctx context.Context, retention time.Duration) (int, int64, error) {
+	removed := s.tasks.CleanupOldOperations(retention)
+	var freed int64
+	// A multi-project engine is registered under each of its project
+	// keys; repack each engine's repository once.
+	seen := map[*sync.Engine]bool{}
+	for _, project := range s.engines.Projects() {
+		engine, ok := s.engines.Get(project)
+		if !ok || seen[engine] {
+			continue
+		}
+		seen[engine] = true
+		// Re-check right before the repack: a scheduled reconcile may
+		// have started since the caller's gate.
+		if engine.SyncInProgress() {
+			return removed, freed, fmt.Errorf("sync in progress for %s, repack aborted", project)
+		}
+		n, err := engine.Git().GarbageCollect(ctx)
+		if err != nil {
+			return removed, freed, fmt.Errorf("repack repository for %s: %w", project, err)
+		}
+		freed += n
+	}
+	return removed, freed, nil
+}
+
+// reconstructState handles POST /api/v1/maintenance/reconstruct-state:
+// rebuild each registered repository's per-issue sync state from its
+// committed issue files and commit log, as an asynchronous task. This
+// backfills the state file on mirrors populated before state tracking
+// existed, so the first reconcile after upgrading skips unchanged
+// issues instead of re-syncing everything. The reconstruction
+// checkpoints its progress, so a cancelled task resumes where it left
+// off when triggered again.
+func (s *Server) reconstructState(c *gin.Context) {
+	// A multi-project engine is registered under each of its project
+	// keys; reconstruct each engine's repository once.
+	seen := map[*sync.Engine]bool{}
+	var engines []*sync.Engine
+	for _, project := range s.engines.Projects() {
+		engine, ok := s.engines.Get(project)
+		if !ok || seen[engine] {
+			continue
+		}
+		seen[engine] = true
+		engines = append(engines, engine)
+	}
+	if len(engines) == 0 {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync engines configured", nil)
+		return
+	}
+	managed, err := s.tasks.SubmitExclusive("reconstruct-state", "reconstruct-state",
+		"reconstruct per-issue sync state from repository history",
+		func(ctx context.Context) error {
+			for _, engine := range engines {
+				if _, rerr := engine.Git().ReconstructState(ctx); rerr != nil {
+					return rerr
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		submitError(c, err)
+		return
+	}
+	s.tasks.SetRequestID(managed.ID, requestID(c))
+	s.audit.Record(audit.Entry{
+		Action: audit.ActionStateReconstruct,
+		Actor:  actorFromRequest(c),
+		Detail: "state reconstruction task " + managed.ID,
+	})
+	c.JSON(http.StatusAccepted, gin.H{
+		"taskId":    managed.ID,
+		"requestId": requestID(c),
+	})
+}
+
+// MaintenanceScheduler runs the same compaction as
+// POST /api/v1/maintenance/compact on a cron schedule. It implements
+// manager.Runnable without opting out of leader election, so a
+// multi-replica deployment compacts once per tick, on the leader. Ticks
+// that coincide with an in-flight operation are skipped rather than
+// queued; the next tick picks the work up.
+type MaintenanceScheduler struct {
+	// Server is the API server whose task manager and engines are
+	// compacted.
+	Server *Server
+	// Schedule decides when compaction runs.
+	Schedule *cron.Schedule
+	// Retention overrides defaultTaskRetention when positive.
+	Retention time.Duration
+}
+
+// Start runs scheduled compactions until the context is cancelled.
+func (m *MaintenanceScheduler) Start(ctx context.Context) error {
+	logger := ctrllog.FromContext(ctx).WithName("maintenance")
+	retention := m.Retention
+	if retention <= 0 {
+		retention = defaultTaskRetention
+	}
+	for {
+		next := m.Schedule.Next(time.Now())
+		if next.IsZero() {
+			// The schedule never fires within the search horizon (e.g.
+			// Feb 30); waiting on it would spin the loop hot instead.
+			logger.Info("maintenance schedule never fires, scheduler exiting")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+		if task, busy := m.Server.activeTask(); busy {
+			logger.Info("skipping scheduled compaction, operation in progress",
+				"taskID", task.ID)
+			continue
+		}
+		if project, syncing := m.Server.syncingProject(); syncing {
+			logger.Info("skipping scheduled compaction, sync in progress",
+				"project", project)
+			continue
+		}
+		removed, freed, err := m.Server.runMaintenance(ctx, retention)
+		if err != nil {
+			logger.Error(err, "scheduled compaction failed")
+			continue
+		}
+		logger.Info("compaction complete", "removedTasks", removed, "freedBytes", freed)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+)
+
+// pauseProject patches spec.sync.paused=true on the resource mirroring
+// the project, durably suspending sync cycles.
+func (s *Server) pauseProject(c *gin.Context) {
+	s.setPaused(c, true)
+}
+
+// resumeProject clears spec.sync.paused so the next reconcile picks up
+// from the last sync watermark.
+func (s *Server) resumeProject(c *gin.Context) {
+	s.setPaused(c, false)
+}
+
+func (s *Server) setPaused(c *gin.Context, paused bool) {
+	if s.kube == nil {
+		jsonError(c, http.StatusServiceUnavailable, CodeNotReady, "kubernetes client not configured", nil)
+		return
+	}
+	projectKey := c.Param("projectKey")
+	cdc, err := s.findResourceForProject(c.Request.Context(), projectKey)
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+	if cdc == nil {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no JiraCDC resource mirrors project "+projectKey, nil)
+		return
+	}
+
+	patch := client.MergeFrom(cdc.DeepCopy())
+	cdc.Spec.Sync.Paused = paused
+	if err := s.kube.Patch(c.Request.Context(), cdc, patch); err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+	action := audit.ActionPause
+	if !paused {
+		action = audit.ActionResume
+	}
+	s.audit.RecordEvent(cdc, audit.Entry{
+		Action:   action,
+		Actor:    actorFromRequest(c),
+		Resource: cdc.Namespace + "/" + cdc.Name,
+		Project:  projectKey,
+	})
+	c.JSON(http.StatusOK, gin.H{
+		"projectKey": projectKey,
+		"resource":   cdc.Namespace + "/" + cdc.Name,
+		"paused":     paused,
+	})
+}
+
+// findResourceForProject locates the JiraCDC resource whose project list
+// contains projectKey.
+func (s *Server) findResourceForProject(ctx context.Context, projectKey string) (*cdcv1.JiraCDC, error) {
+	var list cdcv1.JiraCDCList
+	if err := s.kube.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		for _, key := range list.Items[i].Spec.Jira.Projects() {
+			if key == projectKey {
+				return &list.Items[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// ReasonSyncStarted is the event reason emitted when a reconcile is
+// triggered through the admin API.
+const ReasonSyncStarted = "SyncStarted"
+
+// sinceJQLLayout is the timestamp form JQL accepts in updated clauses.
+const sinceJQLLayout = "2006/01/02 15:04"
+
+// reconcileRequest scopes a triggered reconcile. At most one of the
+// fields may be set; an empty request reconciles every configured
+// project in full.
+type reconcileRequest struct {
+	// ProjectKeys limits the reconcile to the listed projects.
+	ProjectKeys []string `json:"projectKeys"`
+	// IssueKeys limits the reconcile to the listed issues.
+	IssueKeys []string `json:"issueKeys"`
+	// Since limits the reconcile to issues updated at or after the
+	// given RFC 3339 timestamp.
+	Since string `json:"since"`
+}
+
+// reconcileNow triggers a targeted reconcile as an asynchronous task,
+// without editing the JiraCDC resource. The scope is validated to be at
+// most one of projectKeys, issueKeys, or since; the created task ID is
+// returned for polling. Submissions share the task manager's exclusive
+// keys and the engines' own operation guards, so a reconcile cannot
+// overlap an already-running operation on the same scope.
+func (s *Server) reconcileNow(c *gin.Context) {
+	var req reconcileRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed request body: "+err.Error(), nil)
+			return
+		}
+	}
+	scopes := 0
+	if len(req.ProjectKeys) > 0 {
+		scopes++
+	}
+	if len(req.IssueKeys) > 0 {
+		scopes++
+	}
+	if req.Since != "" {
+		scopes++
+	}
+	if scopes > 1 {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+			"projectKeys, issueKeys, and since are mutually exclusive", nil)
+		return
+	}
+
+	switch {
+	case len(req.IssueKeys) > 0:
+		s.reconcileIssues(c, req.IssueKeys)
+	case req.Since != "":
+		s.reconcileSince(c, req.Since)
+	default:
+		s.reconcileProjects(c, req.ProjectKeys)
+	}
+}
+
+// reconcileProjects runs a full sync of the given projects, or of every
+// configured project when none are named.
+func (s *Server) reconcileProjects(c *gin.Context, projects []string) {
+	if len(projects) == 0 {
+		projects = s.engines.Projects()
+	}
+	if len(projects) == 0 {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync engines configured", nil)
+		return
+	}
+	engines, err := s.resolveEngines(projects)
+	if err != nil {
+		jsonError(c, http.StatusNotFound, CodeNotFound, err.Error(), nil)
+		return
+	}
+	scope := "projects " + strings.Join(projects, ", ")
+	s.submitReconcile(c, scope, projects, func(ctx context.Context) error {
+		for _, engine := range engines {
+			if err := engine.Bootstrap(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// reconcileIssues resyncs the listed issues through one bulk fetch per
+// owning engine.
+func (s *Server) reconcileIssues(c *gin.Context, keys []string) {
+	byProject := make(map[string][]string)
+	for _, key := range keys {
+		if !issueKeyPattern.MatchString(key) {
+			jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed issue key "+key, nil)
+			return
+		}
+		project := projectFromIssueKey(key)
+		byProject[project] = append(byProject[project], key)
+	}
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	if _, err := s.resolveEngines(projects); err != nil {
+		jsonError(c, http.StatusNotFound, CodeNotFound, err.Error(), nil)
+		return
+	}
+	scope := fmt.Sprintf("%d issues (%s)", len(keys), strings.Join(keys, ", "))
+	s.submitReconcile(c, scope, projects, func(ctx context.Context) error {
+		for _, project := range projects {
+			engine, ok := s.engines.Get(project)
+			if !ok {
+				continue
+			}
+			if _, _, err := engine.SyncIssueBatch(ctx, byProject[project]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// reconcileSince resyncs every issue updated at or after the given
+// timestamp, discovered through one JQL search per configured project.
+func (s *Server) reconcileSince(c *gin.Context, since string) {
+	cutoff, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+			"since must be an RFC 3339 timestamp: "+err.Error(), nil)
+		return
+	}
+	projects := s.engines.Projects()
+	if len(projects) == 0 {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync engines configured", nil)
+		return
+	}
+	scope := "issues updated since " + cutoff.UTC().Format(time.RFC3339)
+	s.submitReconcile(c, scope, projects, func(ctx context.Context) error {
+		for _, project := range projects {
+			engine, ok := s.engines.Get(project)
+			if !ok {
+				continue
+			}
+			keys, err := searchUpdatedKeys(ctx, engine.Jira(), project, cutoff)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			if _, _, err := engine.SyncIssueBatch(ctx, keys); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// resolveEngines looks up the engine of every listed project, erroring
+// on the first project without one.
+func (s *Server) resolveEngines(projects []string) ([]*sync.Engine, error) {
+	seen := make(map[*sync.Engine]struct{}, len(projects))
+	var engines []*sync.Engine
+	for _, project := range projects {
+		engine, ok := s.engines.Get(project)
+		if !ok {
+			return nil, fmt.Errorf("no sync configured for project %s", project)
+		}
+		if _, dup := seen[engine]; dup {
+			continue
+		}
+		seen[engine] = struct{}{}
+		engines = append(engines, engine)
+	}
+	return engines, nil
+}
+
+// submitReconcile queues the reconcile under the shared exclusive key,
+// records the trigger, and emits SyncStarted events naming the scope on
+// the resources mirroring the affected projects.
+func (s *Server) submitReconcile(c *gin.Context, scope string, projects []string, run func(ctx context.Context) error) {
+	managed, err := s.tasks.SubmitExclusive("reconcile", "reconcile",
+		"targeted reconcile of "+scope, run)
+	if err != nil {
+		submitError(c, err)
+		return
+	}
+	s.tasks.SetRequestID(managed.ID, requestID(c))
+	if len(projects) > 0 {
+		s.tasks.Tag(managed.ID, projects[0])
+	}
+	s.audit.Record(audit.Entry{
+		Action: audit.ActionSyncTrigger,
+		Actor:  actorFromRequest(c),
+		Detail: fmt.Sprintf("targeted reconcile of %s, task %s", scope, managed.ID),
+	})
+	s.recordSyncStarted(c.Request.Context(), projects, scope)
+	c.JSON(http.StatusAccepted, gin.H{
+		"taskId":    managed.ID,
+		"scope":     scope,
+		"requestId": requestID(c),
+	})
+}
+
+// recordSyncStarted emits a SyncStarted event on each resource mirroring
+// an affected project, when one can be resolved.
+func (s *Server) recordSyncStarted(ctx context.Context, projects []string, scope string) {
+	if s.recorder == nil || s.kube == nil {
+		return
+	}
+	notified := make(map[string]struct{}, len(projects))
+	for _, project := range projects {
+		cdc, err := s.findResourceForProject(ctx, project)
+		if err != nil || cdc == nil {
+			continue
+		}
+		name := cdc.Namespace + "/" + cdc.Name
+		if _, done := notified[name]; done {
+			continue
+		}
+		notified[name] = struct{}{}
+		s.recorder.Event(cdc, corev1.EventTypeNormal, ReasonSyncStarted,
+			"Reconcile triggered for "+scope)
+	}
+}
+
+// searchUpdatedKeys pages through one project's issues updated at or
+// after cutoff, returning their keys.
+func searchUpdatedKeys(ctx context.Context, client *jira.Client, project string, cutoff time.Time) ([]string, error) {
+	jql := fmt.Sprintf("project = %s AND updated >= %q ORDER BY key ASC",
+		project, cutoff.Format(sinceJQLLayout))
+	var keys []string
+	for {
+		page, err := client.SearchIssues(ctx, jql, len(keys), 100)
+		if err != nil {
+			return nil, fmt.Errorf("search updated issues for %s: %w", project, err)
+		}
+		for i := range page.Issues {
+			keys = append(keys, page.Issues[i].Key)
+		}
+		if len(page.Issues) == 0 || len(keys) >= page.Total {
+			return keys, nil
+		}
+	}
+}
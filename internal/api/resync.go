@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// ReasonIssueProcessed is the event reason emitted when a targeted
+// resync completes.
+const ReasonIssueProcessed = "IssueProcessed"
+
+// issueKeyPattern matches well-formed JIRA issue keys like "PROJ-123".
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*-\d+$`)
+
+// resyncIssue force-refreshes a single issue from JIRA via a targeted
+// task, returning the task ID for polling. The task record carries the
+// resulting commit hash once it completes.
+func (s *Server) resyncIssue(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	key := c.Param("key")
+	if !issueKeyPattern.MatchString(key) {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed issue key "+key, nil)
+		return
+	}
+	if projectFromIssueKey(key) != projectKey {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest,
+			fmt.Sprintf("issue %s does not belong to project %s", key, projectKey), nil)
+		return
+	}
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+projectKey, nil)
+		return
+	}
+
+	if _, err := engine.Jira().GetIssue(c.Request.Context(), key); err != nil {
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			jsonError(c, http.StatusNotFound, CodeNotFound, "issue "+key+" not found in JIRA", nil)
+			return
+		}
+		respondClassified(c, err)
+		return
+	}
+
+	managed, err := s.tasks.SubmitExclusiveResult("issue:"+key, "issue-resync",
+		fmt.Sprintf("force resync of %s", key),
+		func(ctx context.Context) (string, error) {
+			hash, rerr := engine.ResyncIssue(ctx, key)
+			if rerr != nil {
+				return "", rerr
+			}
+			s.recordIssueProcessed(ctx, projectKey, key, hash)
+			return hash, nil
+		})
+	if err != nil {
+		submitError(c, err)
+		return
+	}
+	s.tasks.Tag(managed.ID, projectKey)
+	s.tasks.SetRequestID(managed.ID, requestID(c))
+	s.audit.Record(audit.Entry{
+		Action:  audit.ActionIssueResync,
+		Actor:   actorFromRequest(c),
+		Project: projectKey,
+		Issue:   key,
+		Detail:  "task " + managed.ID,
+	})
+	c.JSON(http.StatusAccepted, gin.H{"taskId": managed.ID, "requestId": requestID(c)})
+}
+
+// recordIssueProcessed emits an IssueProcessed event on the JiraCDC
+// resource mirroring the project, when one can be resolved.
+func (s *Server) recordIssueProcessed(ctx context.Context, projectKey, key, hash string) {
+	if s.recorder == nil || s.kube == nil {
+		return
+	}
+	cdc, err := s.findResourceForProject(ctx, projectKey)
+	if err != nil || cdc == nil {
+		return
+	}
+	message := fmt.Sprintf("Resynced issue %s", key)
+	if hash != "" {
+		message += " in commit " + hash
+	}
+	s.recorder.Event(cdc, corev1.EventTypeNormal, ReasonIssueProcessed, message)
+}
@@ -0,0 +1,228 @@
+// Package api serves the operator's REST API: operational visibility
+// into sync state and admin actions that fall outside the declarative
+// CRD surface.
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	gosync "sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// Options wires the server's collaborators.
+type Options struct {
+	// Addr is the listen address.
+	Addr string
+	// DeadLetters exposes permanently failed issues.
+	DeadLetters *sync.DeadLetterStore
+	// Health runs connectivity probes for readiness.
+	Health *HealthChecker
+	// Tasks executes asynchronous operations triggered via the API.
+	Tasks *sync.TaskManager
+	// Engines resolves sync engines by project key.
+	Engines *sync.EngineRegistry
+	// Breakers exposes circuit breaker states in the metrics summary.
+	// Nil omits them.
+	Breakers *cdcerrors.CircuitBreakerManager
+	// StatusBus feeds the WebSocket status endpoint with project and
+	// task events. Nil disables it.
+	StatusBus *sync.StatusBus
+	// WebhookSecret is the shared HMAC secret for the JIRA webhook
+	// receiver. Empty disables signature validation.
+	WebhookSecret []byte
+	// Kube patches JiraCDC resources for admin actions like pause and
+	// resume. Nil disables those endpoints.
+	Kube client.Client
+	// Recorder emits Kubernetes events for API-triggered operations.
+	// Nil disables event emission.
+	Recorder record.EventRecorder
+	// Audit records mutating API operations. Nil disables auditing.
+	Audit *audit.Logger
+	// Auth configures request authentication for /api/v1 routes. Nil or
+	// empty leaves the API open; health and webhook endpoints are never
+	// gated.
+	Auth *AuthConfig
+}
+
+// Server is the HTTP API operand. It implements manager.Runnable so it
+// can be driven by the controller manager's lifecycle.
+type Server struct {
+	addr          string
+	router        *gin.Engine
+	deadLetters   *sync.DeadLetterStore
+	health        *HealthChecker
+	tasks         *sync.TaskManager
+	engines       *sync.EngineRegistry
+	breakers      *cdcerrors.CircuitBreakerManager
+	summary       summaryCache
+	statusBus     *sync.StatusBus
+	webhookSecret []byte
+
+	// wsMu guards wsConns, the active WebSocket connections closed
+	// during server drain. wsShutdown signals their write loops to send
+	// a going-away close frame first.
+	wsMu       gosync.Mutex
+	wsConns    map[net.Conn]struct{}
+	wsShutdown chan struct{}
+	kube       client.Client
+	recorder   record.EventRecorder
+	audit      *audit.Logger
+	auth       *AuthConfig
+}
+
+// NewServer builds the API server with its routes registered.
+func NewServer(opts Options) *Server {
+	gin.SetMode(gin.ReleaseMode)
+	s := &Server{
+		addr:          opts.Addr,
+		router:        gin.New(),
+		deadLetters:   opts.DeadLetters,
+		health:        opts.Health,
+		tasks:         opts.Tasks,
+		engines:       opts.Engines,
+		breakers:      opts.Breakers,
+		statusBus:     opts.StatusBus,
+		wsConns:       map[net.Conn]struct{}{},
+		wsShutdown:    make(chan struct{}),
+		webhookSecret: opts.WebhookSecret,
+		kube:          opts.Kube,
+		recorder:      opts.Recorder,
+		audit:         opts.Audit,
+		auth:          opts.Auth,
+	}
+	s.router.Use(gin.Recovery())
+	s.registerRoutes()
+	s.registerHealthRoutes()
+	s.registerWebhookRoutes()
+	return s
+}
+
+func (s *Server) registerRoutes() {
+	v1 := s.router.Group("/api/v1")
+	if s.auth.enabled() {
+		v1.Use(s.requireAuth())
+	}
+	v1.GET("/projects/:projectKey/failures", s.listFailures)
+	v1.POST("/projects/:projectKey/failures/retry", s.retryFailures)
+	v1.POST("/projects/:projectKey/pause", s.pauseProject)
+	v1.POST("/projects/:projectKey/resume", s.resumeProject)
+	v1.POST("/projects/:projectKey/sync", s.triggerSync)
+	v1.GET("/projects/:projectKey/export", s.exportProject)
+	v1.GET("/projects/:projectKey/drift", s.getDrift)
+	v1.POST("/projects/:projectKey/issues/:key/resync", s.resyncIssue)
+	v1.GET("/projects/:projectKey/issues/:key/status", s.getIssueSyncStatus)
+	v1.GET("/issues/:key", s.getIssue)
+	v1.GET("/issues/:key/history", s.getIssueHistory)
+	v1.GET("/metrics/summary", s.getMetricsSummary)
+	v1.GET("/ws", s.statusWebSocket)
+	v1.DELETE("/tasks/:taskID", s.cancelTask)
+	v1.POST("/jira/jql/validate", s.validateJQL)
+	v1.POST("/reconcile", s.reconcileNow)
+	v1.POST("/validate", s.validateSpec)
+	v1.POST("/graphql", s.graphQL)
+	v1.POST("/maintenance/compact", s.compactMaintenance)
+	v1.POST("/maintenance/reconstruct-state", s.reconstructState)
+}
+
+// listFailures returns the dead-lettered issues for a project.
+func (s *Server) listFailures(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	c.JSON(http.StatusOK, gin.H{
+		"projectKey": projectKey,
+		"failures":   s.deadLetters.List(projectKey),
+	})
+}
+
+// retryFailures clears a project's dead-letter entries and re-syncs
+// them through a single bulk fetch rather than waiting for the next
+// full reconcile.
+func (s *Server) retryFailures(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	retried := s.deadLetters.RetryFailures(projectKey)
+	s.audit.Record(audit.Entry{
+		Action:  audit.ActionRetryFailures,
+		Actor:   actorFromRequest(c),
+		Project: projectKey,
+	})
+	response := gin.H{
+		"projectKey": projectKey,
+		"retried":    retried,
+	}
+	if engine, ok := s.engines.Get(projectKey); ok && len(retried) > 0 {
+		managed, err := s.tasks.Submit("bulk-resync",
+			fmt.Sprintf("bulk resync of %d retried issues in %s", len(retried), projectKey),
+			func(ctx context.Context) error {
+				_, _, rerr := engine.SyncIssueBatch(ctx, retried)
+				return rerr
+			})
+		if err != nil {
+			submitError(c, err)
+			return
+		}
+		s.tasks.Tag(managed.ID, projectKey)
+		s.tasks.SetRequestID(managed.ID, requestID(c))
+		response["taskId"] = managed.ID
+	}
+	response["requestId"] = requestID(c)
+	c.JSON(http.StatusOK, response)
+}
+
+// actorFromRequest identifies who made an API request for audit
+// entries: the identity the auth middleware established, then the
+// authenticated user forwarded by the ingress, then the client address.
+func actorFromRequest(c *gin.Context) string {
+	if user := c.GetString(authUserKey); user != "" {
+		return user
+	}
+	if user := c.GetHeader("X-Remote-User"); user != "" {
+		return user
+	}
+	return c.ClientIP()
+}
+
+// NeedLeaderElection marks the API server as a non-leader runnable: in
+// HA deployments every replica serves webhooks and API requests, while
+// only the leader schedules syncs.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the HTTP server until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		// Hijacked WebSocket connections are invisible to Shutdown, so
+		// drain them explicitly first.
+		s.closeWebSockets()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
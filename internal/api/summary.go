@@ -0,0 +1,127 @@
+package api
+
+import (
+	"net/http"
+	syncpkg "sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// summaryCacheTTL is how long an assembled summary is served before it
+// is recomputed. The endpoint backs dashboard polling, so a few seconds
+// of staleness is fine in exchange for not walking every engine and
+// task record per request.
+const summaryCacheTTL = 5 * time.Second
+
+// errorRateWindow is the sliding window the task error rate is computed
+// over.
+const errorRateWindow = time.Hour
+
+// SummaryResponse is the aggregated operational snapshot served by
+// GET /api/v1/metrics/summary. It is a UI-friendly complement to the
+// Prometheus /metrics endpoint, not a replacement for it.
+type SummaryResponse struct {
+	// TotalIssuesSynced sums issues completed by the current or most
+	// recent operation of every registered engine.
+	TotalIssuesSynced int `json:"totalIssuesSynced"`
+	// LastSyncTime is when the most recent successful task finished,
+	// omitted if no task has completed since the process started.
+	LastSyncTime *time.Time `json:"lastSyncTime,omitempty"`
+	// ActiveOperations counts tasks currently pending or running.
+	ActiveOperations int `json:"activeOperations"`
+	// ErrorRateLastHour is the fraction (0..1) of tasks finishing in the
+	// last hour that failed. Zero when no task finished in the window.
+	ErrorRateLastHour float64 `json:"errorRateLastHour"`
+	// CircuitBreakers maps breaker names to their current state.
+	CircuitBreakers map[string]string `json:"circuitBreakers,omitempty"`
+	// RateLimiter aggregates JIRA rate limiter counters across engines.
+	RateLimiter SummaryRateLimiter `json:"rateLimiter"`
+	// GeneratedAt is when this summary was assembled; cached responses
+	// keep the original timestamp.
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// SummaryRateLimiter is the cross-engine rollup of JIRA rate limiter
+// counters included in a summary.
+type SummaryRateLimiter struct {
+	Requests        int64 `json:"requests"`
+	Throttled       int64 `json:"throttled"`
+	ServerLimitHits int64 `json:"serverLimitHits"`
+}
+
+// summaryCache memoizes the last assembled summary for summaryCacheTTL.
+type summaryCache struct {
+	mu       syncpkg.Mutex
+	response SummaryResponse
+	at       time.Time
+}
+
+// getMetricsSummary serves the cached operational summary, assembling a
+// fresh one when the cache has expired.
+func (s *Server) getMetricsSummary(c *gin.Context) {
+	s.summary.mu.Lock()
+	defer s.summary.mu.Unlock()
+	if time.Since(s.summary.at) >= summaryCacheTTL {
+		s.summary.response = s.buildSummary()
+		s.summary.at = time.Now()
+	}
+	c.JSON(http.StatusOK, s.summary.response)
+}
+
+// buildSummary assembles a summary from the task manager, the engine
+// registry and the circuit breakers.
+func (s *Server) buildSummary() SummaryResponse {
+	summary := SummaryResponse{GeneratedAt: time.Now()}
+
+	var finished, failed int
+	cutoff := summary.GeneratedAt.Add(-errorRateWindow)
+	for _, task := range s.tasks.List() {
+		switch task.Status {
+		case sync.TaskPending, sync.TaskRunning:
+			summary.ActiveOperations++
+		case sync.TaskCompleted:
+			if task.FinishedAt != nil &&
+				(summary.LastSyncTime == nil || task.FinishedAt.After(*summary.LastSyncTime)) {
+				at := *task.FinishedAt
+				summary.LastSyncTime = &at
+			}
+		}
+		if task.FinishedAt == nil || task.FinishedAt.Before(cutoff) {
+			continue
+		}
+		finished++
+		if task.Status == sync.TaskFailed {
+			failed++
+		}
+	}
+	if finished > 0 {
+		summary.ErrorRateLastHour = float64(failed) / float64(finished)
+	}
+
+	// A multi-project engine is registered under each of its project
+	// keys; count each engine once.
+	seen := map[*sync.Engine]bool{}
+	for _, project := range s.engines.Projects() {
+		engine, ok := s.engines.Get(project)
+		if !ok || seen[engine] {
+			continue
+		}
+		seen[engine] = true
+		summary.TotalIssuesSynced += engine.Progress().Snapshot().Completed
+		stats := engine.Jira().RateLimitStats()
+		summary.RateLimiter.Requests += stats.Requests
+		summary.RateLimiter.Throttled += stats.Throttled
+		summary.RateLimiter.ServerLimitHits += stats.ServerLimitHits
+	}
+
+	if s.breakers != nil {
+		summary.CircuitBreakers = map[string]string{}
+		for name, state := range s.breakers.States() {
+			summary.CircuitBreakers[name] = string(state)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// ReasonSyncCancelled is the event reason emitted when a task is
+// cancelled via the API.
+const ReasonSyncCancelled = "SyncCancelled"
+
+// cancelTask cancels a pending or running task by ID. A pending task is
+// cancelled immediately; a running task has its context cancelled,
+// which propagates into in-flight JIRA and git calls, and reports
+// "cancelled" once the operation unwinds. Already-finished tasks answer
+// 409, unknown IDs 404.
+func (s *Server) cancelTask(c *gin.Context) {
+	taskID := c.Param("taskID")
+	task, ok := s.tasks.Get(taskID)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no task with ID "+taskID, nil)
+		return
+	}
+	if err := s.tasks.Cancel(taskID); err != nil {
+		switch {
+		case errors.Is(err, sync.ErrTaskNotFound):
+			jsonError(c, http.StatusNotFound, CodeNotFound, "no task with ID "+taskID, nil)
+		case errors.Is(err, sync.ErrTaskTerminal):
+			jsonError(c, http.StatusConflict, CodeTaskTerminal,
+				fmt.Sprintf("task %s already finished with status %s", taskID, task.Status), nil)
+		default:
+			jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		}
+		return
+	}
+	s.audit.Record(audit.Entry{
+		Action:  audit.ActionTaskCancel,
+		Actor:   actorFromRequest(c),
+		Project: task.Project,
+		Detail:  "task " + taskID,
+	})
+	s.recordSyncCancelled(c.Request.Context(), task.Project, taskID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"taskId": taskID,
+		"status": "cancelling",
+	})
+}
+
+// recordSyncCancelled emits a SyncCancelled event on the JiraCDC
+// resource mirroring the task's project, when one can be resolved.
+func (s *Server) recordSyncCancelled(ctx context.Context, projectKey, taskID string) {
+	if s.recorder == nil || s.kube == nil || projectKey == "" {
+		return
+	}
+	cdc, err := s.findResourceForProject(ctx, projectKey)
+	if err != nil || cdc == nil {
+		return
+	}
+	s.recorder.Event(cdc, corev1.EventTypeNormal, ReasonSyncCancelled,
+		fmt.Sprintf("Cancelled task %s", taskID))
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+)
+
+// triggerSync starts a full sync cycle for a project as an asynchronous
+// task, outside the regular reconcile schedule. Retried client requests
+// can carry an Idempotency-Key header: a repeat of the same key within
+// the task manager's idempotency window answers 200 with the original
+// task ("status": "replayed") instead of creating a duplicate, while a
+// fresh submission answers 202 ("status": "accepted"). Keys are scoped
+// per project.
+func (s *Server) triggerSync(c *gin.Context) {
+	projectKey := c.Param("projectKey")
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound, "no sync configured for project "+projectKey, nil)
+		return
+	}
+
+	idemKey := c.GetHeader("Idempotency-Key")
+	if idemKey != "" {
+		idemKey = projectKey + ":" + idemKey
+	}
+	managed, replayed, err := s.tasks.SubmitExclusiveIdempotent(idemKey,
+		"project:"+projectKey, "project-sync",
+		fmt.Sprintf("triggered sync of %s", projectKey),
+		func(ctx context.Context) error {
+			return engine.Bootstrap(ctx)
+		})
+	if err != nil {
+		submitError(c, err)
+		return
+	}
+	if replayed {
+		c.JSON(http.StatusOK, gin.H{
+			"taskId":    managed.ID,
+			"status":    "replayed",
+			"replayed":  true,
+			"task":      managed,
+			"requestId": requestID(c),
+		})
+		return
+	}
+	s.tasks.Tag(managed.ID, projectKey)
+	s.tasks.SetRequestID(managed.ID, requestID(c))
+	s.audit.Record(audit.Entry{
+		Action:  audit.ActionSyncTrigger,
+		Actor:   actorFromRequest(c),
+		Project: projectKey,
+		Detail:  "task " + managed.ID,
+	})
+	c.JSON(http.StatusAccepted, gin.H{
+		"taskId":    managed.ID,
+		"status":    "accepted",
+		"replayed":  false,
+		"requestId": requestID(c),
+	})
+}
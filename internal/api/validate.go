@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/webhooks"
+)
+
+// validateSpec dry-runs a JiraCDC spec through the same defaulting and
+// validation the admission webhook applies, without creating anything.
+// The response lists every validation error and warning at once and
+// echoes the effective spec after defaulting, so users can preview a
+// configuration before submitting it to the cluster. Pre-flight
+// connectivity checks are not run here; they need the referenced
+// secrets and only happen at admission.
+func (s *Server) validateSpec(c *gin.Context) {
+	var spec cdcv1.JiraCDCSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed request body", nil)
+		return
+	}
+
+	effective := spec.DeepCopy()
+	webhooks.ApplyDefaults(effective)
+	warnings, errs := webhooks.Validate(effective)
+
+	errorMessages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		errorMessages = append(errorMessages, err.Error())
+	}
+	if warnings == nil {
+		warnings = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"valid":         len(errs) == 0,
+		"errors":        errorMessages,
+		"warnings":      warnings,
+		"effectiveSpec": effective,
+	})
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// maxWebhookEventAge rejects replayed or long-delayed webhook
+// deliveries.
+const maxWebhookEventAge = 5 * time.Minute
+
+// jiraWebhookEvent is the subset of the JIRA webhook payload the
+// receiver consumes.
+type jiraWebhookEvent struct {
+	// Timestamp is the event time in epoch milliseconds.
+	Timestamp    int64  `json:"timestamp"`
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        *struct {
+		Key string `json:"key"`
+	} `json:"issue"`
+}
+
+// registerWebhookRoutes adds the JIRA push-event receiver.
+func (s *Server) registerWebhookRoutes() {
+	s.router.POST("/webhooks/jira", s.handleJiraWebhook)
+}
+
+// handleJiraWebhook validates and enqueues a JIRA push event. The
+// actual sync happens asynchronously on the task manager so the
+// response stays fast.
+func (s *Server) handleJiraWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+	if err != nil {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "unreadable body", nil)
+		return
+	}
+
+	if len(s.webhookSecret) > 0 {
+		if !validSignature(body, c.GetHeader("X-Hub-Signature"), s.webhookSecret) {
+			jsonError(c, http.StatusUnauthorized, CodeUnauthorized, "invalid signature", nil)
+			return
+		}
+	}
+
+	var event jiraWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "malformed payload", nil)
+		return
+	}
+	if event.Issue == nil || event.Issue.Key == "" {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "payload has no issue", nil)
+		return
+	}
+	if event.Timestamp > 0 {
+		eventTime := time.UnixMilli(event.Timestamp)
+		if time.Since(eventTime) > maxWebhookEventAge {
+			jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "event too old", nil)
+			return
+		}
+	}
+
+	issueKey := event.Issue.Key
+	projectKey := projectFromIssueKey(issueKey)
+	engine, ok := s.engines.Get(projectKey)
+	if !ok {
+		jsonError(c, http.StatusNotFound, CodeNotFound,
+			fmt.Sprintf("no sync configured for project %s", projectKey), nil)
+		return
+	}
+
+	switch event.WebhookEvent {
+	case "jira:issue_created", "jira:issue_updated":
+		managed, err := s.tasks.SubmitExclusive("issue:"+issueKey, "issue-sync",
+			fmt.Sprintf("webhook sync of %s", issueKey),
+			func(ctx context.Context) error { return engine.SyncIssue(ctx, issueKey) })
+		if err != nil {
+			submitError(c, err)
+			return
+		}
+		s.tasks.SetRequestID(managed.ID, requestID(c))
+		s.audit.Record(audit.Entry{
+			Action:  audit.ActionSyncTrigger,
+			Actor:   "jira-webhook",
+			Project: projectKey,
+			Issue:   issueKey,
+			Detail:  event.WebhookEvent,
+		})
+		c.JSON(http.StatusAccepted, gin.H{"taskId": managed.ID, "requestId": requestID(c)})
+	case "jira:issue_deleted":
+		managed, err := s.tasks.SubmitExclusive("issue:"+issueKey, "issue-delete",
+			fmt.Sprintf("webhook delete of %s", issueKey),
+			func(ctx context.Context) error { return engine.DeleteIssue(ctx, issueKey) })
+		if err != nil {
+			submitError(c, err)
+			return
+		}
+		s.tasks.SetRequestID(managed.ID, requestID(c))
+		s.audit.Record(audit.Entry{
+			Action:  audit.ActionSyncTrigger,
+			Actor:   "jira-webhook",
+			Project: projectKey,
+			Issue:   issueKey,
+			Detail:  event.WebhookEvent,
+		})
+		c.JSON(http.StatusAccepted, gin.H{"taskId": managed.ID, "requestId": requestID(c)})
+	default:
+		c.JSON(http.StatusOK, gin.H{"ignored": event.WebhookEvent})
+	}
+}
+
+// submitError maps task submission failures to HTTP responses: an
+// already-running exclusive operation is a 409 pointing at the in-flight
+// task, anything else is backpressure with a retry hint.
+func submitError(c *gin.Context, err error) {
+	var inProgress *sync.ErrOperationInProgress
+	if errors.As(err, &inProgress) {
+		jsonError(c, http.StatusConflict, CodeOperationInProgress, err.Error(),
+			gin.H{"taskId": inProgress.TaskID})
+		return
+	}
+	jsonError(c, http.StatusServiceUnavailable, CodeQueueFull, err.Error(),
+		gin.H{"retryAfter": defaultRetryAfterSeconds})
+}
+
+// validSignature verifies an "sha256=<hex>" HMAC header over the body.
+func validSignature(body []byte, header string, secret []byte) bool {
+	signature := strings.TrimPrefix(header, "sha256=")
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// projectFromIssueKey derives the project key from an issue key like
+// "PROJ-123".
+func projectFromIssueKey(issueKey string) string {
+	if idx := strings.LastIndex(issueKey, "-"); idx > 0 {
+		return issueKey[:idx]
+	}
+	return issueKey
+}
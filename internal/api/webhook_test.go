@@ -0,0 +1,63 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWebhookSignatureValidation verifies the JIRA receiver rejects
+// deliveries whose HMAC is missing or wrong when a secret is
+// configured, and accepts a correctly signed body. A valid signature
+// over a project without an engine answers 404, proving the request
+// got past signature validation.
+func TestWebhookSignatureValidation(t *testing.T) {
+	secret := []byte("webhook-secret")
+	s := newTestServer(Options{WebhookSecret: secret})
+	body := `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	signed := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	cases := []struct {
+		name      string
+		signature string
+		want      int
+	}{
+		{"missing signature", "", http.StatusUnauthorized},
+		{"wrong signature", "sha256=" + strings.Repeat("ab", 32), http.StatusUnauthorized},
+		{"valid signature", signed, http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/jira", strings.NewReader(body))
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature", tc.signature)
+			}
+			rec := httptest.NewRecorder()
+			s.router.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Errorf("got %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+// TestWebhookWithoutSecretSkipsValidation verifies signature checking
+// stays off when no secret is configured, matching the documented
+// opt-in behavior.
+func TestWebhookWithoutSecretSkipsValidation(t *testing.T) {
+	s := newTestServer(Options{})
+	body := `{"webhookEvent":"jira:issue_updated","issue":{"key":"PROJ-1"}}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/jira", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	if rec.Code == http.StatusUnauthorized {
+		t.Errorf("unsigned delivery rejected with %d despite no configured secret", rec.Code)
+	}
+}
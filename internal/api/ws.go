@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	gosync "sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// websocketGUID is the fixed GUID the RFC 6455 handshake concatenates
+// with the client's key.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes used by the status endpoint.
+const (
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xA
+)
+
+// wsCloseGoingAway and wsClosePolicy are the close codes sent on server
+// drain and on slow consumers, respectively.
+const (
+	wsCloseGoingAway = 1001
+	wsClosePolicy    = 1008
+)
+
+// wsMaxControlPayload bounds inbound frame payloads. Clients only send
+// control frames and the occasional small text; anything larger is a
+// protocol violation.
+const wsMaxControlPayload = 4096
+
+// wsSubscriberBuffer is the per-connection event buffer; a consumer
+// that falls this many events behind is dropped.
+const wsSubscriberBuffer = 64
+
+// statusWebSocket upgrades the connection and streams StatusEvents to
+// the client as JSON text frames until the client disconnects, the
+// server drains, or the consumer falls behind. An optional ?project=
+// query parameter filters events to one project.
+func (s *Server) statusWebSocket(c *gin.Context) {
+	if s.statusBus == nil {
+		jsonError(c, http.StatusNotImplemented, CodeInternal, "status streaming is not configured", nil)
+		return
+	}
+	if !strings.EqualFold(c.GetHeader("Upgrade"), "websocket") ||
+		!headerContainsToken(c.GetHeader("Connection"), "upgrade") {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "websocket upgrade required", nil)
+		return
+	}
+	key := c.GetHeader("Sec-WebSocket-Key")
+	if key == "" {
+		jsonError(c, http.StatusBadRequest, CodeInvalidRequest, "missing Sec-WebSocket-Key", nil)
+		return
+	}
+	project := c.Query("project")
+
+	hijacker, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, "connection cannot be hijacked", nil)
+		return
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		jsonError(c, http.StatusInternalServerError, CodeInternal, err.Error(), nil)
+		return
+	}
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		_ = conn.Close()
+		return
+	}
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+		return
+	}
+	s.serveWebSocket(conn, rw.Reader, project)
+}
+
+// serveWebSocket runs one upgraded connection: a reader goroutine
+// handling client control frames, and the main loop pushing bus events.
+func (s *Server) serveWebSocket(conn net.Conn, reader *bufio.Reader, project string) {
+	defer func() { _ = conn.Close() }()
+	events, unsubscribe := s.statusBus.Subscribe(wsSubscriberBuffer)
+	defer unsubscribe()
+	s.trackConn(conn, true)
+	defer s.trackConn(conn, false)
+
+	// writeMu serializes event frames with pong replies.
+	var writeMu gosync.Mutex
+	write := func(opcode byte, payload []byte) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return writeWSFrame(conn, opcode, payload)
+	}
+
+	// The reader goroutine answers pings and signals client departure;
+	// its read on a closed connection errors out, so it never leaks.
+	clientGone := make(chan struct{})
+	go func() {
+		defer close(clientGone)
+		for {
+			opcode, payload, err := readWSFrame(reader)
+			if err != nil {
+				return
+			}
+			switch opcode {
+			case wsOpClose:
+				return
+			case wsOpPing:
+				if write(wsOpPong, payload) != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-clientGone:
+			return
+		case <-s.wsShutdown:
+			_ = write(wsOpClose, wsClosePayload(wsCloseGoingAway, "server shutting down"))
+			return
+		case event, ok := <-events:
+			if !ok {
+				// The bus dropped this subscriber for falling behind.
+				_ = write(wsOpClose, wsClosePayload(wsClosePolicy, "consumer too slow"))
+				return
+			}
+			if project != "" && event.Project != "" && event.Project != project {
+				continue
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if write(wsOpText, payload) != nil {
+				return
+			}
+		}
+	}
+}
+
+// trackConn registers or removes an active WebSocket connection so
+// Start can close them all during drain.
+func (s *Server) trackConn(conn net.Conn, active bool) {
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+	if active {
+		s.wsConns[conn] = struct{}{}
+	} else {
+		delete(s.wsConns, conn)
+	}
+}
+
+// closeWebSockets signals every connection to drain and closes any that
+// linger.
+func (s *Server) closeWebSockets() {
+	close(s.wsShutdown)
+	s.wsMu.Lock()
+	defer s.wsMu.Unlock()
+	for conn := range s.wsConns {
+		_ = conn.Close()
+		delete(s.wsConns, conn)
+	}
+}
+
+// headerContainsToken reports whether a comma-separated header value
+// contains the token, case-insensitively.
+func headerContainsToken(header, token string) bool {
+	for _, part := range strings.Split(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(part), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// wsClosePayload encodes a close frame body: a two-byte status code
+// followed by a UTF-8 reason.
+func wsClosePayload(code int, reason string) []byte {
+	payload := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], reason)
+	return payload
+}
+
+// writeWSFrame writes one unmasked server-to-client frame.
+func writeWSFrame(w io.Writer, opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n < 1<<16:
+		header = append(header, 126, byte(n>>8), byte(n))
+	default:
+		header = append(header, 127)
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(n))
+		header = append(header, ext[:]...)
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readWSFrame reads one client-to-server frame, unmasking its payload.
+// Fragmented messages are not supported; clients of this endpoint only
+// send control frames.
+func readWSFrame(r *bufio.Reader) (opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxControlPayload {
+		return 0, nil, fmt.Errorf("api: websocket frame of %d bytes exceeds limit", length)
+	}
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return opcode, payload, nil
+}
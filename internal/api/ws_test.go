@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// TestStatusWebSocketHandshake verifies the RFC 6455 upgrade: the
+// sample key from the RFC yields its documented accept value, and a
+// published bus event arrives as a text frame on the upgraded
+// connection.
+func TestStatusWebSocketHandshake(t *testing.T) {
+	bus := sync.NewStatusBus()
+	s := newTestServer(Options{StatusBus: bus})
+	ts := httptest.NewServer(s.router)
+	defer ts.Close()
+
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	request := "GET /api/v1/ws HTTP/1.1\r\n" +
+		"Host: " + ts.Listener.Addr().String() + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake answered %d, want %d", resp.StatusCode, http.StatusSwitchingProtocols)
+	}
+	// The accept value for the RFC 6455 sample key.
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Sec-WebSocket-Accept = %q, want the RFC sample value", got)
+	}
+
+	// The subscription is registered after the 101 is written, so keep
+	// publishing until a frame arrives.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		bus.Publish(sync.StatusEvent{Type: "project", Project: "PROJ", Phase: "Syncing"})
+		_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		opcode, payload, err := readWSFrame(reader)
+		if err == nil {
+			if opcode != wsOpText {
+				t.Fatalf("got opcode %#x, want text frame", opcode)
+			}
+			if !strings.Contains(string(payload), `"project":"PROJ"`) {
+				t.Fatalf("frame payload %q does not carry the published event", payload)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no event frame before deadline: %v", err)
+		}
+	}
+}
+
+// TestStatusWebSocketRequiresUpgrade verifies a plain GET without the
+// upgrade headers is rejected instead of hijacked.
+func TestStatusWebSocketRequiresUpgrade(t *testing.T) {
+	s := newTestServer(Options{StatusBus: sync.NewStatusBus()})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/ws", nil)
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("non-upgrade request got %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/hooks"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// buildHooks wires the post-sync hook runner from the resource spec,
+// resolving webhook URLs from their referenced secrets. Nil is returned
+// when no hooks are configured, which the engine treats as a no-op.
+func (r *JiraCDCReconciler) buildHooks(ctx context.Context, cdc *cdcv1.JiraCDC) (*hooks.Runner, error) {
+	if len(cdc.Spec.Hooks) == 0 {
+		return nil, nil
+	}
+	runner := &hooks.Runner{
+		Resource: cdc.Namespace + "/" + cdc.Name,
+		Require:  cdc.Spec.RequireHooks,
+	}
+	for i, hc := range cdc.Spec.Hooks {
+		var hook hooks.PostSyncHook
+		switch hc.Type {
+		case cdcv1.HookWebhook:
+			creds, err := r.readSecret(ctx, cdc.Namespace, hc.URLSecret)
+			if err != nil {
+				return nil, fmt.Errorf("hook %d: %w", i, err)
+			}
+			url := string(creds["url"])
+			if url == "" {
+				return nil, fmt.Errorf("hook %d: secret %q has no \"url\" key", i, hc.URLSecret)
+			}
+			hook = hooks.NewWebhook(url)
+		case cdcv1.HookCommand:
+			hook = hooks.NewCommand(hc.Command)
+		default:
+			return nil, fmt.Errorf("hook %d: unknown type %q", i, hc.Type)
+		}
+		if hc.Scope == cdcv1.HookScopeOperation {
+			runner.Operation = append(runner.Operation, hook)
+		} else {
+			runner.Issue = append(runner.Issue, hook)
+		}
+	}
+	return runner, nil
+}
+
+// reportHookFailures publishes a warning event for post-sync hooks that
+// failed during the last cycle. Hook failures never fail the sync
+// unless spec.requireHooks is set, in which case the error already
+// surfaced through the sync cycle itself.
+func (r *JiraCDCReconciler) reportHookFailures(cdc *cdcv1.JiraCDC, engine *sync.Engine) {
+	for _, failure := range engine.Hooks().Failures() {
+		r.Recorder.Eventf(cdc, corev1.EventTypeWarning, ReasonHookFailed,
+			"post-sync hook %s", failure)
+	}
+}
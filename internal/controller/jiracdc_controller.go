@@ -0,0 +1,853 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	gosync "sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/api"
+	"github.com/chambridge/atlassian-cdc-git/internal/audit"
+	"github.com/chambridge/atlassian-cdc-git/internal/cron"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+	"github.com/chambridge/atlassian-cdc-git/internal/notify"
+	"github.com/chambridge/atlassian-cdc-git/internal/storage"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+const (
+	defaultSyncInterval = 5 * time.Minute
+
+	// WorkDir is the base directory repository clones are placed under.
+	WorkDir = "/var/lib/jira-cdc/repos"
+
+	// ReasonDegraded is the event reason published when a component
+	// circuit breaker opens.
+	ReasonDegraded = "Degraded"
+
+	// ReasonConfigInvalid is the event reason published when a sync is
+	// aborted by a safety guard rather than an outright failure.
+	ReasonConfigInvalid = "ConfigInvalid"
+
+	// ReasonMirrorFailed is the event reason published when pushes to
+	// one or more mirror remotes fail while the primary push succeeds.
+	ReasonMirrorFailed = "MirrorPushFailed"
+
+	// ReasonDriftDetected is the event reason published when manual
+	// edits to managed issue files are found before a sync cycle.
+	ReasonDriftDetected = "DriftDetected"
+
+	// ReasonHookFailed is the event reason published when a post-sync
+	// processing hook fails while the sync itself succeeds.
+	ReasonHookFailed = "HookFailed"
+
+	// ReasonSecretUpdated is the event reason published when a watched
+	// credentials secret changes and clients are rebuilt from it.
+	ReasonSecretUpdated = "SecretUpdated"
+
+	// ReasonPaused and ReasonResumed are the event reasons published on
+	// pause/resume transitions.
+	ReasonPaused  = "Paused"
+	ReasonResumed = "Resumed"
+
+	// degradedRequeueFactor widens the requeue interval while a
+	// component breaker is open, to avoid hammering a failing backend.
+	degradedRequeueFactor = 4
+
+	// failureBackoffThreshold is how many consecutive failed sync cycles
+	// are retried at the normal interval before the backoff starts
+	// widening.
+	failureBackoffThreshold = 2
+
+	// maxFailureBackoff caps the widening retry backoff so a recovered
+	// backend is picked up within the hour.
+	maxFailureBackoff = time.Hour
+)
+
+// JiraCDCReconciler reconciles a JiraCDC object.
+type JiraCDCReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Breakers holds the shared circuit breakers for JIRA and git
+	// operations across all reconciles.
+	Breakers *cdcerrors.CircuitBreakerManager
+	// DeadLetters tracks issues that permanently fail to sync. Shared
+	// with the API server so failures can be inspected and retried.
+	DeadLetters *sync.DeadLetterStore
+	// Health receives connectivity probes for the JIRA instance and git
+	// remote of reconciled resources.
+	Health *api.HealthChecker
+	// Bus, when set, receives project phase changes for the real-time
+	// status stream.
+	Bus *sync.StatusBus
+	// Engines publishes the engine for each project so webhook and
+	// admin triggered operations can reuse it.
+	Engines *sync.EngineRegistry
+	// Audit records configuration changes and credential refreshes.
+	// Nil disables auditing.
+	Audit *audit.Logger
+	// Elected is closed when this replica wins leader election. In HA
+	// deployments the workqueue only runs on the leader anyway; this is
+	// a defense-in-depth guard so a replica that somehow reconciles
+	// without the lease never schedules sync cycles. Nil means
+	// leadership is not contested (single replica or election disabled).
+	Elected <-chan struct{}
+
+	// secretVersions tracks the last seen resourceVersion per credential
+	// secret so refreshes can be audited without logging every read.
+	secretVersions gosync.Map
+}
+
+// +kubebuilder:rbac:groups=cdc.atlassian.io,resources=jiracdcs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cdc.atlassian.io,resources=jiracdcs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile runs one sync cycle for the JiraCDC resource and requeues
+// after the configured interval.
+func (r *JiraCDCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var cdc cdcv1.JiraCDC
+	if err := r.Get(ctx, req.NamespacedName, &cdc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Only the leader schedules sync cycles; standby replicas keep
+	// serving webhooks and API reads but leave the resource untouched
+	// until they are promoted. Manual triggers via the API are
+	// unaffected — they run through the task manager, not this path.
+	if !r.leading() {
+		logger.V(1).Info("not the leader, skipping scheduled sync")
+		return ctrl.Result{RequeueAfter: defaultSyncInterval}, nil
+	}
+
+	// A durable pause: skip sync cycles entirely but keep reconciling
+	// the resource so status stays truthful. An in-flight cycle from a
+	// previous reconcile is never cancelled by pausing.
+	if cdc.Spec.Sync.Paused {
+		if cdc.Status.Phase != cdcv1.PhasePaused {
+			r.Recorder.Event(&cdc, corev1.EventTypeNormal, ReasonPaused, "sync paused via spec")
+		}
+		r.setPhase(&cdc, cdcv1.PhasePaused, "sync paused via spec.sync.paused")
+		cdc.Status.NextScheduledSync = nil
+		if err := r.updateStatus(ctx, &cdc); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if cdc.Status.Phase == cdcv1.PhasePaused {
+		r.Recorder.Event(&cdc, corev1.EventTypeNormal, ReasonResumed, "sync resumed")
+	}
+
+	interval := defaultSyncInterval
+	if cdc.Spec.Sync.Interval != "" {
+		if d, err := time.ParseDuration(cdc.Spec.Sync.Interval); err == nil {
+			interval = d
+		}
+	}
+	// A cron schedule takes precedence over the polling interval.
+	var schedule *cron.Schedule
+	if cdc.Spec.Sync.Schedule != "" {
+		parsed, err := cron.Parse(cdc.Spec.Sync.Schedule)
+		if err != nil {
+			return r.failSync(ctx, &cdc, fmt.Errorf("parse sync schedule: %w", err))
+		}
+		schedule = parsed
+	}
+
+	// A generation the controller has not acted on yet means the spec
+	// was changed; leave an audit trail before applying it.
+	if cdc.Status.ObservedGeneration != 0 && cdc.Generation != cdc.Status.ObservedGeneration {
+		r.Audit.Record(audit.Entry{
+			Action:   audit.ActionConfigChange,
+			Actor:    "kubernetes-api",
+			Resource: cdc.Namespace + "/" + cdc.Name,
+			Detail:   fmt.Sprintf("generation %d supersedes %d", cdc.Generation, cdc.Status.ObservedGeneration),
+		})
+	}
+
+	engine, err := r.buildEngine(ctx, &cdc)
+	if err != nil {
+		return r.failSync(ctx, &cdc, fmt.Errorf("configure sync: %w", err))
+	}
+	metrics.SetWatchedSecrets(cdc.Namespace+"/"+cdc.Name, countSecrets(referencedSecrets(&cdc)))
+
+	bootstrap := cdc.Status.LastSyncTime == nil
+	if bootstrap {
+		r.setPhase(&cdc, cdcv1.PhaseBootstrapping, "initial bootstrap in progress")
+	} else {
+		r.setPhase(&cdc, cdcv1.PhaseSyncing, "sync cycle in progress")
+	}
+	if err := r.updateStatus(ctx, &cdc); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = engine.Bootstrap(ctx)
+	cdc.Status.DiscoveredIssues = engine.DiscoveredIssues()
+	if err != nil {
+		if errors.Is(err, sync.ErrPartialSync) {
+			// Some projects synced and were pushed; report degraded
+			// rather than failed so consumers keep fresh data.
+			logger.Error(err, "sync cycle partially failed")
+			r.Recorder.Event(&cdc, corev1.EventTypeWarning, ReasonDegraded, err.Error())
+			r.updateComponentHealth(&cdc)
+			r.updateProjectStatus(&cdc, engine)
+			r.advanceLastSyncTime(&cdc, engine, true)
+			cdc.Status.SyncedIssues = engine.Progress().Snapshot().Completed
+			cdc.Status.ConsecutiveFailures = 0
+			cdc.Status.ObservedGeneration = cdc.Generation
+			r.setPhase(&cdc, cdcv1.PhaseSyncing, err.Error())
+			requeue := r.nextRequeue(&cdc, schedule, interval)
+			if uerr := r.updateStatus(ctx, &cdc); uerr != nil {
+				return ctrl.Result{}, uerr
+			}
+			r.notifyOutcome(&cdc, notify.StatusCompleted, err.Error(), cdc.Status.SyncedIssues)
+			return ctrl.Result{RequeueAfter: requeue}, nil
+		}
+		logger.Error(err, "sync cycle failed")
+		if errors.Is(err, sync.ErrTooManyDeletions) || errors.Is(err, sync.ErrTooManyIssues) {
+			r.Recorder.Event(&cdc, corev1.EventTypeWarning, ReasonConfigInvalid, err.Error())
+		}
+		r.updateComponentHealth(&cdc)
+		if degraded := r.degradedComponents(&cdc); len(degraded) > 0 {
+			r.Recorder.Eventf(&cdc, corev1.EventTypeWarning, ReasonDegraded,
+				"components degraded: %v", degraded)
+			interval *= degradedRequeueFactor
+		}
+		// Persistent failures widen the retry spacing exponentially so a
+		// broken instance stops hammering JIRA every interval. Manual
+		// triggers through the API bypass this entirely — they run on the
+		// task manager, not this requeue.
+		cdc.Status.ConsecutiveFailures++
+		if backoff := failureBackoff(interval, cdc.Status.ConsecutiveFailures); backoff > interval {
+			r.Recorder.Eventf(&cdc, corev1.EventTypeWarning, ReasonDegraded,
+				"%d consecutive sync failures, backing off to %s between attempts",
+				cdc.Status.ConsecutiveFailures, backoff)
+			interval = backoff
+		}
+		requeue := r.nextRequeue(&cdc, schedule, interval)
+		if _, uerr := r.failSync(ctx, &cdc, err); uerr != nil {
+			return ctrl.Result{}, fmt.Errorf("sync failed (%w), status update failed: %v", err, uerr)
+		}
+		r.notifyOutcome(&cdc, notify.StatusFailed, err.Error(), 0)
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+
+	r.reportMirrorFailures(&cdc, engine)
+	r.reportHookFailures(&cdc, engine)
+	r.reportDrift(&cdc, engine)
+	r.updateComponentHealth(&cdc)
+	r.updateProjectStatus(&cdc, engine)
+	r.advanceLastSyncTime(&cdc, engine, false)
+	progress := engine.Progress().Snapshot()
+	cdc.Status.SyncedIssues = progress.Completed
+	cdc.Status.ConsecutiveFailures = 0
+	cdc.Status.ObservedGeneration = cdc.Generation
+	r.setPhase(&cdc, cdcv1.PhaseSyncing, "last sync cycle succeeded")
+	requeue := r.nextRequeue(&cdc, schedule, interval)
+	if err := r.updateStatus(ctx, &cdc); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.notifyOutcome(&cdc, notify.StatusCompleted, "", cdc.Status.SyncedIssues)
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// advanceLastSyncTime moves the recorded sync time forward after a
+// cycle. Incremental sync anchors it to the highest updated timestamp
+// the engine saw, so the next window starts on JIRA's clock and drift
+// against the operator's clock cannot open a gap; a cycle that saw no
+// issues keeps the previous watermark, which already covers the
+// repository. Partial failures keep it too, so a failed project's
+// missed updates stay inside the next window. Full (non-incremental)
+// cycles record wall-clock time as before.
+func (r *JiraCDCReconciler) advanceLastSyncTime(cdc *cdcv1.JiraCDC, engine *sync.Engine, partial bool) {
+	if cdc.Spec.Sync.Incremental {
+		if partial {
+			return
+		}
+		if wm := engine.Watermark(); !wm.IsZero() {
+			t := metav1.NewTime(wm)
+			cdc.Status.LastSyncTime = &t
+			return
+		}
+		if cdc.Status.LastSyncTime != nil {
+			return
+		}
+	}
+	now := metav1.Now()
+	cdc.Status.LastSyncTime = &now
+}
+
+// updateProjectStatus rebuilds the per-project status map from the
+// engine's last cycle. Rebuilding rather than merging prunes projects
+// removed from the spec; a cycle that produced no per-project results
+// (e.g. it failed before any scan ran) keeps the previous map.
+func (r *JiraCDCReconciler) updateProjectStatus(cdc *cdcv1.JiraCDC, engine *sync.Engine) {
+	results := engine.ProjectResults()
+	if len(results) == 0 {
+		return
+	}
+	status := make(map[string]cdcv1.ProjectSyncStatus, len(results))
+	degraded := 0
+	for project, res := range results {
+		t := metav1.NewTime(res.SyncedAt)
+		ps := cdcv1.ProjectSyncStatus{
+			LastSyncTime: &t,
+			SyncedIssues: res.SyncedIssues,
+			Health:       cdcv1.ComponentHealthy,
+		}
+		if res.Err != nil {
+			degraded++
+			ps.Health = cdcv1.ComponentDegraded
+			ps.Message = res.Err.Error()
+		}
+		status[project] = ps
+	}
+	cdc.Status.ProjectStatus = status
+	cdc.Status.DegradedProjects = degraded
+}
+
+// leading reports whether this replica currently holds the leader
+// lease. A nil Elected channel means leadership is not contested.
+func (r *JiraCDCReconciler) leading() bool {
+	if r.Elected == nil {
+		return true
+	}
+	select {
+	case <-r.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// reportDrift publishes a warning event naming the managed files the
+// pre-sync check found manually edited, so operators learn about drift
+// without scraping the API.
+func (r *JiraCDCReconciler) reportDrift(cdc *cdcv1.JiraCDC, engine *sync.Engine) {
+	drifts := engine.Drifts()
+	if len(drifts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		keys = append(keys, d.Key)
+	}
+	r.Recorder.Eventf(cdc, corev1.EventTypeWarning, ReasonDriftDetected,
+		"manual edits detected in %d managed files: %s", len(drifts), strings.Join(keys, ", "))
+}
+
+// reportMirrorFailures publishes a warning event for mirror remotes
+// that rejected the last push. Mirror outages never fail the sync
+// unless spec.gitRepository.requireMirrors is set, in which case the
+// error already surfaced through the sync cycle itself.
+func (r *JiraCDCReconciler) reportMirrorFailures(cdc *cdcv1.JiraCDC, engine *sync.Engine) {
+	failures := engine.Git().MirrorFailures()
+	if len(failures) == 0 {
+		return
+	}
+	for url, err := range failures {
+		r.Recorder.Eventf(cdc, corev1.EventTypeWarning, ReasonMirrorFailed,
+			"mirror %s: %v", url, err)
+	}
+}
+
+// nextRequeue returns how long to wait before the next sync cycle and
+// records the next scheduled run in status. A cron schedule wins over
+// the fixed interval.
+func (r *JiraCDCReconciler) nextRequeue(cdc *cdcv1.JiraCDC, schedule *cron.Schedule, interval time.Duration) time.Duration {
+	if schedule != nil {
+		if next := schedule.Next(time.Now()); !next.IsZero() {
+			t := metav1.NewTime(next)
+			cdc.Status.NextScheduledSync = &t
+			return time.Until(next)
+		}
+	}
+	cdc.Status.NextScheduledSync = nil
+	return interval
+}
+
+// failureBackoff widens the retry interval after repeated sync-cycle
+// failures: the normal interval up to failureBackoffThreshold
+// consecutive failures, then doubling per failure, capped at
+// maxFailureBackoff. This is independent of the per-call RetryManager,
+// which handles transient errors inside a single cycle.
+func failureBackoff(interval time.Duration, failures int) time.Duration {
+	backoff := interval
+	for i := failureBackoffThreshold; i < failures && backoff < maxFailureBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxFailureBackoff {
+		backoff = maxFailureBackoff
+	}
+	return backoff
+}
+
+// updateComponentHealth mirrors the circuit breaker states into the
+// resource's component status.
+func (r *JiraCDCReconciler) updateComponentHealth(cdc *cdcv1.JiraCDC) {
+	if r.Breakers == nil {
+		return
+	}
+	health := func(state cdcerrors.BreakerState) string {
+		if state == cdcerrors.BreakerClosed {
+			return cdcv1.ComponentHealthy
+		}
+		return cdcv1.ComponentDegraded
+	}
+	states := r.Breakers.States()
+	cdc.Status.Components.JiraConnection = health(states[cdcerrors.ComponentJira])
+	cdc.Status.Components.GitRepository = health(states[cdcerrors.ComponentGit])
+}
+
+// degradedComponents lists component names currently marked degraded.
+func (r *JiraCDCReconciler) degradedComponents(cdc *cdcv1.JiraCDC) []string {
+	var degraded []string
+	if cdc.Status.Components.JiraConnection == cdcv1.ComponentDegraded {
+		degraded = append(degraded, cdcerrors.ComponentJira)
+	}
+	if cdc.Status.Components.GitRepository == cdcv1.ComponentDegraded {
+		degraded = append(degraded, cdcerrors.ComponentGit)
+	}
+	return degraded
+}
+
+func (r *JiraCDCReconciler) setPhase(cdc *cdcv1.JiraCDC, phase cdcv1.SyncPhase, message string) {
+	cdc.Status.Phase = phase
+	cdc.Status.Message = message
+	if r.Bus != nil {
+		for _, project := range cdc.Spec.Jira.Projects() {
+			r.Bus.Publish(sync.StatusEvent{
+				Type:    "project",
+				Project: project,
+				Phase:   string(phase),
+				Message: message,
+			})
+		}
+	}
+}
+
+// updateStatus writes the resource's status through the status
+// subresource, retrying on optimistic-concurrency conflicts. Rapid spec
+// toggles (e.g. pause/resume through the API) bump the resource version
+// between the reconciler's read and its status write; each retry
+// re-fetches the latest object and reapplies the computed status, so
+// spec changes are never overwritten and a cycle never fails with
+// "object has been modified".
+func (r *JiraCDCReconciler) updateStatus(ctx context.Context, cdc *cdcv1.JiraCDC) error {
+	status := cdc.Status
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest cdcv1.JiraCDC
+		if err := r.Get(ctx, client.ObjectKeyFromObject(cdc), &latest); err != nil {
+			return err
+		}
+		latest.Status = status
+		if err := r.Status().Update(ctx, &latest); err != nil {
+			return err
+		}
+		*cdc = latest
+		return nil
+	})
+}
+
+func (r *JiraCDCReconciler) failSync(ctx context.Context, cdc *cdcv1.JiraCDC, err error) (ctrl.Result, error) {
+	r.setPhase(cdc, cdcv1.PhaseFailed, err.Error())
+	if uerr := r.updateStatus(ctx, cdc); uerr != nil {
+		return ctrl.Result{}, uerr
+	}
+	return ctrl.Result{}, err
+}
+
+// buildEngine wires a sync engine from the resource spec and its
+// referenced credential secrets.
+func (r *JiraCDCReconciler) buildEngine(ctx context.Context, cdc *cdcv1.JiraCDC) (*sync.Engine, error) {
+	jiraCreds, err := r.readSecret(ctx, cdc.Namespace, cdc.Spec.Jira.CredentialsSecret)
+	if err != nil {
+		return nil, err
+	}
+	gitCreds, err := r.readSecret(ctx, cdc.Namespace, cdc.Spec.GitRepository.CredentialsSecret)
+	if err != nil {
+		return nil, err
+	}
+	// Check the secrets' shape before building clients: a malformed
+	// rotation fails this reconcile while the previously registered
+	// engine keeps serving with its still-working credentials.
+	if err := validateCredentialKeys(cdc, jiraCreds, gitCreds); err != nil {
+		return nil, err
+	}
+
+	jiraCA, err := r.caBundle(ctx, cdc.Namespace, cdc.Spec.Jira.TLS)
+	if err != nil {
+		return nil, err
+	}
+	gitCA, err := r.caBundle(ctx, cdc.Namespace, cdc.Spec.GitRepository.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	jiraClient, err := jira.NewClient(jira.Config{
+		BaseURL:    cdc.Spec.Jira.BaseURL,
+		AuthMethod: cdc.Spec.Jira.AuthMethod,
+		APIVersion: cdc.Spec.Jira.APIVersion,
+		Email:      string(jiraCreds["email"]),
+		APIToken:   string(jiraCreds["apiToken"]),
+		Token:      string(jiraCreds["token"]),
+		TLS: jira.TLSConfig{
+			CABundle:           jiraCA,
+			InsecureSkipVerify: cdc.Spec.Jira.TLS.InsecureSkipVerify,
+		},
+		Proxy: jira.ProxyConfig(cdc.Spec.Jira.Proxy),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projects := cdc.Spec.Jira.Projects()
+
+	// With several projects in one repository — or a board or saved
+	// filter, which can span projects — namespace files per project
+	// unless the layout already does so.
+	fileLayout := cdc.Spec.GitRepository.FileLayout
+	if len(projects) > 1 || cdc.Spec.Jira.BoardID != 0 || cdc.Spec.Jira.FilterID != 0 {
+		if fileLayout == "" {
+			fileLayout = git.DefaultFileLayout
+		}
+		if !strings.Contains(fileLayout, "{project}") {
+			fileLayout = "{project}/" + fileLayout
+		}
+	}
+
+	mirrorCreds, err := r.mirrorCredentials(ctx, cdc)
+	if err != nil {
+		return nil, err
+	}
+	var redactionRules []git.RedactionRule
+	for _, rule := range cdc.Spec.GitRepository.RedactionRules {
+		redactionRules = append(redactionRules, git.RedactionRule(rule))
+	}
+	gitManager, err := git.NewManager(git.Config{
+		URL:                        cdc.Spec.GitRepository.URL,
+		Branch:                     cdc.Spec.GitRepository.Branch,
+		FileLayout:                 fileLayout,
+		LocalPath:                  filepath.Join(WorkDir, cdc.Namespace, cdc.Name),
+		Username:                   string(gitCreds["username"]),
+		Token:                      string(gitCreds["token"]),
+		ConflictResolutionStrategy: cdc.Spec.GitRepository.ConflictResolutionStrategy,
+		AuthorStrategy:             cdc.Spec.GitRepository.AuthorStrategy,
+		IssueTemplate:              cdc.Spec.GitRepository.IssueTemplate,
+		CommitMessageTemplate:      cdc.Spec.GitRepository.CommitMessageTemplate,
+		CommitMessagePattern:       cdc.Spec.GitRepository.CommitMessagePattern,
+		AddCommitTrailers:          cdc.Spec.GitRepository.AddCommitTrailers,
+		JiraBaseURL:                cdc.Spec.Jira.BaseURL,
+		TypeTemplates:              cdc.Spec.GitRepository.TypeTemplates,
+		TypeLayouts:                cdc.Spec.GitRepository.TypeLayouts,
+		EpicChildIndex:             cdc.Spec.GitRepository.EpicChildIndex,
+		MirrorRemotes:              cdc.Spec.GitRepository.MirrorRemotes,
+		MirrorCredentials:          mirrorCreds,
+		RequireMirrors:             cdc.Spec.GitRepository.RequireMirrors,
+		UseLFS:                     cdc.Spec.GitRepository.UseLFS,
+		TLS: git.TLSConfig{
+			CABundle:           gitCA,
+			InsecureSkipVerify: cdc.Spec.GitRepository.TLS.InsecureSkipVerify,
+		},
+		Proxy:          git.ProxyConfig(cdc.Spec.GitRepository.Proxy),
+		RedactSecrets:  cdc.Spec.GitRepository.RedactSecrets,
+		RedactionRules: redactionRules,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Health != nil {
+		r.Health.RegisterProbe(cdcerrors.ComponentJira, func(ctx context.Context) error {
+			_, err := jiraClient.Myself(ctx)
+			return err
+		})
+		r.Health.RegisterProbe(cdcerrors.ComponentGit, gitManager.CheckRemote)
+	}
+
+	var fieldMapping []sync.FieldMapping
+	for _, fm := range cdc.Spec.Sync.FieldMapping {
+		fieldMapping = append(fieldMapping, sync.FieldMapping(fm))
+	}
+
+	target, err := r.storageTarget(ctx, cdc)
+	if err != nil {
+		return nil, err
+	}
+
+	hookRunner, err := r.buildHooks(ctx, cdc)
+	if err != nil {
+		return nil, err
+	}
+
+	// A malformed timeout is rejected by the admission webhook; fall
+	// back to the engine default rather than failing the reconcile.
+	var opTimeout time.Duration
+	if cdc.Spec.Sync.OperationTimeout != "" {
+		if d, err := time.ParseDuration(cdc.Spec.Sync.OperationTimeout); err == nil {
+			opTimeout = d
+		}
+	}
+	var incrOverlap time.Duration
+	if cdc.Spec.Sync.IncrementalOverlap != "" {
+		if d, err := time.ParseDuration(cdc.Spec.Sync.IncrementalOverlap); err == nil {
+			incrOverlap = d
+		}
+	}
+	// Incremental cycles window from the recorded watermark; a nil
+	// LastSyncTime leaves the seed zero, forcing a full scan.
+	var incrSince time.Time
+	if cdc.Status.LastSyncTime != nil {
+		incrSince = cdc.Status.LastSyncTime.Time
+	}
+
+	engine, err := sync.NewEngine(jiraClient, gitManager, sync.Options{
+		ProjectKeys:              projects,
+		BoardID:                  cdc.Spec.Jira.BoardID,
+		FilterID:                 cdc.Spec.Jira.FilterID,
+		BatchSize:                cdc.Spec.Sync.BatchSize,
+		MaxProjectConcurrency:    cdc.Spec.Sync.MaxProjectConcurrency,
+		FieldMapping:             fieldMapping,
+		SyncChangelog:            cdc.Spec.Sync.SyncChangelog,
+		SyncWorklogs:             cdc.Spec.Sync.SyncWorklogs,
+		SyncRemoteLinks:          cdc.Spec.Sync.SyncRemoteLinks,
+		SyncAttachments:          cdc.Spec.Sync.SyncAttachments,
+		AttachmentConcurrency:    cdc.Spec.Sync.AttachmentConcurrency,
+		RemovedProjectPolicy:     cdc.Spec.Sync.RemovedProjectPolicy,
+		LabelFilter:              cdc.Spec.Sync.LabelFilter,
+		ComponentFilter:          cdc.Spec.Sync.ComponentFilter,
+		IgnoreFields:             cdc.Spec.Sync.IgnoreFields,
+		IgnoreStatuses:           cdc.Spec.Sync.IgnoreStatuses,
+		MaxIssuesPerOperation:    cdc.Spec.Sync.MaxIssuesPerOperation,
+		AllowLargeSync:           cdc.Spec.Sync.AllowLargeSync,
+		OperationTimeout:         opTimeout,
+		CheckpointPath:           filepath.Join(WorkDir, cdc.Namespace, cdc.Name+".checkpoint.json"),
+		ResumeFromCheckpoint:     cdc.Spec.Sync.ResumeFromCheckpoint,
+		Incremental:              cdc.Spec.Sync.Incremental,
+		IncrementalSince:         incrSince,
+		IncrementalOverlap:       incrOverlap,
+		PrioritizeByJiraPriority: cdc.Spec.Sync.PrioritizeByJiraPriority,
+		Breakers:                 r.Breakers,
+		DeadLetters:              r.DeadLetters,
+		InstanceName:             cdc.Name,
+		CommitBatchSize:          cdc.Spec.GitRepository.CommitBatchSize,
+		SquashOnReconcile:        cdc.Spec.GitRepository.SquashOnReconcile,
+		DriftPolicy:              cdc.Spec.GitRepository.DriftPolicy,
+		Target:                   target,
+		Hooks:                    hookRunner,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.Engines != nil {
+		for _, project := range projects {
+			r.Engines.Register(project, engine)
+		}
+	}
+	return engine, nil
+}
+
+// storageTarget builds the storage backend selected in spec.storage,
+// or nil for the default git backend so the engine wires its own git
+// target.
+func (r *JiraCDCReconciler) storageTarget(ctx context.Context, cdc *cdcv1.JiraCDC) (storage.SyncTarget, error) {
+	if cdc.Spec.Storage.Backend != cdcv1.StorageBackendS3 {
+		return nil, nil
+	}
+	creds, err := r.readSecret(ctx, cdc.Namespace, cdc.Spec.Storage.S3.CredentialsSecret)
+	if err != nil {
+		return nil, err
+	}
+	return storage.NewS3Target(storage.S3Config{
+		Bucket:          cdc.Spec.Storage.S3.Bucket,
+		Prefix:          cdc.Spec.Storage.S3.Prefix,
+		Region:          cdc.Spec.Storage.S3.Region,
+		Endpoint:        cdc.Spec.Storage.S3.Endpoint,
+		AccessKeyID:     string(creds["accessKeyId"]),
+		SecretAccessKey: string(creds["secretAccessKey"]),
+	})
+}
+
+// mirrorCredentials resolves per-mirror push credentials from the
+// secrets named in spec.gitRepository.mirrorCredentialsSecrets. Mirrors
+// without an entry fall back to the primary credentials inside the git
+// manager.
+func (r *JiraCDCReconciler) mirrorCredentials(ctx context.Context, cdc *cdcv1.JiraCDC) (map[string]git.Credential, error) {
+	refs := cdc.Spec.GitRepository.MirrorCredentialsSecrets
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	creds := make(map[string]git.Credential, len(refs))
+	for url, secretName := range refs {
+		data, err := r.readSecret(ctx, cdc.Namespace, secretName)
+		if err != nil {
+			return nil, fmt.Errorf("mirror %s: %w", url, err)
+		}
+		creds[url] = git.Credential{
+			Username: string(data["username"]),
+			Token:    string(data["token"]),
+		}
+	}
+	return creds, nil
+}
+
+// caBundle resolves the CA bundle for a TLS config from its referenced
+// secret. CA secrets are part of referencedSecrets, so rotating one
+// triggers a reconcile that rebuilds the clients with the new bundle.
+func (r *JiraCDCReconciler) caBundle(ctx context.Context, namespace string, tls cdcv1.TLSConfig) ([]byte, error) {
+	if tls.CASecret == "" {
+		return nil, nil
+	}
+	data, err := r.readSecret(ctx, namespace, tls.CASecret)
+	if err != nil {
+		return nil, err
+	}
+	bundle := data["ca.crt"]
+	if len(bundle) == 0 {
+		return nil, fmt.Errorf("CA secret %q is missing the ca.crt key", tls.CASecret)
+	}
+	return bundle, nil
+}
+
+func (r *JiraCDCReconciler) readSecret(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := r.Get(ctx, key, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("credentials secret %q not found", name)
+		}
+		return nil, err
+	}
+	// Audit credential rotations by resourceVersion change; the entry
+	// names the secret but never its contents.
+	if prev, seen := r.secretVersions.Swap(key.String(), secret.ResourceVersion); seen && prev != secret.ResourceVersion {
+		r.Audit.Record(audit.Entry{
+			Action:   audit.ActionCredentialRefresh,
+			Actor:    "kubernetes-api",
+			Resource: key.String(),
+		})
+	}
+	return secret.Data, nil
+}
+
+// validateCredentialKeys checks that the credential secrets carry the
+// keys the configured auth methods require, so a bad rotation is caught
+// before any client is swapped.
+func validateCredentialKeys(cdc *cdcv1.JiraCDC, jiraCreds, gitCreds map[string][]byte) error {
+	if cdc.Spec.Jira.AuthMethod == jira.AuthMethodPAT {
+		if len(jiraCreds["token"]) == 0 {
+			return fmt.Errorf("jira credentials secret %q is missing the token key required for pat auth",
+				cdc.Spec.Jira.CredentialsSecret)
+		}
+	} else if len(jiraCreds["email"]) == 0 || len(jiraCreds["apiToken"]) == 0 {
+		return fmt.Errorf("jira credentials secret %q must contain email and apiToken keys",
+			cdc.Spec.Jira.CredentialsSecret)
+	}
+	if len(gitCreds["token"]) == 0 {
+		return fmt.Errorf("git credentials secret %q is missing the token key",
+			cdc.Spec.GitRepository.CredentialsSecret)
+	}
+	return nil
+}
+
+// referencedSecrets lists the secret names a resource reads credentials
+// from: JIRA, git, storage, CA bundles, mirror remotes and notification
+// URLs.
+func referencedSecrets(cdc *cdcv1.JiraCDC) []string {
+	names := []string{
+		cdc.Spec.Jira.CredentialsSecret,
+		cdc.Spec.GitRepository.CredentialsSecret,
+		cdc.Spec.Storage.S3.CredentialsSecret,
+		cdc.Spec.Jira.TLS.CASecret,
+		cdc.Spec.GitRepository.TLS.CASecret,
+	}
+	for _, secretName := range cdc.Spec.GitRepository.MirrorCredentialsSecrets {
+		names = append(names, secretName)
+	}
+	for _, nc := range cdc.Spec.Notifications {
+		names = append(names, nc.URLSecret)
+	}
+	for _, hc := range cdc.Spec.Hooks {
+		names = append(names, hc.URLSecret)
+	}
+	return names
+}
+
+// countSecrets counts distinct non-empty secret names.
+func countSecrets(names []string) int {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if name != "" {
+			seen[name] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// secretToRequests maps a changed Secret onto the JiraCDC resources
+// that reference it, so credential rotations take effect on the next
+// reconcile (which rebuilds the JIRA and git clients from the new data)
+// instead of waiting for a pod restart. Each affected resource gets a
+// SecretUpdated event.
+func (r *JiraCDCReconciler) secretToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list cdcv1.JiraCDCList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		cdc := &list.Items[i]
+		referenced := false
+		for _, name := range referencedSecrets(cdc) {
+			if name == obj.GetName() {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			continue
+		}
+		r.Recorder.Eventf(cdc, corev1.EventTypeNormal, ReasonSecretUpdated,
+			"credentials secret %s changed; refreshing clients", obj.GetName())
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cdc.Namespace, Name: cdc.Name},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager, watching
+// referenced credential secrets alongside the JiraCDC resources.
+func (r *JiraCDCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cdcv1.JiraCDC{}).
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.secretToRequests),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+)
+
+// TestUpdateStatusRetriesOnConflict reproduces the "object has been
+// modified" race: a spec toggle between the reconciler's read and its
+// status write rejects the first update attempts with a conflict, and
+// the wrapper must re-fetch and retry until the status lands.
+func TestUpdateStatusRetriesOnConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := cdcv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cdc := &cdcv1.JiraCDC{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+	}
+
+	conflicts := 2
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cdc).
+		WithStatusSubresource(&cdcv1.JiraCDC{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if conflicts > 0 {
+					conflicts--
+					return apierrors.NewConflict(
+						schema.GroupResource{Group: cdcv1.GroupVersion.Group, Resource: "jiracdcs"},
+						obj.GetName(), fmt.Errorf("the object has been modified"))
+				}
+				return c.Status().Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &JiraCDCReconciler{Client: cl}
+	target := cdc.DeepCopy()
+	target.Status.Phase = cdcv1.PhaseSyncing
+	target.Status.Message = "after retry"
+	if err := r.updateStatus(context.Background(), target); err != nil {
+		t.Fatalf("updateStatus: %v", err)
+	}
+	if conflicts != 0 {
+		t.Fatalf("expected both injected conflicts to be consumed, %d left", conflicts)
+	}
+
+	var got cdcv1.JiraCDC
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(cdc), &got); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Status.Phase != cdcv1.PhaseSyncing || got.Status.Message != "after retry" {
+		t.Fatalf("status not persisted after retries: %+v", got.Status)
+	}
+}
+
+// TestUpdateStatusSurfacesOtherErrors ensures non-conflict failures are
+// not retried into oblivion but returned to the caller.
+func TestUpdateStatusSurfacesOtherErrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := cdcv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	r := &JiraCDCReconciler{Client: cl}
+	missing := &cdcv1.JiraCDC{
+		ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "default"},
+	}
+	if err := r.updateStatus(context.Background(), missing); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+}
+
+// TestFailureBackoff checks the widening retry curve: normal interval up
+// to the threshold, doubling past it, capped at maxFailureBackoff.
+func TestFailureBackoff(t *testing.T) {
+	interval := 5 * time.Minute
+	cases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: 5 * time.Minute},
+		{failures: failureBackoffThreshold, want: 5 * time.Minute},
+		{failures: failureBackoffThreshold + 1, want: 10 * time.Minute},
+		{failures: failureBackoffThreshold + 3, want: 40 * time.Minute},
+		{failures: 100, want: maxFailureBackoff},
+	}
+	for _, tc := range cases {
+		if got := failureBackoff(interval, tc.failures); got != tc.want {
+			t.Errorf("failureBackoff(%v, %d) = %v, want %v", interval, tc.failures, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/notify"
+)
+
+// notificationTimeout bounds delivery of one sync outcome, including
+// retries.
+const notificationTimeout = 60 * time.Second
+
+// notifyOutcome posts the outcome of a sync cycle to the configured
+// notification targets. Delivery happens in the background with retries
+// and never blocks or fails the reconcile.
+func (r *JiraCDCReconciler) notifyOutcome(cdc *cdcv1.JiraCDC, status, message string, syncedIssues int) {
+	if len(cdc.Spec.Notifications) == 0 {
+		return
+	}
+	event := notify.Event{
+		Resource:     cdc.Namespace + "/" + cdc.Name,
+		Projects:     cdc.Spec.Jira.Projects(),
+		Status:       status,
+		Message:      message,
+		SyncedIssues: syncedIssues,
+		Time:         time.Now(),
+	}
+	configs := make([]cdcv1.NotificationConfig, len(cdc.Spec.Notifications))
+	copy(configs, cdc.Spec.Notifications)
+	namespace := cdc.Namespace
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		logger := log.Log.WithName("notify").WithValues("resource", event.Resource)
+		for _, cfg := range configs {
+			if !notificationWanted(cfg.Events, status) {
+				continue
+			}
+			notifier, err := r.buildNotifier(ctx, namespace, cfg)
+			if err != nil {
+				logger.Error(err, "configure notifier", "type", cfg.Type)
+				continue
+			}
+			retries := cdcerrors.NewRetryManager(cdcerrors.RetryConfig{})
+			err = retries.Do(ctx, func(ctx context.Context) error {
+				return notifier.Notify(ctx, event)
+			})
+			if err != nil {
+				logger.Error(err, "deliver notification", "type", cfg.Type)
+			}
+		}
+	}()
+}
+
+// notificationWanted reports whether the config subscribes to status.
+// An empty event list subscribes to everything.
+func notificationWanted(events []string, status string) bool {
+	if len(events) == 0 {
+		return true
+	}
+	for _, event := range events {
+		if event == status {
+			return true
+		}
+	}
+	return false
+}
+
+// buildNotifier resolves the target URL from the referenced secret and
+// constructs the configured notifier.
+func (r *JiraCDCReconciler) buildNotifier(ctx context.Context, namespace string, cfg cdcv1.NotificationConfig) (notify.Notifier, error) {
+	creds, err := r.readSecret(ctx, namespace, cfg.URLSecret)
+	if err != nil {
+		return nil, err
+	}
+	url := string(creds["url"])
+	if url == "" {
+		return nil, fmt.Errorf("secret %q has no \"url\" key", cfg.URLSecret)
+	}
+	switch cfg.Type {
+	case cdcv1.NotifierSlack:
+		return notify.NewSlack(url, cfg.MessageTemplate)
+	case cdcv1.NotifierWebhook:
+		return notify.NewWebhook(url), nil
+	}
+	return nil, fmt.Errorf("unknown notifier type %q", cfg.Type)
+}
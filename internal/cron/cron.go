@@ -0,0 +1,144 @@
+// Package cron implements the subset of five-field cron expressions the
+// operator needs for scheduled reconciles: lists, ranges and steps.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron expression
+// (minute hour day-of-month month day-of-week).
+type Schedule struct {
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool
+	month  [13]bool
+	dow    [7]bool
+
+	// domRestricted/dowRestricted track whether the field was "*".
+	// Per cron convention, when both are restricted a time matches if
+	// either field matches.
+	domRestricted bool
+	dowRestricted bool
+}
+
+// nextHorizon bounds the search in Next; an expression that cannot fire
+// within this window (e.g. Feb 30) yields the zero time.
+const nextHorizon = 2 * 366 * 24 * time.Hour
+
+// Parse parses a five-field cron expression.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron: expected 5 fields, got %d in %q", len(fields), expr)
+	}
+	s := &Schedule{}
+	specs := []struct {
+		field      string
+		min, max   int
+		set        []bool
+		restricted *bool
+	}{
+		{fields[0], 0, 59, s.minute[:], nil},
+		{fields[1], 0, 23, s.hour[:], nil},
+		{fields[2], 1, 31, s.dom[:], &s.domRestricted},
+		{fields[3], 1, 12, s.month[:], nil},
+		{fields[4], 0, 6, s.dow[:], &s.dowRestricted},
+	}
+	for _, spec := range specs {
+		restricted, err := parseField(spec.field, spec.min, spec.max, spec.set)
+		if err != nil {
+			return nil, err
+		}
+		if spec.restricted != nil {
+			*spec.restricted = restricted
+		}
+	}
+	return s, nil
+}
+
+// parseField fills set from a single cron field and reports whether the
+// field restricts values (i.e. is not a bare "*").
+func parseField(field string, min, max int, set []bool) (bool, error) {
+	restricted := false
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return false, fmt.Errorf("cron: invalid step in %q", part)
+			}
+			step = n
+			part = part[:idx]
+		}
+		lo, hi := min, max
+		switch {
+		case part == "*":
+			if step > 1 {
+				restricted = true
+			}
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return false, fmt.Errorf("cron: invalid range %q", part)
+			}
+			restricted = true
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return false, fmt.Errorf("cron: invalid value %q", part)
+			}
+			lo, hi = n, n
+			restricted = true
+		}
+		// Day-of-week 7 is an alias for Sunday.
+		if max == 6 && hi == 7 {
+			hi = 6
+			set[0] = true
+			if lo == 7 {
+				lo, hi = 0, 0
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return false, fmt.Errorf("cron: value out of range in %q (allowed %d-%d)", field, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return restricted, nil
+}
+
+// Next returns the first time strictly after t that matches the
+// schedule, or the zero time if none exists within the search horizon.
+func (s *Schedule) Next(t time.Time) time.Time {
+	candidate := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(nextHorizon)
+	for !candidate.After(limit) {
+		if s.matches(candidate) {
+			return candidate
+		}
+		candidate = candidate.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matches(t time.Time) bool {
+	if !s.minute[t.Minute()] || !s.hour[t.Hour()] || !s.month[int(t.Month())] {
+		return false
+	}
+	domOK := s.dom[t.Day()]
+	dowOK := s.dow[int(t.Weekday())]
+	// Standard cron semantics: if both day fields are restricted, either
+	// matching is sufficient.
+	if s.domRestricted && s.dowRestricted {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
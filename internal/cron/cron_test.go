@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRejectsInvalid verifies malformed expressions fail parsing
+// instead of producing a schedule that silently never fires.
+func TestParseRejectsInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"0 0 * *",
+		"0 0 * * * *",
+		"60 0 * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"x 0 * * *",
+		"0 0 * * 1-x",
+		"*/0 * * * *",
+		"5-2 * * * *",
+	}
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+// TestParseSundayAlias verifies day-of-week 7 works everywhere Sunday
+// can appear: bare, with a step, and as a range bound.
+func TestParseSundayAlias(t *testing.T) {
+	sunday := time.Date(2026, 9, 6, 0, 0, 0, 0, time.UTC)
+	if sunday.Weekday() != time.Sunday {
+		t.Fatal("fixture date is not a Sunday")
+	}
+	for _, expr := range []string{"0 0 * * 7", "0 0 * * 7/1", "0 0 * * 0"} {
+		s, err := Parse(expr)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", expr, err)
+			continue
+		}
+		if !s.matches(sunday) {
+			t.Errorf("%q does not match a Sunday midnight", expr)
+		}
+		if monday := sunday.AddDate(0, 0, 1); s.matches(monday) {
+			t.Errorf("%q matches a Monday", expr)
+		}
+	}
+
+	s, err := Parse("0 0 * * 5-7")
+	if err != nil {
+		t.Fatalf("Parse(5-7): %v", err)
+	}
+	for days, want := range map[int]bool{-2: true, -1: true, 0: true, 1: false} {
+		at := sunday.AddDate(0, 0, days)
+		if s.matches(at) != want {
+			t.Errorf("5-7 match on %s = %v, want %v", at.Weekday(), s.matches(at), want)
+		}
+	}
+}
+
+// TestNext verifies steps, ranges, and lists advance to the expected
+// next firing time.
+func TestNext(t *testing.T) {
+	base := time.Date(2026, 9, 1, 10, 7, 30, 0, time.UTC) // a Tuesday
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 9, 1, 10, 15, 0, 0, time.UTC)},
+		{"0 0 * * *", time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)},
+		{"30 4 1 * *", time.Date(2026, 10, 1, 4, 30, 0, 0, time.UTC)},
+		{"0 12 * * 1,5", time.Date(2026, 9, 4, 12, 0, 0, 0, time.UTC)},
+		{"5 10 * * *", time.Date(2026, 9, 2, 10, 5, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.expr, err)
+			continue
+		}
+		if got := s.Next(base); !got.Equal(tc.want) {
+			t.Errorf("Next(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+// TestNextNeverFires verifies an expression with no matching time in
+// the horizon yields the zero time rather than looping forever.
+func TestNextNeverFires(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := s.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Feb 30 schedule fired at %v, want the zero time", next)
+	}
+}
+
+// TestDayFieldsEitherMatch verifies standard cron semantics: when both
+// day-of-month and day-of-week are restricted, either matching fires.
+func TestDayFieldsEitherMatch(t *testing.T) {
+	s, err := Parse("0 0 15 * 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC)
+	fifteenth := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC) // a Tuesday
+	other := time.Date(2026, 9, 16, 0, 0, 0, 0, time.UTC)
+	if !s.matches(monday) {
+		t.Error("restricted dow did not match a Monday")
+	}
+	if !s.matches(fifteenth) {
+		t.Error("restricted dom did not match the 15th")
+	}
+	if s.matches(other) {
+		t.Error("matched a day satisfying neither field")
+	}
+}
@@ -0,0 +1,200 @@
+// Package errors holds shared failure-handling primitives: circuit
+// breaking and retry/backoff policies used around JIRA and git
+// operations.
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a call is rejected because the
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// CircuitBreaker protects a downstream component from repeated calls
+// while it is failing. After FailureThreshold consecutive failures the
+// breaker opens; once OpenTimeout elapses a single half-open probe is
+// allowed through to test recovery.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	onStateChange    func(name string, state BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a closed breaker for the named component.
+func NewCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 5
+	}
+	if openTimeout <= 0 {
+		openTimeout = 30 * time.Second
+	}
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		state:            BreakerClosed,
+	}
+}
+
+// OnStateChange registers a callback invoked on every state transition.
+func (b *CircuitBreaker) OnStateChange(fn func(name string, state BreakerState)) {
+	b.mu.Lock()
+	b.onStateChange = fn
+	b.mu.Unlock()
+}
+
+// State returns the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentStateLocked()
+}
+
+func (b *CircuitBreaker) currentStateLocked() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.openTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Execute runs fn through the breaker. While open it fails fast with
+// ErrCircuitOpen. In the half-open state exactly one probe call is
+// allowed in flight; concurrent callers fail fast until it settles.
+func (b *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	b.mu.Lock()
+	switch b.currentStateLocked() {
+	case BreakerOpen:
+		b.mu.Unlock()
+		return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+	case BreakerHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.probing = true
+		b.setStateLocked(BreakerHalfOpen)
+	}
+	b.mu.Unlock()
+
+	err := fn(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if err != nil {
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+			b.openedAt = time.Now()
+			b.setStateLocked(BreakerOpen)
+		}
+		return err
+	}
+	b.failures = 0
+	b.setStateLocked(BreakerClosed)
+	return nil
+}
+
+func (b *CircuitBreaker) setStateLocked(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	if b.onStateChange != nil {
+		// Run callbacks without the lock so they may query the breaker.
+		fn, name := b.onStateChange, b.name
+		go fn(name, state)
+	}
+}
+
+// Component names used for the manager's well-known breakers.
+const (
+	ComponentJira = "jira"
+	ComponentGit  = "git"
+)
+
+// CircuitBreakerManager holds named breakers for downstream components.
+type CircuitBreakerManager struct {
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+
+	failureThreshold int
+	openTimeout      time.Duration
+	onStateChange    func(name string, state BreakerState)
+}
+
+// NewCircuitBreakerManager returns a manager that creates breakers on
+// demand with the given policy. The jira and git breakers are created
+// eagerly.
+func NewCircuitBreakerManager(failureThreshold int, openTimeout time.Duration) *CircuitBreakerManager {
+	m := &CircuitBreakerManager{
+		breakers:         map[string]*CircuitBreaker{},
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+	m.Get(ComponentJira)
+	m.Get(ComponentGit)
+	return m
+}
+
+// OnStateChange registers a callback applied to all current and future
+// breakers.
+func (m *CircuitBreakerManager) OnStateChange(fn func(name string, state BreakerState)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onStateChange = fn
+	for _, b := range m.breakers {
+		b.OnStateChange(fn)
+	}
+}
+
+// Get returns the breaker for a component, creating it if necessary.
+func (m *CircuitBreakerManager) Get(name string) *CircuitBreaker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.breakers[name]
+	if !ok {
+		b = NewCircuitBreaker(name, m.failureThreshold, m.openTimeout)
+		if m.onStateChange != nil {
+			b.OnStateChange(m.onStateChange)
+		}
+		m.breakers[name] = b
+	}
+	return b
+}
+
+// Execute runs fn through the named breaker.
+func (m *CircuitBreakerManager) Execute(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	return m.Get(name).Execute(ctx, fn)
+}
+
+// States returns the current state of every breaker.
+func (m *CircuitBreakerManager) States() map[string]BreakerState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	states := make(map[string]BreakerState, len(m.breakers))
+	for name, b := range m.breakers {
+		states[name] = b.State()
+	}
+	return states
+}
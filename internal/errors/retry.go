@@ -0,0 +1,235 @@
+package errors
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrorClass buckets failures by how they should be handled.
+type ErrorClass string
+
+const (
+	// ClassTransient errors are expected to succeed on retry (network
+	// blips, 5xx, rate limits).
+	ClassTransient ErrorClass = "transient"
+	// ClassAuth errors indicate bad or expired credentials.
+	ClassAuth ErrorClass = "auth"
+	// ClassValidation errors indicate the request itself is invalid.
+	ClassValidation ErrorClass = "validation"
+	// ClassPermanent errors will not succeed however often retried.
+	ClassPermanent ErrorClass = "permanent"
+)
+
+// IsRetryable reports whether errors of this class should be retried.
+func (c ErrorClass) IsRetryable() bool {
+	return c == ClassTransient
+}
+
+// statusCoder is implemented by errors carrying an HTTP status code,
+// such as jira.APIError.
+type statusCoder interface {
+	HTTPStatusCode() int
+}
+
+// permanentError marks a wrapped error as not worth retrying regardless
+// of its underlying type.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// NonRetryable wraps err so Classify buckets it as permanent, for
+// failures the caller knows will not resolve on retry.
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Classify buckets an error for retry and dead-letter decisions.
+func Classify(err error) ErrorClass {
+	if err == nil {
+		return ClassTransient
+	}
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return ClassPermanent
+	}
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		switch code := sc.HTTPStatusCode(); {
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			return ClassAuth
+		case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+			return ClassValidation
+		case code == http.StatusNotFound || code == http.StatusGone:
+			return ClassPermanent
+		case code == http.StatusTooManyRequests || code >= 500:
+			return ClassTransient
+		default:
+			return ClassPermanent
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ClassTransient
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTransient
+	}
+	return ClassTransient
+}
+
+// RetryConfig tunes exponential backoff retries.
+type RetryConfig struct {
+	// MaxRetries is how many times an operation is retried after the
+	// first attempt.
+	MaxRetries int
+	// InitialDelay is the backoff before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the backoff between attempts.
+	MaxDelay time.Duration
+	// Multiplier grows the delay between attempts.
+	Multiplier float64
+	// Jitter selects how the backoff delay is randomized: JitterNone,
+	// JitterEqual (default) or JitterFull, following the AWS backoff
+	// recommendations.
+	Jitter string
+}
+
+// Jitter strategies for backoff delays.
+const (
+	// JitterNone uses the exponential delay as-is.
+	JitterNone = "none"
+	// JitterEqual sleeps between half the delay and the full delay,
+	// bounding the minimum wait while spreading retries.
+	JitterEqual = "equal"
+	// JitterFull sleeps anywhere between zero and the full delay,
+	// maximizing spread under heavy contention.
+	JitterFull = "full"
+)
+
+// DefaultRetryConfig is the policy used for JIRA and git operations.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:   3,
+		InitialDelay: time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+	}
+}
+
+// RetryManager retries operations with exponential backoff, giving up
+// immediately on errors classified as non-retryable.
+type RetryManager struct {
+	cfg RetryConfig
+
+	// rngMu guards rng; managers are shared across sync workers and
+	// math/rand sources are not safe for concurrent use.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+// NewRetryManager returns a RetryManager for cfg, applying defaults for
+// zero values.
+func NewRetryManager(cfg RetryConfig) *RetryManager {
+	def := DefaultRetryConfig()
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = def.MaxRetries
+	}
+	if cfg.InitialDelay <= 0 {
+		cfg.InitialDelay = def.InitialDelay
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = def.MaxDelay
+	}
+	if cfg.Multiplier <= 1 {
+		cfg.Multiplier = def.Multiplier
+	}
+	switch cfg.Jitter {
+	case JitterNone, JitterEqual, JitterFull:
+	default:
+		cfg.Jitter = JitterEqual
+	}
+	return &RetryManager{cfg: cfg, rng: rand.New(rand.NewSource(randomSeed()))}
+}
+
+// randomSeed seeds the manager's jitter source once, from crypto/rand
+// with a timestamp fallback, so concurrently created managers do not
+// share a jitter sequence.
+func randomSeed() int64 {
+	var buf [8]byte
+	if _, err := cryptorand.Read(buf[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(buf[:]))
+}
+
+// Do runs op, retrying transient failures with exponential backoff and
+// jitter. It returns the last error once retries are exhausted, or the
+// first non-retryable error immediately.
+func (r *RetryManager) Do(ctx context.Context, op func(ctx context.Context) error) error {
+	delay := r.cfg.InitialDelay
+	var lastErr error
+	for attempt := 0; attempt <= r.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(r.retryDelay(delay))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+			delay = time.Duration(float64(delay) * r.cfg.Multiplier)
+			if delay > r.cfg.MaxDelay {
+				delay = r.cfg.MaxDelay
+			}
+		}
+
+		lastErr = op(ctx)
+		if lastErr == nil {
+			return nil
+		}
+		if !Classify(lastErr).IsRetryable() {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("retries exhausted after %d attempts: %w", r.cfg.MaxRetries+1, lastErr)
+}
+
+// retryDelay applies the configured jitter strategy to the base
+// exponential delay. The result is never negative and never exceeds
+// MaxDelay.
+func (r *RetryManager) retryDelay(base time.Duration) time.Duration {
+	if base > r.cfg.MaxDelay {
+		base = r.cfg.MaxDelay
+	}
+	if base <= 0 {
+		return 0
+	}
+	if r.cfg.Jitter == JitterNone {
+		return base
+	}
+	r.rngMu.Lock()
+	defer r.rngMu.Unlock()
+	if r.cfg.Jitter == JitterFull {
+		return time.Duration(r.rng.Int63n(int64(base) + 1))
+	}
+	return base/2 + time.Duration(r.rng.Int63n(int64(base)/2+1))
+}
+
+// Attempts returns the total attempt budget of the manager.
+func (r *RetryManager) Attempts() int {
+	return r.cfg.MaxRetries + 1
+}
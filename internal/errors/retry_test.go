@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRetryDelayBounds verifies each jitter strategy keeps delays
+// within its expected range and never past MaxDelay or below zero,
+// across many iterations.
+func TestRetryDelayBounds(t *testing.T) {
+	const iterations = 10000
+	base := 8 * time.Second
+	maxDelay := 10 * time.Second
+
+	cases := []struct {
+		jitter string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{JitterNone, base, base},
+		{JitterEqual, base / 2, base},
+		{JitterFull, 0, base},
+	}
+	for _, tc := range cases {
+		r := NewRetryManager(RetryConfig{InitialDelay: base, MaxDelay: maxDelay, Jitter: tc.jitter})
+		for i := 0; i < iterations; i++ {
+			got := r.retryDelay(base)
+			if got < tc.min || got > tc.max {
+				t.Fatalf("jitter %q produced delay %v, want within [%v, %v]", tc.jitter, got, tc.min, tc.max)
+			}
+		}
+	}
+}
+
+// TestRetryDelayCapsAtMaxDelay verifies a base delay past the cap is
+// clamped before jitter is applied.
+func TestRetryDelayCapsAtMaxDelay(t *testing.T) {
+	maxDelay := 5 * time.Second
+	r := NewRetryManager(RetryConfig{InitialDelay: time.Second, MaxDelay: maxDelay, Jitter: JitterFull})
+	for i := 0; i < 10000; i++ {
+		if got := r.retryDelay(time.Hour); got < 0 || got > maxDelay {
+			t.Fatalf("delay %v outside [0, %v]", got, maxDelay)
+		}
+	}
+}
+
+// TestRetryDelayNeverNegative verifies degenerate base delays resolve
+// to a zero wait instead of a negative timer.
+func TestRetryDelayNeverNegative(t *testing.T) {
+	r := NewRetryManager(RetryConfig{Jitter: JitterEqual})
+	for _, base := range []time.Duration{0, -time.Second} {
+		if got := r.retryDelay(base); got != 0 {
+			t.Fatalf("retryDelay(%v) = %v, want 0", base, got)
+		}
+	}
+}
+
+// TestRetryDelayDefaultsToEqualJitter verifies an unknown strategy
+// falls back to equal jitter rather than disabling jitter.
+func TestRetryDelayDefaultsToEqualJitter(t *testing.T) {
+	r := NewRetryManager(RetryConfig{Jitter: "bogus"})
+	if r.cfg.Jitter != JitterEqual {
+		t.Fatalf("jitter defaulted to %q, want %q", r.cfg.Jitter, JitterEqual)
+	}
+}
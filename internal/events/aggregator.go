@@ -0,0 +1,270 @@
+// Package events wraps a Kubernetes event recorder with aggregation:
+// identical events repeated within a window are suppressed after a
+// threshold and summarized once, so a flapping dependency cannot flood
+// the resource's event stream.
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Config tunes event aggregation. The zero value uses the defaults.
+type Config struct {
+	// Window is how long an event key is tracked; occurrences further
+	// apart than this start a fresh count. Defaults to 5 minutes.
+	Window time.Duration
+	// Threshold is how many identical events pass through per window
+	// before further occurrences are suppressed. Defaults to 3.
+	Threshold int
+}
+
+const (
+	defaultWindow    = 5 * time.Minute
+	defaultThreshold = 3
+)
+
+// EventStats is a point-in-time snapshot of aggregator activity.
+type EventStats struct {
+	// Emitted counts events forwarded to the underlying recorder,
+	// including suppression summaries.
+	Emitted int64 `json:"emitted"`
+	// Suppressed counts occurrences swallowed by aggregation.
+	Suppressed int64 `json:"suppressed"`
+	// Summaries counts the "suppressed N further occurrences" events
+	// emitted when a window with suppressed occurrences expired.
+	Summaries int64 `json:"summaries"`
+	// TrackedKeys is the number of distinct event keys currently inside
+	// their window.
+	TrackedKeys int `json:"trackedKeys"`
+}
+
+// EventMetrics accumulates aggregation counters. It is safe for
+// concurrent use.
+type EventMetrics struct {
+	mu         sync.Mutex
+	emitted    int64
+	suppressed int64
+	summaries  int64
+	tracked    func() int
+}
+
+// GetStats returns a snapshot of the counters.
+func (m *EventMetrics) GetStats() EventStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := EventStats{
+		Emitted:    m.emitted,
+		Suppressed: m.suppressed,
+		Summaries:  m.summaries,
+	}
+	if m.tracked != nil {
+		stats.TrackedKeys = m.tracked()
+	}
+	return stats
+}
+
+// eventEntry tracks one distinct event key inside its window.
+type eventEntry struct {
+	object     runtime.Object
+	eventtype  string
+	reason     string
+	message    string
+	firstSeen  time.Time
+	count      int
+	suppressed int
+}
+
+// EventAggregator is a record.EventRecorder that forwards events to an
+// underlying recorder, suppressing repeats of the same event beyond the
+// configured threshold per window. Expired windows with suppressed
+// occurrences emit a single summary event. Call Stop when done; it
+// stops the cleanup goroutine and flushes pending summaries.
+type EventAggregator struct {
+	recorder  record.EventRecorder
+	window    time.Duration
+	threshold int
+	metrics   *EventMetrics
+
+	mu      sync.Mutex
+	entries map[string]*eventEntry
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	done     sync.WaitGroup
+}
+
+// NewEventAggregator wraps recorder with aggregation per cfg and starts
+// the window cleanup goroutine.
+func NewEventAggregator(recorder record.EventRecorder, cfg Config) *EventAggregator {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultWindow
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultThreshold
+	}
+	a := &EventAggregator{
+		recorder:  recorder,
+		window:    cfg.Window,
+		threshold: cfg.Threshold,
+		metrics:   &EventMetrics{},
+		entries:   map[string]*eventEntry{},
+		stopCh:    make(chan struct{}),
+	}
+	a.metrics.tracked = a.trackedKeys
+	a.done.Add(1)
+	go a.cleanupLoop()
+	return a
+}
+
+// Metrics returns the aggregator's counters for stats reporting.
+func (a *EventAggregator) Metrics() *EventMetrics {
+	return a.metrics
+}
+
+// Event records an event, subject to suppression.
+func (a *EventAggregator) Event(object runtime.Object, eventtype, reason, message string) {
+	a.record(object, eventtype, reason, message)
+}
+
+// Eventf records a formatted event, subject to suppression.
+func (a *EventAggregator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	a.record(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf records a formatted event, subject to suppression.
+// Annotations are dropped from suppressed occurrences but kept on the
+// ones that pass through.
+func (a *EventAggregator) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if a.admit(object, eventtype, reason, message) {
+		a.recorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// record forwards the event unless the window's threshold is exhausted.
+func (a *EventAggregator) record(object runtime.Object, eventtype, reason, message string) {
+	if a.admit(object, eventtype, reason, message) {
+		a.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// admit counts an occurrence against its window, reporting whether it
+// should be forwarded.
+func (a *EventAggregator) admit(object runtime.Object, eventtype, reason, message string) bool {
+	key := eventKey(object, eventtype, reason, message)
+	now := time.Now()
+
+	a.mu.Lock()
+	entry, ok := a.entries[key]
+	if !ok || now.Sub(entry.firstSeen) >= a.window {
+		// A stale entry rolls into a fresh window; any suppressed
+		// occurrences it accumulated are summarized by the cleanup
+		// loop's next pass, not silently dropped.
+		if ok && entry.suppressed > 0 {
+			a.summarizeLocked(entry)
+		}
+		entry = &eventEntry{object: object, eventtype: eventtype, reason: reason, message: message, firstSeen: now}
+		a.entries[key] = entry
+	}
+	entry.count++
+	admitted := entry.count <= a.threshold
+	if !admitted {
+		entry.suppressed++
+	}
+	a.mu.Unlock()
+
+	a.metrics.mu.Lock()
+	if admitted {
+		a.metrics.emitted++
+	} else {
+		a.metrics.suppressed++
+	}
+	a.metrics.mu.Unlock()
+	return admitted
+}
+
+// cleanupLoop expires entries whose window has passed, emitting a
+// summary for any that suppressed occurrences, until Stop is called.
+func (a *EventAggregator) cleanupLoop() {
+	defer a.done.Done()
+	ticker := time.NewTicker(a.window / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.expire(time.Now())
+		case <-a.stopCh:
+			return
+		}
+	}
+}
+
+// expire removes entries outside their window, summarizing suppressed
+// occurrences.
+func (a *EventAggregator) expire(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, entry := range a.entries {
+		if now.Sub(entry.firstSeen) < a.window {
+			continue
+		}
+		if entry.suppressed > 0 {
+			a.summarizeLocked(entry)
+		}
+		delete(a.entries, key)
+	}
+}
+
+// summarizeLocked emits the suppression summary for an entry and zeroes
+// its suppressed count. Callers hold a.mu.
+func (a *EventAggregator) summarizeLocked(entry *eventEntry) {
+	a.recorder.Eventf(entry.object, entry.eventtype, entry.reason,
+		"suppressed %d further occurrences of: %s", entry.suppressed, entry.message)
+	a.metrics.mu.Lock()
+	a.metrics.emitted++
+	a.metrics.summaries++
+	a.metrics.mu.Unlock()
+	entry.suppressed = 0
+}
+
+// Stop halts the cleanup goroutine and flushes a summary for every
+// tracked entry that suppressed occurrences. Safe to call more than
+// once.
+func (a *EventAggregator) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopCh)
+		a.done.Wait()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for key, entry := range a.entries {
+			if entry.suppressed > 0 {
+				a.summarizeLocked(entry)
+			}
+			delete(a.entries, key)
+		}
+	})
+}
+
+// trackedKeys reports how many distinct event keys are live.
+func (a *EventAggregator) trackedKeys() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.entries)
+}
+
+// eventKey identifies one distinct event: the object, event type,
+// reason and message together. Objects without accessible metadata fall
+// back to their type.
+func eventKey(object runtime.Object, eventtype, reason, message string) string {
+	name := fmt.Sprintf("%T", object)
+	if accessor, err := meta.Accessor(object); err == nil {
+		name = accessor.GetNamespace() + "/" + accessor.GetName()
+	}
+	return name + "|" + eventtype + "|" + reason + "|" + message
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEventStormSuppressed(t *testing.T) {
+	fake := record.NewFakeRecorder(256)
+	agg := NewEventAggregator(fake, Config{Threshold: 3})
+	obj := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cdc"}}
+
+	for i := 0; i < 100; i++ {
+		agg.Event(obj, corev1.EventTypeWarning, "SyncFailed", "jira unreachable")
+	}
+	agg.Stop()
+	close(fake.Events)
+
+	var forwarded []string
+	for event := range fake.Events {
+		forwarded = append(forwarded, event)
+	}
+	// Threshold passes plus the flush summary.
+	if len(forwarded) != 4 {
+		t.Fatalf("forwarded %d events, want 4: %v", len(forwarded), forwarded)
+	}
+	summary := forwarded[len(forwarded)-1]
+	if !strings.Contains(summary, "suppressed 97 further occurrences") {
+		t.Errorf("summary event = %q, want suppressed occurrence count", summary)
+	}
+
+	stats := agg.Metrics().GetStats()
+	if stats.Emitted != 4 || stats.Suppressed != 97 || stats.Summaries != 1 {
+		t.Errorf("stats = %+v, want 4 emitted, 97 suppressed, 1 summary", stats)
+	}
+	if stats.TrackedKeys != 0 {
+		t.Errorf("tracked keys after Stop = %d, want 0", stats.TrackedKeys)
+	}
+}
+
+func TestDistinctEventsPassThrough(t *testing.T) {
+	fake := record.NewFakeRecorder(16)
+	agg := NewEventAggregator(fake, Config{Threshold: 1})
+	defer agg.Stop()
+	obj := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cdc"}}
+
+	agg.Event(obj, corev1.EventTypeNormal, "Synced", "mirrored 10 issues")
+	agg.Event(obj, corev1.EventTypeNormal, "Synced", "mirrored 12 issues")
+	agg.Event(obj, corev1.EventTypeWarning, "SyncFailed", "jira unreachable")
+
+	if got := len(fake.Events); got != 3 {
+		t.Fatalf("forwarded %d events, want 3", got)
+	}
+	if stats := agg.Metrics().GetStats(); stats.Suppressed != 0 {
+		t.Errorf("suppressed = %d, want 0", stats.Suppressed)
+	}
+}
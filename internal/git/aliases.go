@@ -0,0 +1,100 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// aliasFile is the repository-relative path of the key-alias map, which
+// records old issue keys left behind by project moves so links to them
+// still resolve. It lives in the mirrored repository itself, so agents
+// consuming the repo as a submodule can resolve stale keys too.
+const aliasFile = ".jiracdc/aliases.json"
+
+// Aliases returns the key-alias map, old key to current key. A missing
+// alias file yields an empty map.
+func (m *Manager) Aliases() (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, aliasFile))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git: read %s: %w", aliasFile, err)
+	}
+	aliases := map[string]string{}
+	if err := json.Unmarshal(content, &aliases); err != nil {
+		return nil, fmt.Errorf("git: parse %s: %w", aliasFile, err)
+	}
+	return aliases, nil
+}
+
+// ResolveKey returns the current key for an issue key, following the
+// alias map. Keys that were never renamed resolve to themselves, as
+// does any key when the alias map cannot be read.
+func (m *Manager) ResolveKey(key string) string {
+	aliases, err := m.Aliases()
+	if err != nil {
+		return key
+	}
+	if current, ok := aliases[key]; ok {
+		return current
+	}
+	return key
+}
+
+// recordAlias maps an old issue key to its new key. Earlier aliases
+// already pointing at the old key are repointed at the new one, so
+// chained renames always resolve in a single lookup.
+func (m *Manager) recordAlias(oldKey, newKey string) error {
+	aliases, err := m.Aliases()
+	if err != nil {
+		return err
+	}
+	for key, current := range aliases {
+		if current == oldKey {
+			aliases[key] = newKey
+		}
+	}
+	aliases[oldKey] = newKey
+
+	content, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return fmt.Errorf("git: encode %s: %w", aliasFile, err)
+	}
+	absPath := filepath.Join(m.cfg.LocalPath, aliasFile)
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(absPath, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("git: write %s: %w", aliasFile, err)
+	}
+	return nil
+}
+
+// RenameIssue moves an old key's file to the renamed issue's path, so
+// git records a rename and the file's history follows the issue across
+// a project move, and adds the old key to the alias map. It reports
+// whether a file was moved; an old key whose file was never synced is
+// a no-op.
+func (m *Manager) RenameIssue(oldKey string, data *IssueData) (bool, error) {
+	oldRel, ok := m.locateIssueFile(oldKey)
+	if !ok {
+		return false, nil
+	}
+	newRel := m.IssueFilePath(data)
+	newAbs := filepath.Join(m.cfg.LocalPath, newRel)
+	if err := os.MkdirAll(filepath.Dir(newAbs), 0o755); err != nil {
+		return false, err
+	}
+	oldAbs := filepath.Join(m.cfg.LocalPath, oldRel)
+	if err := os.Rename(oldAbs, newAbs); err != nil {
+		return false, fmt.Errorf("git: move %s to %s: %w", oldRel, newRel, err)
+	}
+	m.pruneEmptyDirs(filepath.Dir(oldAbs))
+	if err := m.recordAlias(oldKey, data.Key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,34 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteAttachment stores one downloaded attachment next to its issue
+// file, under an attachments/ directory so the managed .gitattributes
+// LFS rule applies. The file is named "<key>-<filename>" to keep
+// attachments from different issues apart in a shared directory, and
+// unchanged content is not rewritten so repeat syncs stay commit-free.
+// It returns the repository-relative path written.
+func (m *Manager) WriteAttachment(issueKey, filename string, content []byte) (string, error) {
+	issueRel, ok := m.findIssueFile(issueKey)
+	if !ok {
+		return "", fmt.Errorf("git: no issue file for %s to attach %s to", issueKey, filename)
+	}
+	relPath := filepath.Join(filepath.Dir(issueRel), "attachments", issueKey+"-"+filepath.Base(filename))
+	absPath := filepath.Join(m.cfg.LocalPath, relPath)
+
+	if existing, err := os.ReadFile(absPath); err == nil && bytes.Equal(existing, content) {
+		return relPath, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(absPath, content, 0o644); err != nil {
+		return "", fmt.Errorf("git: write attachment %s: %w", relPath, err)
+	}
+	return relPath, nil
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// CommitContext is the data a commit message template renders with:
+// the issue being committed and a summary of what changed.
+type CommitContext struct {
+	// Key is the issue key, e.g. "PROJ-123".
+	Key string
+	// Project is the issue's project key.
+	Project string
+	// Type is the JIRA issue type name, lower-cased for use as a
+	// conventional-commit type, e.g. "bug".
+	Type string
+	// Summary is the issue's summary field.
+	Summary string
+	// Operation is what the commit does: "create", "update" or
+	// "force-resync".
+	Operation string
+	// Changes lists the field changes this commit records, empty for
+	// creates and forced resyncs.
+	Changes []FieldChange
+	// ChangeSummary is the changes pre-formatted as a one-line human
+	// summary, e.g. "status: Open -> Done".
+	ChangeSummary string
+}
+
+// ParseCommitMessageTemplate compiles a custom commit message template.
+// The template executes with a CommitContext as its context and shares
+// the issue template helpers (join, upper, lower, formatDate).
+func ParseCommitMessageTemplate(text string) (*template.Template, error) {
+	return template.New("commit").Funcs(templateFuncs).Parse(text)
+}
+
+// RenderCommitMessage renders the configured commit message template
+// for one issue commit, enforcing the configured message pattern.
+// Callers must check HasCommitTemplate first.
+func (m *Manager) RenderCommitMessage(ctx CommitContext) (string, error) {
+	var buf strings.Builder
+	if err := m.commitTemplate.Execute(&buf, ctx); err != nil {
+		return "", fmt.Errorf("git: render commit message for %s: %w", ctx.Key, err)
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", fmt.Errorf("git: commit message template rendered empty for %s", ctx.Key)
+	}
+	if m.commitPattern != nil && !m.commitPattern.MatchString(message) {
+		return "", fmt.Errorf("git: commit message %q for %s does not match commitMessagePattern %s",
+			message, ctx.Key, m.commitPattern)
+	}
+	return message, nil
+}
+
+// HasCommitTemplate reports whether a custom commit message template is
+// configured.
+func (m *Manager) HasCommitTemplate() bool {
+	return m.commitTemplate != nil
+}
+
+// compileCommitMessagePattern compiles the configured message pattern,
+// nil when none is set.
+func compileCommitMessagePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("git: compile commit message pattern: %w", err)
+	}
+	return compiled, nil
+}
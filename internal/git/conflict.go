@@ -0,0 +1,200 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v5"
+)
+
+// Conflict resolution strategies for issue files that diverged between
+// the local mirror and the remote repository.
+const (
+	// StrategyPreferJira rewrites conflicted files from the freshly
+	// rendered JIRA state. This is the default: JIRA is the source of
+	// truth.
+	StrategyPreferJira = "prefer-jira"
+	// StrategyPreferGit keeps the committed repository version,
+	// discarding the local rendering.
+	StrategyPreferGit = "prefer-git"
+	// StrategyMerge merges front-matter field by field, taking the
+	// rendered value where one is set and keeping the repository value
+	// otherwise.
+	StrategyMerge = "merge"
+)
+
+// SetConflictResolutionStrategy overrides the strategy applied to
+// diverged issue files. Unknown values fall back to prefer-jira.
+func (m *Manager) SetConflictResolutionStrategy(strategy string) {
+	m.strategy = strategy
+}
+
+// ConflictResolutionStrategy returns the effective strategy.
+func (m *Manager) ConflictResolutionStrategy() string {
+	switch m.strategy {
+	case StrategyPreferGit, StrategyMerge:
+		return m.strategy
+	default:
+		return StrategyPreferJira
+	}
+}
+
+// DetectConflicts returns the repository-relative issue file paths whose
+// working tree content differs from HEAD, i.e. files where both the
+// local mirror and the remote changed after a replay reset.
+func (m *Manager) DetectConflicts() ([]string, error) {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, err
+	}
+	var conflicted []string
+	for path, st := range status {
+		if st.Worktree == gogit.Unmodified && st.Staging == gogit.Unmodified {
+			continue
+		}
+		if issueFilePattern.MatchString(filepath.Base(path)) {
+			conflicted = append(conflicted, path)
+		}
+	}
+	return conflicted, nil
+}
+
+// ResolveConflicts applies the configured strategy to the given
+// conflicted issue file paths. The working tree holds the freshly
+// rendered JIRA side; HEAD holds the repository side.
+func (m *Manager) ResolveConflicts(paths []string) error {
+	switch m.ConflictResolutionStrategy() {
+	case StrategyPreferJira:
+		// The working tree already carries the JIRA rendering; it wins
+		// on the next commit.
+		return nil
+	case StrategyPreferGit:
+		for _, path := range paths {
+			if err := m.restoreFromHead(path); err != nil {
+				return err
+			}
+		}
+		return nil
+	case StrategyMerge:
+		for _, path := range paths {
+			if err := m.mergeWithHead(path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// restoreFromHead overwrites a working tree file with its HEAD version.
+func (m *Manager) restoreFromHead(relPath string) error {
+	content, err := m.headContent(relPath)
+	if err != nil || content == nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.cfg.LocalPath, relPath), content, 0o644)
+}
+
+// mergeWithHead merges the HEAD and working tree versions of an issue
+// file front-matter field by field, preferring the rendered value where
+// one is set. Unparseable content falls back to the rendered version.
+func (m *Manager) mergeWithHead(relPath string) error {
+	headContent, err := m.headContent(relPath)
+	if err != nil || headContent == nil {
+		return err
+	}
+	treeContent, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, relPath))
+	if err != nil {
+		return fmt.Errorf("git: read %s: %w", relPath, err)
+	}
+
+	theirs, terr := parseIssueFile(headContent)
+	ours, oerr := parseIssueFile(treeContent)
+	if terr != nil || oerr != nil {
+		// Keep the rendered side when either version is unparseable.
+		return nil
+	}
+
+	merged := mergeIssueData(theirs, ours)
+	content, err := renderIssueFile(merged)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.cfg.LocalPath, relPath), content, 0o644)
+}
+
+// mergeIssueData overlays ours onto theirs, field by field: a set value
+// on the rendered side wins, everything else keeps the repository value.
+func mergeIssueData(theirs, ours *IssueData) *IssueData {
+	merged := *theirs
+	if ours.Summary != "" {
+		merged.Summary = ours.Summary
+	}
+	if ours.IssueType != "" {
+		merged.IssueType = ours.IssueType
+	}
+	if ours.Status != "" {
+		merged.Status = ours.Status
+	}
+	if ours.StatusCategory != "" {
+		merged.StatusCategory = ours.StatusCategory
+	}
+	if ours.Priority != "" {
+		merged.Priority = ours.Priority
+	}
+	if ours.Assignee != "" {
+		merged.Assignee = ours.Assignee
+	}
+	if ours.Reporter != "" {
+		merged.Reporter = ours.Reporter
+	}
+	if len(ours.Labels) > 0 {
+		merged.Labels = ours.Labels
+	}
+	if len(ours.Components) > 0 {
+		merged.Components = ours.Components
+	}
+	if ours.Updated != "" {
+		merged.Updated = ours.Updated
+	}
+	if len(ours.Links) > 0 {
+		merged.Links = ours.Links
+	}
+	if len(ours.Subtasks) > 0 {
+		merged.Subtasks = ours.Subtasks
+	}
+	if len(ours.Custom) > 0 {
+		merged.Custom = ours.Custom
+	}
+	if ours.Description != "" {
+		merged.Description = ours.Description
+	}
+	return &merged
+}
+
+// headContent reads a file's content from the HEAD commit, or nil when
+// the file does not exist there.
+func (m *Manager) headContent(relPath string) ([]byte, error) {
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := m.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	file, err := commit.File(filepath.ToSlash(relPath))
+	if err != nil {
+		return nil, nil
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
@@ -0,0 +1,168 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// newConflictTestRepo returns a Manager over a repository with one
+// committed issue file, so tests can diverge the working tree from
+// HEAD. The committed side has an assignee and description the
+// conflicting render drops.
+func newConflictTestRepo(t *testing.T) (*Manager, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "repo")
+	if _, err := gogit.PlainInit(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManager(Config{
+		URL:        dir,
+		Branch:     "master",
+		LocalPath:  dir,
+		FileLayout: "{project}/{key}.md",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Open(); err != nil {
+		t.Fatal(err)
+	}
+	rel, err := m.CreateIssueFile(&IssueData{
+		Key: "PROJ-7", Project: "PROJ", Summary: "Original summary",
+		IssueType: "Task", Status: "In Progress", Assignee: "alice",
+		Description: "Original description",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# mirror\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	commitAll(t, m)
+	return m, rel
+}
+
+// commitAll stages and commits every working tree change.
+func commitAll(t *testing.T, m *Manager) {
+	t.Helper()
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.AddWithOptions(&gogit.AddOptions{All: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Commit("sync", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@localhost", When: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// divergeIssueFile rewrites the committed issue with a fresh rendering
+// that changes the status and summary but carries no assignee or
+// description, mimicking a conflicting JIRA-side update.
+func divergeIssueFile(t *testing.T, m *Manager) {
+	t.Helper()
+	if _, err := m.CreateIssueFile(&IssueData{
+		Key: "PROJ-7", Project: "PROJ", Summary: "Updated summary",
+		IssueType: "Task", Status: "Done",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDetectConflicts verifies only modified issue files are reported:
+// a clean tree has no conflicts and non-issue files never count.
+func TestDetectConflicts(t *testing.T) {
+	m, rel := newConflictTestRepo(t)
+
+	conflicts, err := m.DetectConflicts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("clean tree reports conflicts: %v", conflicts)
+	}
+
+	divergeIssueFile(t, m)
+	if err := os.WriteFile(filepath.Join(m.cfg.LocalPath, "README.md"), []byte("# edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conflicts, err = m.DetectConflicts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != rel {
+		t.Errorf("conflicts %v, want only %s", conflicts, rel)
+	}
+}
+
+// TestResolveConflictsStrategies verifies each strategy: prefer-jira
+// keeps the rendered side, prefer-git restores HEAD, and merge keeps
+// repository fields the render left empty while taking rendered
+// updates. Unknown strategies fall back to prefer-jira.
+func TestResolveConflictsStrategies(t *testing.T) {
+	for _, strategy := range []string{StrategyPreferJira, StrategyPreferGit, StrategyMerge, "unknown"} {
+		t.Run(strategy, func(t *testing.T) {
+			m, rel := newConflictTestRepo(t)
+			m.SetConflictResolutionStrategy(strategy)
+			divergeIssueFile(t, m)
+
+			if err := m.ResolveConflicts([]string{rel}); err != nil {
+				t.Fatal(err)
+			}
+			content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, rel))
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := parseIssueFile(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch strategy {
+			case StrategyPreferGit:
+				if data.Summary != "Original summary" || data.Status != "In Progress" {
+					t.Errorf("prefer-git resolved to %q/%q, want the committed version", data.Summary, data.Status)
+				}
+			case StrategyMerge:
+				if data.Summary != "Updated summary" || data.Status != "Done" {
+					t.Errorf("merge dropped rendered updates: %q/%q", data.Summary, data.Status)
+				}
+				if data.Assignee != "alice" || data.Description != "Original description" {
+					t.Errorf("merge lost repository fields the render left empty: assignee %q, description %q",
+						data.Assignee, data.Description)
+				}
+			default: // prefer-jira, including the unknown-strategy fallback
+				if data.Summary != "Updated summary" || data.Status != "Done" {
+					t.Errorf("prefer-jira resolved to %q/%q, want the rendered version", data.Summary, data.Status)
+				}
+				if data.Assignee != "" {
+					t.Errorf("prefer-jira kept repository assignee %q", data.Assignee)
+				}
+			}
+		})
+	}
+}
+
+// TestConflictResolutionStrategyFallback verifies unknown configured
+// values report as prefer-jira.
+func TestConflictResolutionStrategyFallback(t *testing.T) {
+	m, _ := newConflictTestRepo(t)
+	for _, strategy := range []string{"", "theirs", "prefer_jira"} {
+		m.SetConflictResolutionStrategy(strategy)
+		if got := m.ConflictResolutionStrategy(); got != StrategyPreferJira {
+			t.Errorf("strategy %q reported as %q, want %s", strategy, got, StrategyPreferJira)
+		}
+	}
+	m.SetConflictResolutionStrategy(StrategyPreferGit)
+	if got := m.ConflictResolutionStrategy(); got != StrategyPreferGit {
+		t.Errorf("strategy prefer-git reported as %q", got)
+	}
+}
@@ -0,0 +1,18 @@
+package git
+
+import "regexp"
+
+// updatedStampPattern matches the updated front-matter line, the one
+// piece of rendered content that moves even when nothing mirrored
+// changed.
+var updatedStampPattern = regexp.MustCompile(`(?m)^updated: .*\n`)
+
+// deltaHash is the content hash used for write-skip delta detection:
+// the file hashed with the volatile updated stamp removed. JIRA bumps
+// an issue's updated timestamp for changes that never reach the
+// mirrored file — re-ranking, watchers, fields outside the mapping —
+// and rewriting just the stamp would bury real changes in no-op
+// commits.
+func deltaHash(content []byte) string {
+	return hashContent(updatedStampPattern.ReplaceAll(content, nil))
+}
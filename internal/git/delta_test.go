@@ -0,0 +1,46 @@
+package git
+
+import "testing"
+
+// TestDeltaSkipsContentIdenticalUpdate verifies an issue whose JIRA
+// updated stamp moved, but whose mirrored content is otherwise
+// identical, is not rewritten and produces no commit.
+func TestDeltaSkipsContentIdenticalUpdate(t *testing.T) {
+	m := initTestRepo(t)
+
+	data := &IssueData{
+		Key:       "ALPHA-1",
+		Project:   "ALPHA",
+		Summary:   "delta detection",
+		IssueType: "Task",
+		Status:    "To Do",
+		Updated:   "2024-01-01T00:00:00.000+0000",
+	}
+	if _, err := m.CreateIssueFile(data); err != nil {
+		t.Fatal(err)
+	}
+	if hash, err := m.CommitAll("sync: mirror ALPHA-1"); err != nil || hash == "" {
+		t.Fatalf("initial write did not commit (hash=%q, err=%v)", hash, err)
+	}
+
+	// Only the updated stamp moves: the write is skipped and the tree
+	// stays clean.
+	bumped := *data
+	bumped.Updated = "2024-01-02T00:00:00.000+0000"
+	if _, err := m.CreateIssueFile(&bumped); err != nil {
+		t.Fatal(err)
+	}
+	if hash, err := m.CommitAll("sync: mirror ALPHA-1"); err != nil || hash != "" {
+		t.Fatalf("content-identical update produced a commit (hash=%q, err=%v)", hash, err)
+	}
+
+	// A real change still writes and commits.
+	changed := bumped
+	changed.Summary = "delta detection, revised"
+	if _, err := m.CreateIssueFile(&changed); err != nil {
+		t.Fatal(err)
+	}
+	if hash, err := m.CommitAll("sync: mirror ALPHA-1"); err != nil || hash == "" {
+		t.Fatalf("real change did not commit (hash=%q, err=%v)", hash, err)
+	}
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldChange records one issue field transition between two syncs.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// String renders the change as "field old→new".
+func (c FieldChange) String() string {
+	if c.Old == "" && c.New == "" {
+		return c.Field + " changed"
+	}
+	old := c.Old
+	if old == "" {
+		old = "none"
+	}
+	new := c.New
+	if new == "" {
+		new = "none"
+	}
+	return fmt.Sprintf("%s %s→%s", c.Field, old, new)
+}
+
+// FormatFieldChanges joins changes for use in a commit message.
+func FormatFieldChanges(changes []FieldChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, c := range changes {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DiffIssueData compares two issue snapshots and returns the meaningful
+// field changes between them. Timestamps (created, updated) are not
+// considered meaningful: a diff that is empty means the issue content
+// did not change and no commit is warranted.
+func DiffIssueData(old, new *IssueData) []FieldChange {
+	if old == nil || new == nil {
+		return nil
+	}
+	var changes []FieldChange
+	compare := func(field, oldVal, newVal string) {
+		if oldVal != newVal {
+			changes = append(changes, FieldChange{Field: field, Old: oldVal, New: newVal})
+		}
+	}
+	compare("summary", old.Summary, new.Summary)
+	compare("type", old.IssueType, new.IssueType)
+	compare("status", old.Status, new.Status)
+	compare("priority", old.Priority, new.Priority)
+	compare("assignee", old.Assignee, new.Assignee)
+	compare("reporter", old.Reporter, new.Reporter)
+	compare("labels", strings.Join(old.Labels, ","), strings.Join(new.Labels, ","))
+	compare("components", strings.Join(old.Components, ","), strings.Join(new.Components, ","))
+	compare("sprint", old.Sprint, new.Sprint)
+	compare("epic", old.Epic, new.Epic)
+	compare("timeSpent", old.TimeSpent, new.TimeSpent)
+	compare("remainingEstimate", old.RemainingEstimate, new.RemainingEstimate)
+	for _, field := range customFieldNames(old.Custom, new.Custom) {
+		compare(field, customValue(old.Custom, field), customValue(new.Custom, field))
+	}
+	if old.Description != new.Description {
+		changes = append(changes, FieldChange{Field: "description"})
+	}
+	return changes
+}
+
+// customFieldNames returns the union of mapped custom field names in
+// both snapshots, sorted for stable diff output.
+func customFieldNames(old, new map[string]interface{}) []string {
+	seen := make(map[string]bool, len(old)+len(new))
+	var names []string
+	for name := range old {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for name := range new {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// customValue renders a custom field value for comparison. Values pass
+// through a JSON decode on the JIRA side and a YAML round-trip on the
+// git side, so the comparison is over their rendered form rather than
+// their concrete types.
+func customValue(custom map[string]interface{}, field string) string {
+	value, ok := custom[field]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// FilterFieldChanges drops changes to the listed fields, e.g. noisy
+// custom fields excluded from change detection via
+// spec.sync.ignoreFields.
+func FilterFieldChanges(changes []FieldChange, ignored []string) []FieldChange {
+	if len(ignored) == 0 || len(changes) == 0 {
+		return changes
+	}
+	skip := make(map[string]bool, len(ignored))
+	for _, field := range ignored {
+		skip[field] = true
+	}
+	kept := changes[:0]
+	for _, change := range changes {
+		if !skip[change.Field] {
+			kept = append(kept, change)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Drift policies selecting how manually edited managed files are
+// handled at the start of a sync cycle.
+const (
+	// DriftOverwrite re-syncs drifted files from JIRA, with a warning.
+	DriftOverwrite = "overwrite"
+	// DriftSkip leaves drifted files untouched, with a warning.
+	DriftSkip = "skip"
+	// DriftFail aborts the sync cycle until the drift is resolved.
+	DriftFail = "fail"
+)
+
+// Drift reports a managed issue file whose working-tree content no
+// longer matches what the last sync wrote.
+type Drift struct {
+	// Key is the issue whose file drifted.
+	Key string `json:"issueKey"`
+	// Path is the file's repository-relative path. Empty when the file
+	// was deleted.
+	Path string `json:"path,omitempty"`
+	// Deleted marks a managed file that was removed from the tree.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// DetectDrift compares each managed issue file against the content hash
+// recorded at its last sync. Only files present in the sync state are
+// checked, so user-added documents alongside the mirror are never
+// flagged. Issues recorded before content hashes were tracked are
+// skipped until their next sync refreshes the state.
+func (m *Manager) DetectDrift(ctx context.Context) []Drift {
+	state := m.LoadSyncState(ctx)
+	var drifts []Drift
+	for key, result := range state {
+		if result.Hash == "" {
+			continue
+		}
+		relPath, ok := m.findIssueFile(key)
+		if !ok {
+			drifts = append(drifts, Drift{Key: key, Deleted: true})
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, relPath))
+		if err != nil {
+			continue
+		}
+		if hashContent(content) != result.Hash {
+			drifts = append(drifts, Drift{Key: key, Path: relPath})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Key < drifts[j].Key })
+	return drifts
+}
+
+// hashContent is the content hash recorded per issue file for drift
+// detection.
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// epicIndexPattern matches generated epic index basenames like
+// "PROJ-123-index.md". The suffix keeps them outside issueFilePattern so
+// orphan detection never mistakes an index for a mirrored issue.
+var epicIndexPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*-\d+-index\.md$`)
+
+// WriteEpicIndexes regenerates the child-issue index next to each epic:
+// a markdown list linking every issue whose front-matter names the epic.
+// Indexes whose epic no longer exists or no longer has children are
+// removed. Issue files that fail to parse (e.g. rendered by a custom
+// template without front-matter) are skipped. No-op unless the manager
+// is configured with EpicChildIndex.
+func (m *Manager) WriteEpicIndexes() error {
+	if !m.cfg.EpicChildIndex {
+		return nil
+	}
+
+	type childRef struct {
+		key     string
+		summary string
+		relPath string
+	}
+	epicPaths := map[string]string{}
+	children := map[string][]childRef{}
+	_ = filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			if d != nil && d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		match := issueFilePattern.FindStringSubmatch(d.Name())
+		if match == nil {
+			return nil
+		}
+		content, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil
+		}
+		data, perr := parseIssueFile(content)
+		if perr != nil {
+			return nil
+		}
+		rel, rerr := filepath.Rel(m.cfg.LocalPath, path)
+		if rerr != nil {
+			return nil
+		}
+		epicPaths[match[1]] = rel
+		if data.Epic != "" {
+			children[data.Epic] = append(children[data.Epic], childRef{
+				key:     match[1],
+				summary: data.Summary,
+				relPath: rel,
+			})
+		}
+		return nil
+	})
+
+	generated := map[string]struct{}{}
+	for epicKey, refs := range children {
+		epicPath, ok := epicPaths[epicKey]
+		if !ok {
+			continue
+		}
+		indexRel := strings.TrimSuffix(epicPath, ".md") + "-index.md"
+		sort.Slice(refs, func(i, j int) bool { return refs[i].key < refs[j].key })
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "# %s child issues\n\n", epicKey)
+		indexDir := filepath.Dir(indexRel)
+		for _, ref := range refs {
+			link, rerr := filepath.Rel(indexDir, ref.relPath)
+			if rerr != nil {
+				link = ref.relPath
+			}
+			fmt.Fprintf(&buf, "- [%s: %s](%s)\n", ref.key, ref.summary, filepath.ToSlash(link))
+		}
+
+		fullPath := filepath.Join(m.cfg.LocalPath, indexRel)
+		if existing, rerr := os.ReadFile(fullPath); rerr == nil && bytes.Equal(existing, buf.Bytes()) {
+			generated[indexRel] = struct{}{}
+			continue
+		}
+		if err := os.WriteFile(fullPath, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("git: write epic index %s: %w", indexRel, err)
+		}
+		generated[indexRel] = struct{}{}
+	}
+
+	// Remove indexes left behind by epics whose children moved away or
+	// whose epic file was deleted.
+	_ = filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			if d != nil && d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !epicIndexPattern.MatchString(d.Name()) {
+			return nil
+		}
+		rel, rerr := filepath.Rel(m.cfg.LocalPath, path)
+		if rerr != nil {
+			return nil
+		}
+		if _, ok := generated[rel]; !ok {
+			_ = os.Remove(path)
+		}
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// CommitInfo describes one commit that touched an issue file.
+type CommitInfo struct {
+	Hash      string    `json:"hash"`
+	Author    string    `json:"author"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// GetFileHistory returns the commits touching relPath, newest first.
+// Commits are matched by the file's basename rather than the full path:
+// layout changes move an issue file between directories while keeping
+// its "KEY.md" name, and go-git's log does not follow renames, so a
+// strict path match would truncate history at the move.
+func (m *Manager) GetFileHistory(ctx context.Context, relPath string) ([]CommitInfo, error) {
+	if m.repo == nil {
+		if err := m.Open(); err != nil {
+			return nil, err
+		}
+	}
+	// Shallow clones truncate the log; fetch the rest before walking.
+	if err := m.Unshallow(ctx); err != nil {
+		return nil, err
+	}
+	base := filepath.Base(relPath)
+	iter, err := m.repo.Log(&gogit.LogOptions{
+		PathFilter: func(path string) bool {
+			return filepath.Base(path) == base
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("git: log %s: %w", relPath, err)
+	}
+	defer iter.Close()
+
+	var commits []CommitInfo
+	err = iter.ForEach(func(c *object.Commit) error {
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		commits = append(commits, CommitInfo{
+			Hash:      c.Hash.String(),
+			Author:    c.Author.Name,
+			Timestamp: c.Author.When,
+			Message:   strings.TrimSpace(c.Message),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("git: walk history of %s: %w", relPath, err)
+	}
+	return commits, nil
+}
@@ -0,0 +1,96 @@
+package git
+
+// IssueData is the git-side representation of a JIRA issue, rendered to
+// a markdown file with YAML front-matter.
+type IssueData struct {
+	// FrontMatterVersion records which front-matter schema revision the
+	// file was written with, so older files can be upgraded; see
+	// CurrentFrontMatterVersion. Files predating versioning parse as 0.
+	FrontMatterVersion int `yaml:"frontMatterVersion"`
+
+	Key            string   `yaml:"key"`
+	Project        string   `yaml:"project,omitempty"`
+	Summary        string   `yaml:"summary"`
+	IssueType      string   `yaml:"type"`
+	Status         string   `yaml:"status"`
+	StatusCategory string   `yaml:"statusCategory"`
+	Priority       string   `yaml:"priority,omitempty"`
+	Assignee       string   `yaml:"assignee,omitempty"`
+	Reporter       string   `yaml:"reporter,omitempty"`
+	Labels         []string `yaml:"labels,omitempty"`
+	Components     []string `yaml:"components,omitempty"`
+	Sprint         string   `yaml:"sprint,omitempty"`
+	Epic           string   `yaml:"epic,omitempty"`
+	Created        string   `yaml:"created"`
+	Updated        string   `yaml:"updated"`
+
+	// TimeSpent and RemainingEstimate are the issue's aggregated
+	// time-tracking figures, present only when worklog sync is enabled
+	// and the project tracks time.
+	TimeSpent         string `yaml:"timeSpent,omitempty"`
+	RemainingEstimate string `yaml:"remainingEstimate,omitempty"`
+
+	// Links and Subtasks reference related issues. Path is filled in at
+	// render time with the relative markdown path of the target file
+	// when the target is part of the synced scope.
+	Links    []IssueLink  `yaml:"links,omitempty"`
+	Subtasks []SubtaskRef `yaml:"subtasks,omitempty"`
+
+	// Custom holds mapped custom field values, emitted as additional
+	// front-matter keys alongside the standard ones.
+	Custom map[string]interface{} `yaml:",inline"`
+
+	// Description is rendered as the markdown body, not front-matter.
+	Description string `yaml:"-"`
+
+	// History holds field transitions rendered as a "## History" table
+	// in the body, not front-matter.
+	History []HistoryEntry `yaml:"-"`
+	// HistoryNote replaces the history table when the changelog is
+	// unavailable, e.g. for permission reasons.
+	HistoryNote string `yaml:"-"`
+
+	// Worklogs holds time-tracking entries rendered as a "## Work Log"
+	// table in the body, not front-matter.
+	Worklogs []WorklogEntry `yaml:"-"`
+
+	// RemoteLinks holds links to resources outside the issue's own
+	// instance, rendered as a "## Remote Links" list in the body, not
+	// front-matter.
+	RemoteLinks []RemoteLinkEntry `yaml:"-"`
+}
+
+// RemoteLinkEntry is one remote link attached to the issue.
+type RemoteLinkEntry struct {
+	Title string
+	URL   string
+}
+
+// WorklogEntry is one time-tracking entry logged against the issue.
+type WorklogEntry struct {
+	Author    string
+	TimeSpent string
+	Started   string
+}
+
+// HistoryEntry is one field transition from the issue's changelog.
+type HistoryEntry struct {
+	Field  string
+	From   string
+	To     string
+	Author string
+	At     string
+}
+
+// IssueLink is a directional reference to a related issue.
+type IssueLink struct {
+	Type string `yaml:"type"`
+	Key  string `yaml:"key"`
+	Path string `yaml:"path,omitempty"`
+}
+
+// SubtaskRef references a subtask of the issue.
+type SubtaskRef struct {
+	Key  string `yaml:"key"`
+	Path string `yaml:"path,omitempty"`
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultFileLayout is the flat layout used when no template is
+// configured: every issue lands in the repository root as KEY.md.
+const DefaultFileLayout = "{key}.md"
+
+var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z]+)\}`)
+
+// layoutPlaceholders maps supported template placeholders to the
+// IssueData field they render.
+var layoutPlaceholders = map[string]func(*IssueData) string{
+	"project":        func(d *IssueData) string { return d.Project },
+	"key":            func(d *IssueData) string { return d.Key },
+	"type":           func(d *IssueData) string { return d.IssueType },
+	"status":         func(d *IssueData) string { return d.Status },
+	"statusCategory": func(d *IssueData) string { return d.StatusCategory },
+	// {sprint} groups issues by sprint, e.g. "sprints/{sprint}/{key}.md".
+	// Issues not assigned to any sprint land under "backlog".
+	"sprint": func(d *IssueData) string {
+		if d.Sprint == "" {
+			return "backlog"
+		}
+		return d.Sprint
+	},
+}
+
+// ValidateFileLayout checks that a layout template only uses known
+// placeholders and includes {key} so paths stay unique per issue.
+func ValidateFileLayout(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	if strings.Contains(layout, "..") || strings.HasPrefix(layout, "/") {
+		return fmt.Errorf("file layout %q must be a relative path without traversal", layout)
+	}
+	seenKey := false
+	for _, match := range placeholderPattern.FindAllStringSubmatch(layout, -1) {
+		name := match[1]
+		if _, ok := layoutPlaceholders[name]; !ok {
+			return fmt.Errorf("file layout references unknown placeholder {%s}", name)
+		}
+		if name == "key" {
+			seenKey = true
+		}
+	}
+	if !seenKey {
+		return fmt.Errorf("file layout %q must include the {key} placeholder", layout)
+	}
+	return nil
+}
+
+// renderLayout expands a layout template for an issue, sanitizing each
+// substituted value so it cannot introduce extra path components.
+func renderLayout(layout string, data *IssueData) string {
+	if layout == "" {
+		layout = DefaultFileLayout
+	}
+	return placeholderPattern.ReplaceAllStringFunc(layout, func(match string) string {
+		name := strings.Trim(match, "{}")
+		render, ok := layoutPlaceholders[name]
+		if !ok {
+			return match
+		}
+		return sanitizePathComponent(render(data))
+	})
+}
+
+// sanitizePathComponent makes a placeholder value safe for use as a
+// single path segment.
+func sanitizePathComponent(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, "/", "-")
+	value = strings.ReplaceAll(value, "\\", "-")
+	value = strings.ReplaceAll(value, "..", "-")
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
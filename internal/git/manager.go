@@ -0,0 +1,879 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	gosync "sync"
+	"text/template"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"gopkg.in/yaml.v3"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// Config holds the settings for a managed issue-mirror repository.
+type Config struct {
+	// URL is the clone/push URL of the repository.
+	URL string
+	// Branch is the branch issue files are committed to.
+	Branch string
+	// FileLayout is the path template issue files are written under,
+	// e.g. "{project}/{type}/{key}.md". Empty means DefaultFileLayout.
+	FileLayout string
+	// IssueTemplate is an optional Go text/template rendering each
+	// issue file from its IssueData, replacing the built-in markdown
+	// layout. Note that change diffing and the read-back API rely on
+	// the built-in front-matter format, so templates without it trade
+	// those features for layout control. Empty uses the built-in
+	// layout.
+	IssueTemplate string
+	// TypeTemplates overrides IssueTemplate per JIRA issue type name,
+	// e.g. a dedicated layout for "Epic". Types without an entry fall
+	// back to IssueTemplate or the built-in layout.
+	TypeTemplates map[string]string
+	// TypeLayouts overrides FileLayout per JIRA issue type name, so
+	// e.g. bugs and epics land in different directories. An issue whose
+	// type changes is moved to its new location on the next write.
+	TypeLayouts map[string]string
+	// CommitMessageTemplate is an optional Go text/template rendering
+	// the message of each single-issue commit from its CommitContext,
+	// replacing the built-in messages. Empty uses the built-in format.
+	CommitMessageTemplate string
+	// CommitMessagePattern is a regular expression every rendered
+	// commit message must match, e.g. a conventional-commits form.
+	// Messages that do not match fail the commit. Empty disables the
+	// check.
+	CommitMessagePattern string
+	// AddCommitTrailers appends Jira-Issue and Jira-URL trailers to
+	// mirrored commit messages via AppendTrailers, linking each commit
+	// back to the issues it touched.
+	AddCommitTrailers bool
+	// JiraBaseURL is the JIRA instance base URL the Jira-URL trailers
+	// link to. Empty omits the URL trailer.
+	JiraBaseURL string
+	// EpicChildIndex generates an index file next to each epic linking
+	// the issues assigned to it, regenerated as children change.
+	EpicChildIndex bool
+	// LocalPath is the working directory the repository is cloned into.
+	LocalPath string
+	// CloneDepth truncates clone history to the given number of
+	// commits. Zero clones the full history.
+	CloneDepth int
+	// SparsePaths restricts the checkout to the listed directories,
+	// e.g. one project prefix out of a multi-project repository. Empty
+	// checks out the full tree.
+	SparsePaths []string
+	// Username and Token authenticate pushes over HTTPS.
+	Username string
+	Token    string
+	// AuthorName and AuthorEmail identify the bot account in commits.
+	AuthorName  string
+	AuthorEmail string
+	// AuthorStrategy selects whose identity appears as the commit
+	// author for single-issue commits: "operator" (default, the bot),
+	// "reporter" or "assignee". The committer stays the operator
+	// identity either way, so mirrored commits remain traceable to the
+	// bot; batch commits always use the operator.
+	AuthorStrategy string
+	// ConflictResolutionStrategy selects how diverged issue files are
+	// reconciled during push recovery: prefer-jira (default),
+	// prefer-git, or merge.
+	ConflictResolutionStrategy string
+	// MirrorRemotes lists additional push URLs the branch is mirrored
+	// to after each primary push.
+	MirrorRemotes []string
+	// MirrorCredentials overrides push credentials per mirror URL.
+	// Mirrors without an entry reuse Username and Token.
+	MirrorCredentials map[string]Credential
+	// RequireMirrors makes a mirror push failure fail the push instead
+	// of being recorded for later inspection.
+	RequireMirrors bool
+	// UseLFS routes attachment paths through Git LFS in the managed
+	// .gitattributes section written by EnsureRepoConfig.
+	UseLFS bool
+	// IsolatedWorktrees lets each concurrently syncing project work in
+	// a private clone on its own branch (see Manager.Worktree) instead
+	// of sharing the working tree. Off by default: every worktree costs
+	// a full copy of the repository on disk.
+	IsolatedWorktrees bool
+	// TLS controls certificate trust for self-hosted git servers behind
+	// an internal CA.
+	TLS TLSConfig
+	// Proxy routes clone, fetch, and push traffic through an egress
+	// proxy. Only applies to http(s) remotes; zero values honor the
+	// standard proxy environment variables.
+	Proxy ProxyConfig
+	// RedactSecrets applies the built-in redaction rules for common
+	// secret shapes to issue content before it is written.
+	RedactSecrets bool
+	// RedactionRules lists custom redaction patterns applied to issue
+	// content after the built-in rules.
+	RedactionRules []RedactionRule
+}
+
+// TLSConfig controls certificate trust for the repository's remotes.
+type TLSConfig struct {
+	// CABundle is PEM certificate data trusted in addition to the
+	// system roots. Empty uses the system roots alone.
+	CABundle []byte
+	// InsecureSkipVerify disables certificate verification entirely.
+	// Strongly discouraged; prefer CABundle.
+	InsecureSkipVerify bool
+}
+
+// Commit author strategies.
+const (
+	AuthorOperator = "operator"
+	AuthorReporter = "reporter"
+	AuthorAssignee = "assignee"
+)
+
+// authorEmailPattern is a lenient shape check on author emails; JIRA
+// users with hidden or malformed addresses fall back to the operator
+// identity rather than producing unparseable commits.
+var authorEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+// Credential authenticates pushes to one remote over HTTPS.
+type Credential struct {
+	Username string
+	Token    string
+}
+
+// Manager owns the local clone of an issue-mirror repository and writes
+// issue files into it.
+type Manager struct {
+	cfg      Config
+	repo     *gogit.Repository
+	strategy string
+	// issueTemplate is the compiled custom template, nil when the
+	// built-in layout is used.
+	issueTemplate *template.Template
+	// typeTemplates are the compiled per-type templates, keyed by issue
+	// type name.
+	typeTemplates map[string]*template.Template
+
+	// commitTemplate and commitPattern are the compiled commit message
+	// template and its validation pattern; both nil when unconfigured.
+	commitTemplate *template.Template
+	commitPattern  *regexp.Regexp
+
+	// redactors are the compiled redaction rules applied to issue
+	// content before it is written. Empty disables redaction.
+	redactors []redactor
+
+	// mirrorMu guards mirrorFailures, written after every mirror push
+	// round and read by callers surfacing warnings.
+	mirrorMu       gosync.Mutex
+	mirrorFailures map[string]error
+
+	// worktreeMu serializes worktree merges into the shared tree.
+	worktreeMu gosync.Mutex
+}
+
+// NewManager returns a Manager for cfg. The repository is not opened
+// until Clone or Open is called.
+func NewManager(cfg Config) (*Manager, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("git: repository URL is required")
+	}
+	if cfg.Branch == "" {
+		cfg.Branch = "main"
+	}
+	if cfg.AuthorName == "" {
+		cfg.AuthorName = "jira-cdc-bot"
+	}
+	if cfg.AuthorEmail == "" {
+		cfg.AuthorEmail = "jira-cdc-bot@localhost"
+	}
+	var issueTemplate *template.Template
+	if cfg.IssueTemplate != "" {
+		parsed, err := ParseIssueTemplate(cfg.IssueTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("git: parse issue template: %w", err)
+		}
+		issueTemplate = parsed
+	}
+	var typeTemplates map[string]*template.Template
+	for typeName, text := range cfg.TypeTemplates {
+		parsed, err := ParseIssueTemplate(text)
+		if err != nil {
+			return nil, fmt.Errorf("git: parse issue template for type %s: %w", typeName, err)
+		}
+		if typeTemplates == nil {
+			typeTemplates = map[string]*template.Template{}
+		}
+		typeTemplates[typeName] = parsed
+	}
+	var commitTemplate *template.Template
+	if cfg.CommitMessageTemplate != "" {
+		parsed, err := ParseCommitMessageTemplate(cfg.CommitMessageTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("git: parse commit message template: %w", err)
+		}
+		commitTemplate = parsed
+	}
+	commitPattern, err := compileCommitMessagePattern(cfg.CommitMessagePattern)
+	if err != nil {
+		return nil, err
+	}
+	redactors, err := compileRedactionRules(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{cfg: cfg, strategy: cfg.ConflictResolutionStrategy, issueTemplate: issueTemplate, typeTemplates: typeTemplates,
+		commitTemplate: commitTemplate, commitPattern: commitPattern, redactors: redactors}, nil
+}
+
+func (m *Manager) auth() transport.AuthMethod {
+	if m.cfg.Token == "" {
+		return nil
+	}
+	username := m.cfg.Username
+	if username == "" {
+		username = "git"
+	}
+	return &githttp.BasicAuth{Username: username, Password: m.cfg.Token}
+}
+
+// CheckRemote verifies the remote repository is reachable with the
+// configured credentials, equivalent to a git ls-remote.
+func (m *Manager) CheckRemote(ctx context.Context) error {
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{m.cfg.URL},
+	})
+	opts := &gogit.ListOptions{
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+	}
+	if _, err := remote.ListContext(ctx, opts); err != nil {
+		return fmt.Errorf("git: ls-remote %s: %w", m.cfg.URL, err)
+	}
+	return nil
+}
+
+// Clone clones the remote repository into LocalPath, or opens the
+// existing clone if one is already present. CloneDepth and SparsePaths
+// from the config are applied when set; if the remote rejects the
+// shallow negotiation the clone falls back to a full one.
+func (m *Manager) Clone(ctx context.Context) error {
+	start := time.Now()
+	opts := &gogit.CloneOptions{
+		URL:             m.cfg.URL,
+		ReferenceName:   plumbing.NewBranchReferenceName(m.cfg.Branch),
+		SingleBranch:    true,
+		Depth:           m.cfg.CloneDepth,
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(m.cfg.URL),
+	}
+	sparse := len(m.cfg.SparsePaths) > 0
+	if sparse {
+		opts.NoCheckout = true
+	}
+
+	repo, err := gogit.PlainCloneContext(ctx, m.cfg.LocalPath, false, opts)
+	if errors.Is(err, gogit.ErrRepositoryAlreadyExists) {
+		return m.Open()
+	}
+	if err != nil && m.cfg.CloneDepth > 0 {
+		// Some remotes refuse shallow fetches; retry with full history.
+		opts.Depth = 0
+		repo, err = gogit.PlainCloneContext(ctx, m.cfg.LocalPath, false, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("git: clone %s: %w", m.cfg.URL, err)
+	}
+	m.repo = repo
+
+	if sparse {
+		if err := m.sparseCheckout(); err != nil {
+			return err
+		}
+	}
+	metrics.RecordGitOperation("clone", time.Since(start))
+	return nil
+}
+
+// sparseCheckout populates the working tree with only the configured
+// paths, falling back to a full checkout when sparse checkout fails.
+func (m *Manager) sparseCheckout() error {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	opts := &gogit.CheckoutOptions{
+		Branch:                    plumbing.NewBranchReferenceName(m.cfg.Branch),
+		SparseCheckoutDirectories: m.cfg.SparsePaths,
+	}
+	if err := wt.Checkout(opts); err != nil {
+		opts.SparseCheckoutDirectories = nil
+		if ferr := wt.Checkout(opts); ferr != nil {
+			return fmt.Errorf("git: checkout %s: %w", m.cfg.Branch, ferr)
+		}
+	}
+	return nil
+}
+
+// Pull fast-forwards the local branch from the remote, keeping the
+// configured depth for shallow clones.
+func (m *Manager) Pull(ctx context.Context) error {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = wt.PullContext(ctx, &gogit.PullOptions{
+		ReferenceName:   plumbing.NewBranchReferenceName(m.cfg.Branch),
+		SingleBranch:    true,
+		Depth:           m.cfg.CloneDepth,
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(m.cfg.URL),
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("git: pull: %w", err)
+	}
+	metrics.RecordGitOperation("pull", time.Since(start))
+	return nil
+}
+
+// maxGitDepth is the git protocol's "infinite" depth, used to convert a
+// shallow clone into a full one.
+const maxGitDepth = 2147483647
+
+// isShallow reports whether the clone has truncated history.
+func (m *Manager) isShallow() bool {
+	hashes, err := m.repo.Storer.Shallow()
+	return err == nil && len(hashes) > 0
+}
+
+// Unshallow fetches the full history for a shallow clone so history
+// walks see every commit. It is a no-op on full clones.
+func (m *Manager) Unshallow(ctx context.Context) error {
+	if !m.isShallow() {
+		return nil
+	}
+	err := m.repo.FetchContext(ctx, &gogit.FetchOptions{
+		Depth:           maxGitDepth,
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(m.cfg.URL),
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("git: unshallow: %w", err)
+	}
+	return nil
+}
+
+// Open opens an existing clone at LocalPath.
+func (m *Manager) Open() error {
+	repo, err := gogit.PlainOpen(m.cfg.LocalPath)
+	if err != nil {
+		return fmt.Errorf("git: open %s: %w", m.cfg.LocalPath, err)
+	}
+	m.repo = repo
+	return nil
+}
+
+// IssueFilePath returns the repository-relative path for an issue file
+// under the configured layout, with any per-type layout taking
+// precedence over the repository-wide one.
+func (m *Manager) IssueFilePath(data *IssueData) string {
+	if layout, ok := m.cfg.TypeLayouts[data.IssueType]; ok {
+		return renderLayout(layout, data)
+	}
+	return renderLayout(m.cfg.FileLayout, data)
+}
+
+// findIssueFile locates the current file for an issue key, following
+// the key-alias map when the issue was renamed by a project move, so
+// callers holding an old key still resolve to the moved file.
+func (m *Manager) findIssueFile(key string) (string, bool) {
+	if rel, ok := m.locateIssueFile(key); ok {
+		return rel, true
+	}
+	if current := m.ResolveKey(key); current != key {
+		return m.locateIssueFile(current)
+	}
+	return "", false
+}
+
+// locateIssueFile searches the working tree for a key's file by name,
+// returning its repository-relative path.
+func (m *Manager) locateIssueFile(key string) (string, bool) {
+	var found string
+	target := key + ".md"
+	_ = filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			if d != nil && d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == target {
+			rel, rerr := filepath.Rel(m.cfg.LocalPath, path)
+			if rerr == nil {
+				found = rel
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return found, found != ""
+}
+
+// WorkingDirectory returns the local path of the clone's working tree.
+func (m *Manager) WorkingDirectory() string {
+	return m.cfg.LocalPath
+}
+
+// Cloned reports whether the local working tree exists yet.
+func (m *Manager) Cloned() bool {
+	_, err := os.Stat(filepath.Join(m.cfg.LocalPath, ".git"))
+	return err == nil
+}
+
+// ReadIssueFile returns the raw markdown content of an issue file, or
+// nil without error when no file exists.
+func (m *Manager) ReadIssueFile(key string) ([]byte, error) {
+	relPath, ok := m.findIssueFile(key)
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, relPath))
+	if err != nil {
+		return nil, fmt.Errorf("git: read %s: %w", relPath, err)
+	}
+	return content, nil
+}
+
+// ReadIssueData parses the existing file for an issue key back into its
+// IssueData form, so updates can be diffed against the mirrored state.
+// It returns nil without error when no file exists.
+func (m *Manager) ReadIssueData(key string) (*IssueData, error) {
+	relPath, ok := m.findIssueFile(key)
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, relPath))
+	if err != nil {
+		return nil, fmt.Errorf("git: read %s: %w", relPath, err)
+	}
+	return parseIssueFile(content)
+}
+
+// parseIssueFile splits a rendered issue file back into front-matter and
+// description body.
+func parseIssueFile(content []byte) (*IssueData, error) {
+	front, rawBody, err := splitFrontMatter(content)
+	if err != nil {
+		return nil, err
+	}
+	var data IssueData
+	if err := yaml.Unmarshal(front, &data); err != nil {
+		return nil, fmt.Errorf("git: parse front-matter: %w", err)
+	}
+	body := string(rawBody)
+	// The body is "\n# KEY: summary\n\n<description>\n" with optional
+	// trailing "## Work Log", "## Remote Links", and "## History"
+	// sections that are not part of the description.
+	for _, heading := range []string{worklogHeading, remoteLinksHeading, historyHeading} {
+		if idx := strings.Index(body, "\n"+heading+"\n"); idx >= 0 {
+			body = body[:idx]
+		}
+	}
+	if idx := strings.Index(body, "\n\n"); idx >= 0 {
+		data.Description = strings.TrimSuffix(body[idx+2:], "\n")
+	}
+	return &data, nil
+}
+
+// HasIssueFile reports whether an issue file for key already exists in
+// the working tree.
+func (m *Manager) HasIssueFile(key string) bool {
+	_, ok := m.findIssueFile(key)
+	return ok
+}
+
+// StageIssueFiles writes the given issues into the working tree without
+// committing, for callers batching several writes into one commit.
+func (m *Manager) StageIssueFiles(issues []*IssueData) error {
+	for _, data := range issues {
+		if _, err := m.CreateIssueFile(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateIssueFile renders data as markdown with YAML front-matter and
+// writes it into the working tree, returning the repository-relative
+// path written. If the layout resolves to a different path than the
+// issue's existing file (for example after a status category change),
+// the old file is moved so git records a rename instead of a duplicate.
+func (m *Manager) CreateIssueFile(data *IssueData) (string, error) {
+	relPath := m.IssueFilePath(data)
+	absPath := filepath.Join(m.cfg.LocalPath, relPath)
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return "", err
+	}
+
+	if oldRel, ok := m.findIssueFile(data.Key); ok && oldRel != relPath {
+		oldAbs := filepath.Join(m.cfg.LocalPath, oldRel)
+		if err := os.Rename(oldAbs, absPath); err != nil {
+			return "", fmt.Errorf("git: move %s to %s: %w", oldRel, relPath, err)
+		}
+		m.pruneEmptyDirs(filepath.Dir(oldAbs))
+	}
+
+	m.resolveRelatedPaths(relPath, data)
+
+	// Redaction runs on the description before rendering; comments are
+	// not mirrored, so the description is the only free-form content
+	// that can carry secrets.
+	if len(m.redactors) > 0 {
+		data.Description = m.redact(data.Description)
+	}
+
+	content, err := m.renderIssue(data)
+	if err != nil {
+		return "", err
+	}
+	// Delta detection: when the render differs from the stored file only
+	// in the updated stamp, leave the file alone so the change produces
+	// no commit.
+	if old, rerr := os.ReadFile(absPath); rerr == nil && deltaHash(old) == deltaHash(content) {
+		return relPath, nil
+	}
+	if err := os.WriteFile(absPath, content, 0o644); err != nil {
+		return "", fmt.Errorf("git: write %s: %w", relPath, err)
+	}
+	metrics.RecordGitFileModified()
+	return relPath, nil
+}
+
+// resolveRelatedPaths fills in the relative markdown path for each link
+// and subtask whose target file exists in the working tree. Issues
+// outside the synced scope keep only their key.
+func (m *Manager) resolveRelatedPaths(relPath string, data *IssueData) {
+	fromDir := filepath.Dir(relPath)
+	resolve := func(key string) string {
+		target, ok := m.findIssueFile(key)
+		if !ok {
+			return ""
+		}
+		rel, err := filepath.Rel(fromDir, target)
+		if err != nil {
+			return ""
+		}
+		return filepath.ToSlash(rel)
+	}
+	for i := range data.Links {
+		data.Links[i].Path = resolve(data.Links[i].Key)
+	}
+	for i := range data.Subtasks {
+		data.Subtasks[i].Path = resolve(data.Subtasks[i].Key)
+	}
+}
+
+// renderIssue produces the file content for one issue, through the
+// custom template when one is configured. A template matching the
+// issue's type wins over the repository-wide one. A template execution
+// error fails only this issue; the caller's per-issue error handling
+// keeps the rest of the batch going.
+func (m *Manager) renderIssue(data *IssueData) ([]byte, error) {
+	tmpl := m.issueTemplate
+	if typed, ok := m.typeTemplates[data.IssueType]; ok {
+		tmpl = typed
+	}
+	if tmpl == nil {
+		return renderIssueFile(data)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("git: render issue template for %s: %w", data.Key, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderIssueFile renders an issue with the built-in markdown layout,
+// for storage backends writing rendered files outside a managed working
+// tree.
+func RenderIssueFile(data *IssueData) ([]byte, error) {
+	return renderIssueFile(data)
+}
+
+func renderIssueFile(data *IssueData) ([]byte, error) {
+	// Every render stamps the current schema version, so an issue file is
+	// migrated implicitly the next time its issue is touched.
+	data.FrontMatterVersion = CurrentFrontMatterVersion
+	front, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("git: marshal front-matter for %s: %w", data.Key, err)
+	}
+	body := fmt.Sprintf("---\n%s---\n\n# %s: %s\n\n%s\n", front, data.Key, data.Summary, data.Description)
+	if section := renderWorklogSection(data); section != "" {
+		body += section
+	}
+	if section := renderRemoteLinksSection(data); section != "" {
+		body += section
+	}
+	if section := renderHistorySection(data); section != "" {
+		body += section
+	}
+	return []byte(body), nil
+}
+
+// worklogHeading separates the time-tracking table from the description
+// body.
+const worklogHeading = "## Work Log"
+
+// renderWorklogSection renders the issue's worklog entries as a markdown
+// table. Issues without logged time get no section, so projects with
+// time tracking disabled render unchanged.
+func renderWorklogSection(data *IssueData) string {
+	if len(data.Worklogs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + worklogHeading + "\n\n")
+	b.WriteString("| Author | Time Spent | Date |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, entry := range data.Worklogs {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", entry.Author, entry.TimeSpent, entry.Started)
+	}
+	return b.String()
+}
+
+// remoteLinksHeading separates the remote link list from the
+// description body.
+const remoteLinksHeading = "## Remote Links"
+
+// renderRemoteLinksSection renders the issue's remote links as a
+// markdown link list. Issues without remote links get no section.
+func renderRemoteLinksSection(data *IssueData) string {
+	if len(data.RemoteLinks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + remoteLinksHeading + "\n\n")
+	for _, link := range data.RemoteLinks {
+		title := link.Title
+		if title == "" {
+			title = link.URL
+		}
+		fmt.Fprintf(&b, "- [%s](%s)\n", title, link.URL)
+	}
+	return b.String()
+}
+
+// historyHeading separates the changelog table from the description
+// body.
+const historyHeading = "## History"
+
+// renderHistorySection renders the issue's changelog as a markdown
+// table, or a note when the changelog was unavailable.
+func renderHistorySection(data *IssueData) string {
+	if len(data.History) == 0 && data.HistoryNote == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + historyHeading + "\n\n")
+	if data.HistoryNote != "" {
+		b.WriteString("_" + data.HistoryNote + "_\n")
+		return b.String()
+	}
+	b.WriteString("| Field | From | To | Author | When |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, entry := range data.History {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			entry.Field, entry.From, entry.To, entry.Author, entry.At)
+	}
+	return b.String()
+}
+
+// issueFilePattern matches issue file basenames like "PROJ-123.md".
+var issueFilePattern = regexp.MustCompile(`^([A-Z][A-Z0-9]*-\d+)\.md$`)
+
+// DetectOrphanedFiles lists the issue files in the working tree whose
+// keys are not in presentKeys, i.e. issues that no longer exist in the
+// current JIRA result set.
+func (m *Manager) DetectOrphanedFiles(ctx context.Context, presentKeys []string) ([]string, error) {
+	present := make(map[string]struct{}, len(presentKeys))
+	for _, key := range presentKeys {
+		present[key] = struct{}{}
+	}
+
+	var orphans []string
+	err := filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		if d.IsDir() {
+			// Archived projects are deliberately absent from JIRA scans;
+			// their files must not read as orphans.
+			if d.Name() == ".git" || path == filepath.Join(m.cfg.LocalPath, ArchiveDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		match := issueFilePattern.FindStringSubmatch(d.Name())
+		if match == nil {
+			return nil
+		}
+		if _, ok := present[match[1]]; !ok {
+			orphans = append(orphans, match[1])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("git: detect orphaned files: %w", err)
+	}
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// RemoveIssueFile deletes the file for an issue key from the working
+// tree, returning the repository-relative path removed. It returns an
+// empty path without error when no file exists.
+func (m *Manager) RemoveIssueFile(key string) (string, error) {
+	relPath, ok := m.findIssueFile(key)
+	if !ok {
+		return "", nil
+	}
+	absPath := filepath.Join(m.cfg.LocalPath, relPath)
+	if err := os.Remove(absPath); err != nil {
+		return "", fmt.Errorf("git: remove %s: %w", relPath, err)
+	}
+	m.pruneEmptyDirs(filepath.Dir(absPath))
+	return relPath, nil
+}
+
+// pruneEmptyDirs removes now-empty directories left behind by a file
+// move, stopping at the repository root.
+func (m *Manager) pruneEmptyDirs(dir string) {
+	root := filepath.Clean(m.cfg.LocalPath)
+	for dir != root && strings.HasPrefix(dir, root) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// HasChanges reports whether the working tree differs from HEAD.
+func (m *Manager) HasChanges() (bool, error) {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	return !status.IsClean(), nil
+}
+
+// CommitAll stages every change in the working tree and commits it with
+// the given message. It returns the new commit hash, or an empty string
+// if there was nothing to commit.
+func (m *Manager) CommitAll(message string) (string, error) {
+	return m.CommitAllAs(message, "", "")
+}
+
+// CommitAllAs is CommitAll with an explicit author, used when the
+// author strategy attributes a single-issue commit to a JIRA user. The
+// committer stays the operator identity for traceability. An empty
+// name or an email that does not look like an address falls back to
+// the operator identity rather than failing the commit.
+func (m *Manager) CommitAllAs(message, authorName, authorEmail string) (string, error) {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	if err := wt.AddGlob("."); err != nil {
+		return "", fmt.Errorf("git: stage changes: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", err
+	}
+	if status.IsClean() {
+		return "", nil
+	}
+
+	now := time.Now()
+	operator := &object.Signature{
+		Name:  m.cfg.AuthorName,
+		Email: m.cfg.AuthorEmail,
+		When:  now,
+	}
+	author := operator
+	if authorName != "" && authorEmailPattern.MatchString(authorEmail) {
+		author = &object.Signature{Name: authorName, Email: authorEmail, When: now}
+	}
+	hash, err := wt.Commit(message, &gogit.CommitOptions{
+		Author:    author,
+		Committer: operator,
+	})
+	if err != nil {
+		return "", fmt.Errorf("git: commit: %w", err)
+	}
+	return hash.String(), nil
+}
+
+// AuthorStrategy returns the configured commit author strategy,
+// defaulting to operator.
+func (m *Manager) AuthorStrategy() string {
+	if m.cfg.AuthorStrategy == "" {
+		return AuthorOperator
+	}
+	return m.cfg.AuthorStrategy
+}
+
+// Push pushes the local branch to the primary remote, then to each
+// configured mirror.
+func (m *Manager) Push(ctx context.Context) error {
+	start := time.Now()
+	err := m.repo.PushContext(ctx, &gogit.PushOptions{
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(m.cfg.URL),
+	})
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("git: push: %w", err)
+	}
+	metrics.RecordGitOperation("push", time.Since(start))
+	return m.pushMirrors(ctx)
+}
@@ -0,0 +1,117 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentFrontMatterVersion is the schema revision of the YAML
+// front-matter written to issue files. Bump it — and append a step to
+// frontMatterMigrations — whenever a key is added, renamed or moved, so
+// older files can be recognized and upgraded.
+const CurrentFrontMatterVersion = 1
+
+// frontMatterMigrations upgrade a parsed front-matter map one schema
+// version at a time: entry i migrates version i to i+1. Steps must be
+// idempotent. The initial versioning step renames nothing — every key
+// in pre-versioning files is still current — so it only causes the
+// version stamp to be recorded.
+var frontMatterMigrations = []func(front map[string]interface{}){
+	func(front map[string]interface{}) {},
+}
+
+// splitFrontMatter separates a rendered issue file into its YAML
+// front-matter block and the remaining body, both without the "---"
+// delimiters.
+func splitFrontMatter(content []byte) (front, body []byte, err error) {
+	delim := []byte("---\n")
+	if !bytes.HasPrefix(content, delim) {
+		return nil, nil, fmt.Errorf("git: issue file missing front-matter")
+	}
+	rest := content[len(delim):]
+	end := bytes.Index(rest, delim)
+	if end < 0 {
+		return nil, nil, fmt.Errorf("git: issue file front-matter not terminated")
+	}
+	return rest[:end], rest[end+len(delim):], nil
+}
+
+// migrateFrontMatter applies the outstanding migration steps to a
+// parsed front-matter map and stamps the current version, reporting
+// whether the file needs rewriting. Files already at the current
+// version are left untouched, so repeated runs cause no churn.
+func migrateFrontMatter(front map[string]interface{}) bool {
+	version, _ := front["frontMatterVersion"].(int)
+	if version >= CurrentFrontMatterVersion {
+		return false
+	}
+	for ; version < len(frontMatterMigrations); version++ {
+		frontMatterMigrations[version](front)
+	}
+	front["frontMatterVersion"] = CurrentFrontMatterVersion
+	return true
+}
+
+// MigrateRepo upgrades every issue file in the working tree still on an
+// older front-matter schema to the current version, returning how many
+// files were rewritten. Only the front-matter block is rewritten — the
+// markdown body is preserved byte for byte — and files that fail to
+// parse are skipped rather than failing the whole pass, matching how
+// the rest of the tree tolerates hand-edited files. The caller commits
+// the result.
+func (m *Manager) MigrateRepo(ctx context.Context) (int, error) {
+	migrated := 0
+	err := filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !issueFilePattern.MatchString(d.Name()) {
+			return nil
+		}
+		content, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return rerr
+		}
+		frontRaw, body, serr := splitFrontMatter(content)
+		if serr != nil {
+			return nil
+		}
+		front := map[string]interface{}{}
+		if uerr := yaml.Unmarshal(frontRaw, &front); uerr != nil {
+			return nil
+		}
+		if !migrateFrontMatter(front) {
+			return nil
+		}
+		// Note the map marshal orders keys alphabetically; the next full
+		// re-render of the issue restores the canonical struct order.
+		newFront, merr := yaml.Marshal(front)
+		if merr != nil {
+			return fmt.Errorf("git: marshal migrated front-matter for %s: %w", d.Name(), merr)
+		}
+		newContent := append(append([]byte("---\n"), newFront...), append([]byte("---\n"), body...)...)
+		if werr := os.WriteFile(path, newContent, 0o644); werr != nil {
+			return fmt.Errorf("git: write migrated %s: %w", d.Name(), werr)
+		}
+		migrated++
+		return nil
+	})
+	if err != nil {
+		return migrated, fmt.Errorf("git: migrate repository: %w", err)
+	}
+	return migrated, nil
+}
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// MirrorPushError aggregates per-remote mirror push failures. It is
+// only returned when RequireMirrors is set; otherwise failures are
+// recorded for retrieval via MirrorFailures.
+type MirrorPushError struct {
+	Failures map[string]error
+}
+
+func (e *MirrorPushError) Error() string {
+	urls := make([]string, 0, len(e.Failures))
+	for url := range e.Failures {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	parts := make([]string, len(urls))
+	for i, url := range urls {
+		parts[i] = fmt.Sprintf("%s: %v", url, e.Failures[url])
+	}
+	return fmt.Sprintf("git: %d mirror pushes failed: %s", len(urls), strings.Join(parts, "; "))
+}
+
+// pushMirrors pushes the branch to every configured mirror remote,
+// recording per-remote outcomes. Failures only fail the push when
+// RequireMirrors is set; otherwise callers surface them as warnings via
+// MirrorFailures so a backup remote outage cannot block syncs.
+func (m *Manager) pushMirrors(ctx context.Context) error {
+	if len(m.cfg.MirrorRemotes) == 0 {
+		return nil
+	}
+	failures := map[string]error{}
+	for i, url := range m.cfg.MirrorRemotes {
+		if err := m.pushMirror(ctx, fmt.Sprintf("mirror-%d", i), url); err != nil {
+			failures[url] = err
+		}
+	}
+	m.mirrorMu.Lock()
+	m.mirrorFailures = failures
+	m.mirrorMu.Unlock()
+
+	if len(failures) > 0 && m.cfg.RequireMirrors {
+		return &MirrorPushError{Failures: failures}
+	}
+	return nil
+}
+
+// pushMirror pushes the branch to one mirror, creating its remote on
+// first use.
+func (m *Manager) pushMirror(ctx context.Context, name, url string) error {
+	if _, err := m.repo.Remote(name); err != nil {
+		if _, cerr := m.repo.CreateRemote(&config.RemoteConfig{
+			Name: name,
+			URLs: []string{url},
+		}); cerr != nil {
+			return fmt.Errorf("git: configure mirror %s: %w", url, cerr)
+		}
+	}
+	start := time.Now()
+	err := m.repo.PushContext(ctx, &gogit.PushOptions{
+		RemoteName:      name,
+		Auth:            m.mirrorAuth(url),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(url),
+	})
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("git: push mirror %s: %w", url, err)
+	}
+	metrics.RecordMirrorPush(url, time.Since(start))
+	return nil
+}
+
+// mirrorAuth resolves push credentials for a mirror URL, falling back
+// to the primary remote's credentials.
+func (m *Manager) mirrorAuth(url string) transport.AuthMethod {
+	cred, ok := m.cfg.MirrorCredentials[url]
+	if !ok || cred.Token == "" {
+		return m.auth()
+	}
+	username := cred.Username
+	if username == "" {
+		username = "git"
+	}
+	return &githttp.BasicAuth{Username: username, Password: cred.Token}
+}
+
+// MirrorFailures returns the mirror pushes that failed during the most
+// recent Push, keyed by mirror URL. Empty means all mirrors succeeded.
+func (m *Manager) MirrorFailures() map[string]error {
+	m.mirrorMu.Lock()
+	defer m.mirrorMu.Unlock()
+	failures := make(map[string]error, len(m.mirrorFailures))
+	for url, err := range m.mirrorFailures {
+		failures[url] = err
+	}
+	return failures
+}
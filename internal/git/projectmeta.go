@@ -0,0 +1,30 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// projectMetaFile is the per-project schema reference file name.
+const projectMetaFile = ".project-meta.yaml"
+
+// WriteProjectMeta writes a project's rendered schema reference to
+// <project>/.project-meta.yaml, reporting whether the content changed.
+// An unchanged file is left untouched so metadata refreshes on
+// steady-state cycles never dirty the working tree or produce commits.
+func (m *Manager) WriteProjectMeta(project string, content []byte) (bool, error) {
+	dir := filepath.Join(m.cfg.LocalPath, project)
+	path := filepath.Join(dir, projectMetaFile)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return false, fmt.Errorf("git: create project directory %s: %w", project, err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return false, fmt.Errorf("git: write project metadata for %s: %w", project, err)
+	}
+	return true, nil
+}
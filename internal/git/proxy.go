@@ -0,0 +1,58 @@
+package git
+
+import (
+	"net/url"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig routes clone, fetch, and push traffic through an HTTP
+// egress proxy, for clusters whose network policy blocks direct
+// internet access. Zero values leave go-git's own handling of the
+// standard proxy environment variables in effect.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL used for plain-HTTP remotes.
+	HTTPProxy string
+	// HTTPSProxy is the proxy URL used for HTTPS remotes.
+	HTTPSProxy string
+	// NoProxy lists comma-separated hosts, domain suffixes, and CIDRs
+	// that bypass the proxy.
+	NoProxy string
+}
+
+// enabled reports whether an explicit proxy is configured.
+func (p ProxyConfig) enabled() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != ""
+}
+
+// options resolves the proxy to apply for remoteURL as go-git
+// transport proxy options, honoring NoProxy. Credentials embedded in
+// the proxy URL are split into the dedicated fields, so they never
+// appear in URLs echoed by transport errors. Non-HTTP remotes and
+// bypassed hosts get zero options.
+func (p ProxyConfig) options(remoteURL string) transport.ProxyOptions {
+	if !p.enabled() {
+		return transport.ProxyOptions{}
+	}
+	target, err := url.Parse(remoteURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		return transport.ProxyOptions{}
+	}
+	proxy, err := (&httpproxy.Config{
+		HTTPProxy:  p.HTTPProxy,
+		HTTPSProxy: p.HTTPSProxy,
+		NoProxy:    p.NoProxy,
+	}).ProxyFunc()(target)
+	if err != nil || proxy == nil {
+		return transport.ProxyOptions{}
+	}
+	opts := transport.ProxyOptions{}
+	if user := proxy.User; user != nil {
+		opts.Username = user.Username()
+		opts.Password, _ = user.Password()
+		proxy.User = nil
+	}
+	opts.URL = proxy.String()
+	return opts
+}
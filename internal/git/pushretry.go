@@ -0,0 +1,158 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// PushConflictError reports a push rejection that could not be
+// recovered by replaying local commits onto the remote head.
+type PushConflictError struct {
+	// Path is the unmanaged file whose content diverged, when one was
+	// identified.
+	Path string
+	Err  error
+}
+
+func (e *PushConflictError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("git: push conflict: unmanaged file %s diverged from remote", e.Path)
+	}
+	return fmt.Sprintf("git: push conflict: %v", e.Err)
+}
+
+func (e *PushConflictError) Unwrap() error { return e.Err }
+
+// isNonFastForward reports whether a push was rejected because the
+// remote branch advanced concurrently.
+func isNonFastForward(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "non-fast-forward")
+}
+
+// PushWithRetry pushes the local branch, recovering from concurrent
+// pushes by other operands. On a non-fast-forward rejection it fetches
+// the remote head, replays the local issue-file state on top of it, and
+// re-pushes with backoff. A replay that would touch anything other than
+// sync-managed files aborts with a PushConflictError instead of
+// guessing.
+func (m *Manager) PushWithRetry(ctx context.Context, retries *cdcerrors.RetryManager) error {
+	if retries == nil {
+		retries = cdcerrors.NewRetryManager(cdcerrors.RetryConfig{})
+	}
+	first := true
+	return retries.Do(ctx, func(ctx context.Context) error {
+		if !first {
+			metrics.RecordPushRetry()
+		}
+		first = false
+
+		err := m.Push(ctx)
+		if err == nil || !isNonFastForward(err) {
+			return err
+		}
+		if rerr := m.replayOntoRemote(ctx); rerr != nil {
+			return cdcerrors.NonRetryable(rerr)
+		}
+		return err
+	})
+}
+
+// replayOntoRemote moves the local branch to the fetched remote head
+// while keeping the working tree, so the mirror's current issue-file
+// state is recommitted on top of the concurrent remote commits. The
+// divergent local commits only ever contain derived issue files, which
+// makes them safe to regenerate this way.
+func (m *Manager) replayOntoRemote(ctx context.Context) error {
+	err := m.repo.FetchContext(ctx, &gogit.FetchOptions{
+		Auth:            m.auth(),
+		InsecureSkipTLS: m.cfg.TLS.InsecureSkipVerify,
+		CABundle:        m.cfg.TLS.CABundle,
+		ProxyOptions:    m.cfg.Proxy.options(m.cfg.URL),
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("git: fetch before replay: %w", err)
+	}
+	remoteRef, err := m.repo.Reference(plumbing.NewRemoteReferenceName("origin", m.cfg.Branch), true)
+	if err != nil {
+		return fmt.Errorf("git: resolve remote head: %w", err)
+	}
+
+	// Reuse the message of the commit being replayed so the history
+	// still says what changed.
+	message := "sync: replay concurrent updates"
+	if head, herr := m.repo.Head(); herr == nil {
+		if commit, cerr := m.repo.CommitObject(head.Hash()); cerr == nil {
+			message = strings.TrimSpace(commit.Message)
+		}
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := wt.Reset(&gogit.ResetOptions{Commit: remoteRef.Hash(), Mode: gogit.MixedReset}); err != nil {
+		return fmt.Errorf("git: reset to remote head: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	for path, st := range status {
+		if st.Worktree == gogit.Unmodified && st.Staging == gogit.Unmodified {
+			continue
+		}
+		if !isSyncManagedPath(path) {
+			return &PushConflictError{Path: path}
+		}
+	}
+
+	// Apply the configured strategy to issue files where both sides
+	// changed before recommitting.
+	conflicted, err := m.DetectConflicts()
+	if err != nil {
+		return err
+	}
+	if err := m.ResolveConflicts(conflicted); err != nil {
+		return err
+	}
+
+	if _, err := m.CommitAll(message); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isSyncManagedPath reports whether a repository-relative path is owned
+// by the sync itself and therefore safe to regenerate during a replay:
+// issue files, generated epic indexes, mirrored attachments, per-project
+// schema references, the .jiracdc bookkeeping directory, and the managed
+// .gitignore and .gitattributes. Anything else in a replay diff is a
+// genuine conflict with content the sync did not write.
+func isSyncManagedPath(path string) bool {
+	base := filepath.Base(path)
+	if issueFilePattern.MatchString(base) || epicIndexPattern.MatchString(base) {
+		return true
+	}
+	if base == projectMetaFile || base == ".gitignore" || base == ".gitattributes" {
+		return true
+	}
+	if path == ".jiracdc" || strings.HasPrefix(path, ".jiracdc/") {
+		return true
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if segment == "attachments" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package git
+
+import "testing"
+
+// TestIsSyncManagedPath verifies the replay guard allows every file the
+// sync itself writes — issue files, epic indexes, attachments, project
+// metadata, .jiracdc bookkeeping, the managed repo config — and rejects
+// everything else.
+func TestIsSyncManagedPath(t *testing.T) {
+	managed := []string{
+		"PROJ-123.md",
+		"PROJ/PROJ-123.md",
+		"PROJ/done/PROJ-123.md",
+		"PROJ/PROJ-9-index.md",
+		"PROJ/attachments/PROJ-123-design.png",
+		"PROJ/.project-meta.yaml",
+		".jiracdc/state.json",
+		".jiracdc/aliases.json",
+		".jiracdc/tmp/reconstruct.json",
+		".gitignore",
+		".gitattributes",
+	}
+	for _, path := range managed {
+		if !isSyncManagedPath(path) {
+			t.Errorf("sync-managed path %s treated as a conflict", path)
+		}
+	}
+	unmanaged := []string{
+		"README.md",
+		"PROJ/notes.md",
+		"docs/PROJ.md",
+		"Makefile",
+		"jiracdc/state.json",
+	}
+	for _, path := range unmanaged {
+		if isSyncManagedPath(path) {
+			t.Errorf("unmanaged path %s treated as sync-managed", path)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RedactionRule replaces matches of a regular expression in issue
+// content before it is written to the working tree, keeping secrets and
+// PII out of the mirrored repository.
+type RedactionRule struct {
+	// Pattern is an RE2 regular expression.
+	Pattern string
+	// Replacement is substituted for each match, supporting $1-style
+	// group references. Empty uses "[REDACTED]".
+	Replacement string
+}
+
+// redactedText is the default replacement for matched content.
+const redactedText = "[REDACTED]"
+
+// redactor is a compiled redaction rule.
+type redactor struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// builtinRedactors cover common secret shapes that should never land in
+// a mirrored repository: cloud access keys, service tokens, private key
+// blocks and credential assignments. Enabled via Config.RedactSecrets.
+var builtinRedactors = []redactor{
+	{re: regexp.MustCompile(`AKIA[0-9A-Z]{16}`), replacement: redactedText},
+	{re: regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,}`), replacement: redactedText},
+	{re: regexp.MustCompile(`xox[baprs]-[A-Za-z0-9-]{10,}`), replacement: redactedText},
+	{re: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), replacement: redactedText},
+	{re: regexp.MustCompile(`(?i)\b(password|passwd|secret|api[_-]?key|auth[_-]?token)\s*[:=]\s*\S+`), replacement: "$1: " + redactedText},
+}
+
+// redactionProbe is a benign sample of mirrored issue content, with no
+// secrets in it, used to reject patterns that would redact everything.
+const redactionProbe = `---
+key: PROJ-123
+summary: Add retry handling to the sync engine
+type: Task
+status: In Progress
+labels: [backend, reliability]
+---
+
+# PROJ-123: Add retry handling to the sync engine
+
+The engine should retry transient JIRA failures with backoff before
+surfacing an error. See https://jira.example.com/browse/PROJ-123 for
+the full discussion and the linked design document.
+`
+
+// CheckRedactionPattern reports an error when the pattern would redact
+// essentially all content rather than specific secrets: it matches the
+// empty string, or its matches cover most of a benign content sample.
+// Catch-alls like ".+" or "[\s\S]+" pass an empty-string check but
+// would still blank every mirrored file.
+func CheckRedactionPattern(re *regexp.Regexp) error {
+	if re.MatchString("") {
+		return fmt.Errorf("matches the empty string and would redact all content")
+	}
+	matched := 0
+	for _, span := range re.FindAllStringIndex(redactionProbe, -1) {
+		matched += span[1] - span[0]
+	}
+	if matched*2 > len(redactionProbe) {
+		return fmt.Errorf("matches %d%% of ordinary issue content and would redact far more than secrets", matched*100/len(redactionProbe))
+	}
+	return nil
+}
+
+// compileRedactionRules compiles the configured rules, with the
+// built-in secret patterns prepended when enabled. Rules apply in a
+// fixed order so redaction is deterministic: re-syncing an already
+// redacted issue produces the same content and no diff churn.
+func compileRedactionRules(cfg Config) ([]redactor, error) {
+	var redactors []redactor
+	if cfg.RedactSecrets {
+		redactors = append(redactors, builtinRedactors...)
+	}
+	for _, rule := range cfg.RedactionRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("git: redaction pattern %q: %w", rule.Pattern, err)
+		}
+		if err := CheckRedactionPattern(re); err != nil {
+			return nil, fmt.Errorf("git: redaction pattern %q %v", rule.Pattern, err)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = redactedText
+		}
+		redactors = append(redactors, redactor{re: re, replacement: replacement})
+	}
+	return redactors, nil
+}
+
+// redact applies the compiled redaction rules to text in order.
+func (m *Manager) redact(text string) string {
+	for _, r := range m.redactors {
+		text = r.re.ReplaceAllString(text, r.replacement)
+	}
+	return text
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Policies for handling projects removed from the sync configuration.
+const (
+	// RemovedProjectKeep leaves a removed project's files untouched.
+	RemovedProjectKeep = "keep"
+	// RemovedProjectArchive moves a removed project's files under the
+	// archive directory instead of deleting them.
+	RemovedProjectArchive = "archive"
+	// RemovedProjectDelete deletes a removed project's files outright.
+	RemovedProjectDelete = "delete"
+)
+
+// ArchiveDir is the repository subdirectory archived projects move
+// under. Files below it are excluded from orphan detection and project
+// listings, so archived data survives subsequent cleanup cycles.
+const ArchiveDir = "archive"
+
+// ListMirroredProjects returns the project keys that currently have
+// issue files in the working tree, excluding the archive directory.
+func (m *Manager) ListMirroredProjects(ctx context.Context) ([]string, error) {
+	seen := map[string]struct{}{}
+	err := m.walkIssueFiles(ctx, func(path string, key string) error {
+		if i := strings.LastIndex(key, "-"); i > 0 {
+			seen[key[:i]] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("git: list mirrored projects: %w", err)
+	}
+	projects := make([]string, 0, len(seen))
+	for project := range seen {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// ArchiveProject moves every file of a project under
+// ArchiveDir/<project>/, preserving relative paths, and returns how
+// many files moved. A project directory at the repository root is moved
+// wholesale so attachments and indexes travel with their issues.
+func (m *Manager) ArchiveProject(ctx context.Context, project string) (int, error) {
+	target := filepath.Join(m.cfg.LocalPath, ArchiveDir, project)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return 0, fmt.Errorf("git: archive project %s: %w", project, err)
+	}
+	// Namespaced layouts keep each project in a root-level directory;
+	// moving it in one rename carries every related file along.
+	projectDir := filepath.Join(m.cfg.LocalPath, project)
+	if info, err := os.Stat(projectDir); err == nil && info.IsDir() {
+		moved, cerr := countFiles(projectDir)
+		if cerr != nil {
+			return 0, fmt.Errorf("git: archive project %s: %w", project, cerr)
+		}
+		if err := os.Rename(projectDir, target); err != nil {
+			return 0, fmt.Errorf("git: archive project %s: %w", project, err)
+		}
+		return moved, nil
+	}
+	// Flat layouts move file by file.
+	moved := 0
+	err := m.walkIssueFiles(ctx, func(path string, key string) error {
+		if !strings.HasPrefix(key, project+"-") {
+			return nil
+		}
+		rel, rerr := filepath.Rel(m.cfg.LocalPath, path)
+		if rerr != nil {
+			return rerr
+		}
+		dest := filepath.Join(target, rel)
+		if mkErr := os.MkdirAll(filepath.Dir(dest), 0o755); mkErr != nil {
+			return mkErr
+		}
+		if mvErr := os.Rename(path, dest); mvErr != nil {
+			return mvErr
+		}
+		moved++
+		return nil
+	})
+	if err != nil {
+		return moved, fmt.Errorf("git: archive project %s: %w", project, err)
+	}
+	return moved, nil
+}
+
+// RemoveProject deletes every file of a project from the working tree
+// and returns how many files were removed.
+func (m *Manager) RemoveProject(ctx context.Context, project string) (int, error) {
+	projectDir := filepath.Join(m.cfg.LocalPath, project)
+	if info, err := os.Stat(projectDir); err == nil && info.IsDir() {
+		removed, cerr := countFiles(projectDir)
+		if cerr != nil {
+			return 0, fmt.Errorf("git: remove project %s: %w", project, cerr)
+		}
+		if err := os.RemoveAll(projectDir); err != nil {
+			return 0, fmt.Errorf("git: remove project %s: %w", project, err)
+		}
+		return removed, nil
+	}
+	removed := 0
+	err := m.walkIssueFiles(ctx, func(path string, key string) error {
+		if !strings.HasPrefix(key, project+"-") {
+			return nil
+		}
+		if rmErr := os.Remove(path); rmErr != nil {
+			return rmErr
+		}
+		removed++
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("git: remove project %s: %w", project, err)
+	}
+	return removed, nil
+}
+
+// walkIssueFiles visits every issue file in the working tree outside
+// .git and the archive directory, passing its path and issue key.
+func (m *Manager) walkIssueFiles(ctx context.Context, visit func(path, key string) error) error {
+	return filepath.WalkDir(m.cfg.LocalPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || path == filepath.Join(m.cfg.LocalPath, ArchiveDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		match := issueFilePattern.FindStringSubmatch(d.Name())
+		if match == nil {
+			return nil
+		}
+		return visit(path, match[1])
+	})
+}
+
+// countFiles counts regular files under dir.
+func countFiles(dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
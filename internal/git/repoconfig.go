@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimiting the managed section in .gitignore and
+// .gitattributes. Everything outside the markers belongs to the user
+// and is never touched.
+const (
+	managedBegin = "# jira-cdc: begin managed section"
+	managedEnd   = "# jira-cdc: end managed section"
+)
+
+// managedGitignore is the managed .gitignore content: operator working
+// files that must never be committed into the mirror.
+var managedGitignore = []string{
+	".jiracdc/tmp/",
+	"*.partial",
+}
+
+// managedGitattributes returns the managed .gitattributes content.
+// Issue files are always treated as text; with LFS enabled, attachment
+// blobs are routed through the LFS filter instead of bloating the
+// repository.
+func managedGitattributes(useLFS bool) []string {
+	lines := []string{"*.md text"}
+	if useLFS {
+		lines = append(lines, "attachments/** filter=lfs diff=lfs merge=lfs -text")
+	}
+	return lines
+}
+
+// EnsureRepoConfig writes the managed sections of .gitignore and
+// .gitattributes, committing them separately from issue syncs. Files
+// are only rewritten when the desired managed content differs from
+// what is on disk, so an unchanged configuration costs nothing per
+// reconcile; user edits outside the managed markers are preserved.
+func (m *Manager) EnsureRepoConfig(ctx context.Context) error {
+	files := []struct {
+		name  string
+		lines []string
+	}{
+		{".gitignore", managedGitignore},
+		{".gitattributes", managedGitattributes(m.cfg.UseLFS)},
+	}
+	changed := false
+	for _, f := range files {
+		path := filepath.Join(m.cfg.LocalPath, f.name)
+		existing := ""
+		if raw, err := os.ReadFile(path); err == nil {
+			existing = string(raw)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("git: read %s: %w", f.name, err)
+		}
+		updated := spliceManaged(existing, f.lines)
+		if updated == existing {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+			return fmt.Errorf("git: write %s: %w", f.name, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	_, err := m.CommitAll("chore: update managed .gitignore and .gitattributes")
+	return err
+}
+
+// spliceManaged replaces the managed marker section in existing with
+// the given lines, appending a new section when none exists. Content
+// outside the markers is preserved byte for byte.
+func spliceManaged(existing string, lines []string) string {
+	section := managedBegin + "\n" + strings.Join(lines, "\n") + "\n" + managedEnd + "\n"
+	begin := strings.Index(existing, managedBegin)
+	if begin < 0 {
+		if existing != "" && !strings.HasSuffix(existing, "\n") {
+			existing += "\n"
+		}
+		if existing != "" {
+			existing += "\n"
+		}
+		return existing + section
+	}
+	rest := existing[begin:]
+	end := strings.Index(rest, managedEnd)
+	if end < 0 {
+		// A damaged section without its end marker; rewrite from the
+		// begin marker down.
+		return existing[:begin] + section
+	}
+	after := rest[end+len(managedEnd):]
+	after = strings.TrimPrefix(after, "\n")
+	return existing[:begin] + section + after
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// HeadHash returns the current HEAD commit hash, or an empty string for
+// a repository with no commits yet.
+func (m *Manager) HeadHash() string {
+	if m.repo == nil {
+		return ""
+	}
+	head, err := m.repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
+
+// SquashSince collapses every local commit made after baseHash into a
+// single commit with the given message, keeping the final tree intact.
+// It only ever rewrites unpushed history: a base that the remote branch
+// has already advanced past is refused, as is a HEAD that is not on the
+// configured mirror branch. Squashing fewer than two commits is a no-op.
+func (m *Manager) SquashSince(ctx context.Context, baseHash, message string) error {
+	if m.repo == nil {
+		return fmt.Errorf("git: repository not open")
+	}
+	head, err := m.repo.Head()
+	if err != nil {
+		return fmt.Errorf("git: resolve HEAD: %w", err)
+	}
+	if head.Name() != plumbing.NewBranchReferenceName(m.cfg.Branch) {
+		return fmt.Errorf("git: refusing to squash: HEAD is on %s, not the mirror branch %s",
+			head.Name().Short(), m.cfg.Branch)
+	}
+	if head.Hash().String() == baseHash {
+		return nil
+	}
+
+	// Walk back from HEAD to the base, collecting the commits that would
+	// be squashed. A base that is not an ancestor means the caller's
+	// notion of "this run" is stale, so refuse rather than guess.
+	base := plumbing.NewHash(baseHash)
+	squashed := map[string]struct{}{}
+	for hash := head.Hash(); hash != base; {
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		commit, cerr := m.repo.CommitObject(hash)
+		if cerr != nil {
+			return fmt.Errorf("git: walk commits for squash: %w", cerr)
+		}
+		if commit.NumParents() == 0 {
+			return fmt.Errorf("git: refusing to squash: %s is not an ancestor of HEAD", baseHash)
+		}
+		squashed[hash.String()] = struct{}{}
+		hash = commit.ParentHashes[0]
+	}
+	if len(squashed) < 2 {
+		return nil
+	}
+
+	// Never rewrite history the remote already has: if the remote branch
+	// points at one of the commits being squashed, part of this run was
+	// pushed (for example by a mid-cycle batch push) and must stand.
+	remoteRef, rerr := m.repo.Reference(plumbing.NewRemoteReferenceName("origin", m.cfg.Branch), true)
+	if rerr == nil {
+		if _, pushed := squashed[remoteRef.Hash().String()]; pushed {
+			return fmt.Errorf("git: refusing to squash: remote %s already has commits from this run", m.cfg.Branch)
+		}
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := wt.Reset(&gogit.ResetOptions{Commit: base, Mode: gogit.MixedReset}); err != nil {
+		return fmt.Errorf("git: reset to squash base: %w", err)
+	}
+	hash, err := m.CommitAll(message)
+	if err != nil {
+		return err
+	}
+	m.remapSyncStateCommits(ctx, squashed, hash)
+	return nil
+}
+
+// remapSyncStateCommits points sync-state entries attributed to any of
+// the squashed commits at the replacement commit, so the state file does
+// not reference hashes the squash discarded. The state is an
+// optimization, so remap failures are dropped.
+func (m *Manager) remapSyncStateCommits(ctx context.Context, squashed map[string]struct{}, newHash string) {
+	if newHash == "" {
+		return
+	}
+	state := m.LoadSyncState(ctx)
+	changed := false
+	for key, result := range state {
+		if _, ok := squashed[result.Commit]; !ok {
+			continue
+		}
+		result.Commit = newHash
+		state[key] = result
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if content, err := json.MarshalIndent(state, "", "  "); err == nil {
+		_ = os.WriteFile(filepath.Join(m.cfg.LocalPath, syncStateFile), append(content, '\n'), 0o644)
+	}
+}
@@ -0,0 +1,301 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// syncStateFile is the repository-relative path of the per-issue sync
+// state, recording what was last mirrored so reconciles can skip issues
+// the JQL returns but that have not changed since.
+const syncStateFile = ".jiracdc/state.json"
+
+// IssueSyncResult records the last mirrored state of one issue.
+type IssueSyncResult struct {
+	// Revision is the issue's JIRA updated timestamp at sync time.
+	Revision string `json:"revision"`
+	// Commit is the commit that contained the issue's last write. Empty
+	// when the write produced no tree change.
+	Commit string `json:"commit,omitempty"`
+	// SyncedAt is when the issue was last mirrored.
+	SyncedAt time.Time `json:"syncedAt"`
+	// Hash is the content hash of the file as written by the sync, used
+	// to detect manual edits. Empty for entries recorded before hashes
+	// were tracked.
+	Hash string `json:"hash,omitempty"`
+}
+
+// LoadSyncState returns the per-issue sync state, keyed by issue key. A
+// missing state file yields an empty map; a corrupt one is rebuilt from
+// the working tree and git history rather than failing the sync.
+func (m *Manager) LoadSyncState(ctx context.Context) map[string]IssueSyncResult {
+	content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, syncStateFile))
+	if err != nil {
+		return map[string]IssueSyncResult{}
+	}
+	state := map[string]IssueSyncResult{}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return m.RebuildSyncState(ctx)
+	}
+	return state
+}
+
+// RecordSyncResults merges the given issue revisions into the state
+// file, stamping them with the containing commit and the current time.
+// The state file is written after the commit it describes, so it rides
+// into the repository with the next commit.
+func (m *Manager) RecordSyncResults(commit string, revisions map[string]string) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	state := m.LoadSyncState(context.Background())
+	now := time.Now()
+	for key, revision := range revisions {
+		result := IssueSyncResult{Revision: revision, Commit: commit, SyncedAt: now}
+		if relPath, ok := m.findIssueFile(key); ok {
+			if content, rerr := os.ReadFile(filepath.Join(m.cfg.LocalPath, relPath)); rerr == nil {
+				result.Hash = hashContent(content)
+			}
+		}
+		state[key] = result
+	}
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("git: encode %s: %w", syncStateFile, err)
+	}
+	absPath := filepath.Join(m.cfg.LocalPath, syncStateFile)
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(absPath, append(content, '\n'), 0o644); err != nil {
+		return fmt.Errorf("git: write %s: %w", syncStateFile, err)
+	}
+	return nil
+}
+
+// RebuildSyncState reconstructs the per-issue sync state from what the
+// repository already records: each issue file's front-matter supplies
+// its revision, and one walk of the commit log supplies the newest
+// commit touching each file. The rebuilt state is written back so the
+// next load is cheap again.
+func (m *Manager) RebuildSyncState(ctx context.Context) map[string]IssueSyncResult {
+	state := map[string]IssueSyncResult{}
+	_ = filepath.WalkDir(m.cfg.LocalPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		key, ok := issueKeyFromFileName(d.Name())
+		if !ok {
+			return nil
+		}
+		content, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil
+		}
+		data, perr := parseIssueFile(content)
+		if perr != nil {
+			return nil
+		}
+		state[key] = IssueSyncResult{Revision: data.Updated, Hash: hashContent(content)}
+		return nil
+	})
+	m.fillCommitsFromLog(ctx, state)
+
+	if len(state) > 0 {
+		if content, err := json.MarshalIndent(state, "", "  "); err == nil {
+			absPath := filepath.Join(m.cfg.LocalPath, syncStateFile)
+			if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err == nil {
+				_ = os.WriteFile(absPath, append(content, '\n'), 0o644)
+			}
+		}
+	}
+	return state
+}
+
+// fillCommitsFromLog walks the commit log newest-first, filling in the
+// newest commit that touched each issue's file, and stops as soon as
+// every issue is accounted for.
+func (m *Manager) fillCommitsFromLog(ctx context.Context, state map[string]IssueSyncResult) {
+	if m.repo == nil {
+		if err := m.Open(); err != nil {
+			return
+		}
+	}
+	remaining := make(map[string]struct{}, len(state))
+	for key := range state {
+		remaining[key] = struct{}{}
+	}
+	iter, err := m.repo.Log(&gogit.LogOptions{})
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	_ = iter.ForEach(func(c *object.Commit) error {
+		if len(remaining) == 0 || ctx.Err() != nil {
+			return storer.ErrStop
+		}
+		stats, serr := c.Stats()
+		if serr != nil {
+			return nil
+		}
+		for _, stat := range stats {
+			key, ok := issueKeyFromFileName(filepath.Base(stat.Name))
+			if !ok {
+				continue
+			}
+			if _, pending := remaining[key]; !pending {
+				continue
+			}
+			result := state[key]
+			result.Commit = c.Hash.String()
+			result.SyncedAt = c.Author.When
+			state[key] = result
+			delete(remaining, key)
+		}
+		return nil
+	})
+}
+
+// reconstructCheckpointFile is the repository-relative path of the
+// reconstruction checkpoint. It lives under the gitignored tmp
+// directory, so an interrupted reconstruction never rides into a
+// commit.
+const reconstructCheckpointFile = ".jiracdc/tmp/reconstruct.json"
+
+// reconstructCheckpointInterval is how many newly parsed issues pass
+// between checkpoint writes during a reconstruction.
+const reconstructCheckpointInterval = 500
+
+// ReconstructState rebuilds the per-issue sync state file from what the
+// repository already records, for mirrors populated before state
+// tracking existed: each issue file's front-matter supplies its
+// revision, its content supplies the hash, and one newest-first walk of
+// the commit log supplies the containing commit. Progress is
+// checkpointed under the gitignored tmp directory, so a cancelled
+// reconstruction resumes past the issues it already parsed instead of
+// starting over; the checkpoint is removed once the state file is
+// written. Issue files stream through one at a time, keeping memory
+// proportional to the state map rather than the repository. It returns
+// the number of issues recorded.
+func (m *Manager) ReconstructState(ctx context.Context) (int, error) {
+	state := m.loadReconstructCheckpoint()
+	sinceCheckpoint := 0
+	err := filepath.WalkDir(m.cfg.LocalPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		key, ok := issueKeyFromFileName(d.Name())
+		if !ok {
+			return nil
+		}
+		if _, done := state[key]; done {
+			return nil
+		}
+		content, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil
+		}
+		data, perr := parseIssueFile(content)
+		if perr != nil {
+			return nil
+		}
+		state[key] = IssueSyncResult{Revision: data.Updated, Hash: hashContent(content)}
+		if sinceCheckpoint++; sinceCheckpoint >= reconstructCheckpointInterval {
+			sinceCheckpoint = 0
+			m.writeReconstructCheckpoint(state)
+		}
+		return nil
+	})
+	if err != nil {
+		// Cancelled mid-walk: persist what was parsed so the next run
+		// resumes from here.
+		m.writeReconstructCheckpoint(state)
+		return 0, err
+	}
+
+	// The log walk fills commits for restored checkpoint entries too, so
+	// it is repeated in full on resume; it stops as soon as every issue
+	// is accounted for.
+	m.fillCommitsFromLog(ctx, state)
+	if cerr := ctx.Err(); cerr != nil {
+		m.writeReconstructCheckpoint(state)
+		return 0, cerr
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("git: encode %s: %w", syncStateFile, err)
+	}
+	absPath := filepath.Join(m.cfg.LocalPath, syncStateFile)
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(absPath, append(content, '\n'), 0o644); err != nil {
+		return 0, fmt.Errorf("git: write %s: %w", syncStateFile, err)
+	}
+	_ = os.Remove(filepath.Join(m.cfg.LocalPath, reconstructCheckpointFile))
+	return len(state), nil
+}
+
+// loadReconstructCheckpoint returns the partial state of an interrupted
+// reconstruction, or an empty map when none exists or it is unreadable.
+func (m *Manager) loadReconstructCheckpoint() map[string]IssueSyncResult {
+	state := map[string]IssueSyncResult{}
+	content, err := os.ReadFile(filepath.Join(m.cfg.LocalPath, reconstructCheckpointFile))
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(content, &state); err != nil {
+		return map[string]IssueSyncResult{}
+	}
+	return state
+}
+
+// writeReconstructCheckpoint persists partial reconstruction progress.
+// Checkpoint failures are ignored: the reconstruction still completes,
+// it just restarts from scratch if interrupted.
+func (m *Manager) writeReconstructCheckpoint(state map[string]IssueSyncResult) {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	absPath := filepath.Join(m.cfg.LocalPath, reconstructCheckpointFile)
+	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(absPath, content, 0o644)
+}
+
+// issueKeyFromFileName extracts the issue key from an issue file name
+// like "PROJ-123.md", reporting whether the name has that shape.
+func issueKeyFromFileName(name string) (string, bool) {
+	match := issueFilePattern.FindStringSubmatch(name)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
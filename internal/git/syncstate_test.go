@@ -0,0 +1,111 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReconstructState verifies the state file is rebuilt from committed
+// issue files with their revisions, hashes, and containing commits, and
+// that a checkpoint from an interrupted run is resumed and cleaned up.
+func TestReconstructState(t *testing.T) {
+	m := initTestRepo(t)
+	m.cfg.IsolatedWorktrees = false
+
+	keys := []string{"ALPHA-1", "ALPHA-2", "BETA-1"}
+	for _, key := range keys {
+		data := &IssueData{
+			Key:       key,
+			Project:   key[:len(key)-2],
+			Summary:   "issue " + key,
+			IssueType: "Task",
+			Status:    "To Do",
+			Updated:   "2026-01-02T03:04:05.000+0000",
+		}
+		if _, err := m.CreateIssueFile(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit, err := m.CommitAll("sync: mirror 3 issues")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An interrupted earlier run left a checkpoint covering ALPHA-1 with
+	// a marker revision; the reconstruction must keep it rather than
+	// re-parse the file.
+	m.writeReconstructCheckpoint(map[string]IssueSyncResult{
+		"ALPHA-1": {Revision: "from-checkpoint"},
+	})
+
+	count, err := m.ReconstructState(context.Background())
+	if err != nil {
+		t.Fatalf("ReconstructState: %v", err)
+	}
+	if count != len(keys) {
+		t.Errorf("reconstructed %d issues, want %d", count, len(keys))
+	}
+
+	state := m.LoadSyncState(context.Background())
+	for _, key := range keys {
+		result, ok := state[key]
+		if !ok {
+			t.Errorf("issue %s missing from reconstructed state", key)
+			continue
+		}
+		if result.Commit != commit {
+			t.Errorf("issue %s commit = %q, want %q", key, result.Commit, commit)
+		}
+		want := "2026-01-02T03:04:05.000+0000"
+		if key == "ALPHA-1" {
+			want = "from-checkpoint"
+		}
+		if result.Revision != want {
+			t.Errorf("issue %s revision = %q, want %q", key, result.Revision, want)
+		}
+		if key != "ALPHA-1" && result.Hash == "" {
+			t.Errorf("issue %s has no content hash", key)
+		}
+	}
+
+	checkpoint := filepath.Join(m.cfg.LocalPath, reconstructCheckpointFile)
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("checkpoint %s not removed after completion", reconstructCheckpointFile)
+	}
+}
+
+// TestReconstructStateCancelled verifies a cancelled reconstruction
+// persists its progress as a checkpoint instead of a state file.
+func TestReconstructStateCancelled(t *testing.T) {
+	m := initTestRepo(t)
+	m.cfg.IsolatedWorktrees = false
+
+	data := &IssueData{
+		Key:       "ALPHA-1",
+		Project:   "ALPHA",
+		Summary:   "issue",
+		IssueType: "Task",
+		Status:    "To Do",
+		Updated:   "2026-01-02T03:04:05.000+0000",
+	}
+	if _, err := m.CreateIssueFile(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.CommitAll("sync: mirror ALPHA-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.ReconstructState(ctx); err == nil {
+		t.Fatal("cancelled reconstruction returned no error")
+	}
+	if _, err := os.Stat(filepath.Join(m.cfg.LocalPath, syncStateFile)); !os.IsNotExist(err) {
+		t.Fatalf("cancelled reconstruction wrote %s", syncStateFile)
+	}
+	if _, err := os.Stat(filepath.Join(m.cfg.LocalPath, reconstructCheckpointFile)); err != nil {
+		t.Fatalf("cancelled reconstruction left no checkpoint: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFuncs are the helpers available to custom issue templates.
+var templateFuncs = template.FuncMap{
+	// join concatenates a string slice with a separator, e.g.
+	// {{join .Labels ", "}}.
+	"join": strings.Join,
+	// formatDate reformats a JIRA timestamp with a Go reference-time
+	// layout, e.g. {{formatDate .Created "2006-01-02"}}. Values that do
+	// not parse pass through unchanged.
+	"formatDate": formatTemplateDate,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+}
+
+// jiraTimestampLayouts are the formats JIRA emits for issue timestamps.
+var jiraTimestampLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02",
+}
+
+func formatTemplateDate(value, layout string) string {
+	for _, in := range jiraTimestampLayouts {
+		if t, err := time.Parse(in, value); err == nil {
+			return t.Format(layout)
+		}
+	}
+	return value
+}
+
+// ParseIssueTemplate compiles a custom issue file template. The
+// template executes with an *IssueData as its context and has the
+// templateFuncs helpers available.
+func ParseIssueTemplate(text string) (*template.Template, error) {
+	return template.New("issue").Funcs(templateFuncs).Parse(text)
+}
@@ -0,0 +1,25 @@
+package git
+
+import "strings"
+
+// AppendTrailers appends Jira-Issue and Jira-URL trailers naming the
+// given issue keys to a commit message, so tools that parse trailers
+// (git interpret-trailers, CI integrations) can link the commit back to
+// its issues. The trailer block is separated from the body by a blank
+// line and uses the Key: value form git recognizes; batched commits
+// carry one trailer pair per issue. Returns the message unchanged when
+// trailers are not configured or no keys are given.
+func (m *Manager) AppendTrailers(message string, keys ...string) string {
+	if !m.cfg.AddCommitTrailers || len(keys) == 0 {
+		return message
+	}
+	base := strings.TrimRight(m.cfg.JiraBaseURL, "/")
+	lines := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		lines = append(lines, "Jira-Issue: "+key)
+		if base != "" {
+			lines = append(lines, "Jira-URL: "+base+"/browse/"+key)
+		}
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAppendTrailers verifies the trailer block is separated from the
+// body by a blank line, uses the Key: value form git recognizes, and
+// carries one trailer pair per issue on batched commits.
+func TestAppendTrailers(t *testing.T) {
+	m, err := NewManager(Config{
+		URL:               "https://git.example.com/mirror.git",
+		AddCommitTrailers: true,
+		JiraBaseURL:       "https://jira.example.com/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := m.AppendTrailers("fix(TEST-123): status To Do -> Done", "TEST-123")
+	want := "fix(TEST-123): status To Do -> Done\n\n" +
+		"Jira-Issue: TEST-123\n" +
+		"Jira-URL: https://jira.example.com/browse/TEST-123"
+	if got != want {
+		t.Errorf("single-issue message:\n%q\nwant:\n%q", got, want)
+	}
+
+	got = m.AppendTrailers("sync: update 2 issues (TEST-1..TEST-2)", "TEST-1", "TEST-2")
+	if !strings.Contains(got, "\n\nJira-Issue: TEST-1\n") {
+		t.Errorf("batch message %q lacks a blank line before the trailer block", got)
+	}
+	for _, line := range []string{
+		"Jira-Issue: TEST-1",
+		"Jira-URL: https://jira.example.com/browse/TEST-1",
+		"Jira-Issue: TEST-2",
+		"Jira-URL: https://jira.example.com/browse/TEST-2",
+	} {
+		if !strings.Contains(got, line+"\n") && !strings.HasSuffix(got, line) {
+			t.Errorf("batch message %q lacks trailer %q", got, line)
+		}
+	}
+
+	// Without a base URL only the issue trailer is emitted.
+	noURL, err := NewManager(Config{
+		URL:               "https://git.example.com/mirror.git",
+		AddCommitTrailers: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := noURL.AppendTrailers("sync: update TEST-1", "TEST-1"); strings.Contains(got, "Jira-URL") {
+		t.Errorf("message %q carries a URL trailer without a base URL", got)
+	}
+
+	// Trailers off leaves messages untouched.
+	plain, err := NewManager(Config{URL: "https://git.example.com/mirror.git"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := plain.AppendTrailers("sync: update TEST-1", "TEST-1"); got != "sync: update TEST-1" {
+		t.Errorf("disabled trailers changed the message to %q", got)
+	}
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// worktreesDirSuffix names the directory per-project worktrees are
+// cloned into. It is a sibling of the main clone rather than a
+// subdirectory, so repository scans (orphan detection, drift checks,
+// migration) never descend into a worktree's copy of the tree.
+const worktreesDirSuffix = ".worktrees"
+
+// Worktree is an isolated clone of the managed repository scoped to one
+// project's sync, on its own sync/<project> branch. It embeds a Manager
+// over the clone, so issue writes and commits use the same layout,
+// templates and redaction as the shared tree while touching only this
+// project's private copy. A finished worktree is folded back into the
+// shared tree with MergeWorktree and discarded with Remove.
+type Worktree struct {
+	*Manager
+	project string
+	path    string
+	// base is the shared-tree commit the worktree was cloned from; the
+	// merge replays the diff from base to the worktree's head.
+	base plumbing.Hash
+}
+
+// Project returns the JIRA project the worktree is scoped to.
+func (w *Worktree) Project() string {
+	return w.project
+}
+
+// Remove deletes the worktree's clone from disk.
+func (w *Worktree) Remove() error {
+	return os.RemoveAll(w.path)
+}
+
+// Worktree creates the isolated worktree for a project, cloning the
+// shared tree and checking out a sync/<project> branch from its HEAD.
+// A stale worktree left by an interrupted run is recreated rather than
+// reused, since its base no longer matches the shared tree. Requires
+// Config.IsolatedWorktrees: each worktree costs a full copy of the
+// repository on disk, so isolation is opt-in for repositories where
+// concurrent project syncs justify it.
+func (m *Manager) Worktree(project string) (*Worktree, error) {
+	if !m.cfg.IsolatedWorktrees {
+		return nil, fmt.Errorf("git: isolated worktrees are not enabled")
+	}
+	if m.repo == nil {
+		return nil, fmt.Errorf("git: repository is not cloned")
+	}
+	root := m.worktreeRoot()
+	path := filepath.Join(root, project)
+	if err := os.RemoveAll(path); err != nil {
+		return nil, fmt.Errorf("git: remove stale worktree for %s: %w", project, err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	repo, err := gogit.PlainClone(path, false, &gogit.CloneOptions{
+		URL:           m.cfg.LocalPath,
+		ReferenceName: plumbing.NewBranchReferenceName(m.cfg.Branch),
+		SingleBranch:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("git: clone worktree for %s: %w", project, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	branchRef := plumbing.NewHashReference(
+		plumbing.NewBranchReferenceName("sync/"+project), head.Hash())
+	if err := repo.Storer.SetReference(branchRef); err != nil {
+		return nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	if err := wt.Checkout(&gogit.CheckoutOptions{Branch: branchRef.Name()}); err != nil {
+		return nil, fmt.Errorf("git: checkout %s: %w", branchRef.Name(), err)
+	}
+	cfg := m.cfg
+	cfg.LocalPath = path
+	sub, err := NewManager(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sub.repo = repo
+	return &Worktree{Manager: sub, project: project, path: path, base: head.Hash()}, nil
+}
+
+// worktreeRoot is the sibling directory holding per-project worktrees.
+func (m *Manager) worktreeRoot() string {
+	return strings.TrimSuffix(m.cfg.LocalPath, string(os.PathSeparator)) + worktreesDirSuffix
+}
+
+// MergeWorktree folds a finished worktree's changes into the shared
+// working tree as one commit, returning the commit hash or an empty
+// string when the worktree changed nothing. Projects write disjoint
+// paths, so the merge is a conflict-free replay of the worktree's diff
+// against its base; concurrent merges are serialized internally.
+func (m *Manager) MergeWorktree(w *Worktree) (string, error) {
+	m.worktreeMu.Lock()
+	defer m.worktreeMu.Unlock()
+	changes, err := w.changesSinceBase()
+	if err != nil {
+		return "", err
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+	for _, ch := range changes {
+		if ch.To.Name == "" {
+			target := filepath.Join(m.cfg.LocalPath, ch.From.Name)
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return "", fmt.Errorf("git: merge remove %s: %w", ch.From.Name, err)
+			}
+			m.pruneEmptyDirs(filepath.Dir(target))
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(w.path, ch.To.Name))
+		if err != nil {
+			return "", fmt.Errorf("git: merge read %s: %w", ch.To.Name, err)
+		}
+		target := filepath.Join(m.cfg.LocalPath, ch.To.Name)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return "", fmt.Errorf("git: merge write %s: %w", ch.To.Name, err)
+		}
+		// A rename leaves the old path only on the From side.
+		if ch.From.Name != "" && ch.From.Name != ch.To.Name {
+			old := filepath.Join(m.cfg.LocalPath, ch.From.Name)
+			if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+				return "", fmt.Errorf("git: merge remove %s: %w", ch.From.Name, err)
+			}
+			m.pruneEmptyDirs(filepath.Dir(old))
+		}
+	}
+	message := fmt.Sprintf("sync: merge %s worktree (%d files)", w.project, len(changes))
+	return m.CommitAll(message)
+}
+
+// changesSinceBase diffs the worktree's head against the shared-tree
+// commit it was cloned from.
+func (w *Worktree) changesSinceBase() (object.Changes, error) {
+	head, err := w.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head.Hash() == w.base {
+		return nil, nil
+	}
+	baseTree, err := commitTree(w.repo, w.base)
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := commitTree(w.repo, head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return object.DiffTree(baseTree, headTree)
+}
+
+// commitTree resolves a commit hash to its tree.
+func commitTree(repo *gogit.Repository, hash plumbing.Hash) (*object.Tree, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Tree()
+}
+
+// Cleanup removes transient state the manager keeps next to the clone,
+// currently the per-project worktrees. The shared working tree itself
+// is left in place.
+func (m *Manager) Cleanup() error {
+	return os.RemoveAll(m.worktreeRoot())
+}
@@ -0,0 +1,142 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// initTestRepo creates a local repository with one commit so worktrees
+// have a base to clone from, returning a Manager opened over it.
+func initTestRepo(t *testing.T) *Manager {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "repo")
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# mirror\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add("README.md"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = wt.Commit("init", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@localhost", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewManager(Config{
+		URL:               dir,
+		Branch:            "master",
+		LocalPath:         dir,
+		FileLayout:        "{project}/{key}.md",
+		IsolatedWorktrees: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// TestWorktreeConcurrentWrites verifies projects syncing through
+// isolated worktrees in parallel end up fully merged into the shared
+// tree without corrupting each other.
+func TestWorktreeConcurrentWrites(t *testing.T) {
+	m := initTestRepo(t)
+	defer func() {
+		if err := m.Cleanup(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	projects := []string{"ALPHA", "BETA", "GAMMA"}
+	const perProject = 5
+	errs := make(chan error, len(projects))
+	var wg sync.WaitGroup
+	for _, project := range projects {
+		project := project
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- syncProjectThroughWorktree(m, project, perProject)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, project := range projects {
+		for i := 0; i < perProject; i++ {
+			key := fmt.Sprintf("%s-%d", project, i+1)
+			if !m.HasIssueFile(key) {
+				t.Fatalf("issue %s missing from the merged tree", key)
+			}
+		}
+	}
+	if dirty, err := m.HasChanges(); err != nil || dirty {
+		t.Fatalf("merged tree not committed cleanly (changes=%v, err=%v)", dirty, err)
+	}
+}
+
+// syncProjectThroughWorktree mimics one project worker: write issues in
+// an isolated worktree, commit there, merge back, discard the worktree.
+func syncProjectThroughWorktree(m *Manager, project string, count int) error {
+	w, err := m.Worktree(project)
+	if err != nil {
+		return err
+	}
+	defer w.Remove()
+	for i := 0; i < count; i++ {
+		data := &IssueData{
+			Key:       fmt.Sprintf("%s-%d", project, i+1),
+			Project:   project,
+			Summary:   "issue " + project,
+			IssueType: "Task",
+			Status:    "To Do",
+		}
+		if _, err := w.CreateIssueFile(data); err != nil {
+			return err
+		}
+	}
+	if _, err := w.CommitAll(fmt.Sprintf("sync: mirror %d issues from %s", count, project)); err != nil {
+		return err
+	}
+	hash, err := m.MergeWorktree(w)
+	if err != nil {
+		return err
+	}
+	if hash == "" {
+		return fmt.Errorf("merge of %s produced no commit", project)
+	}
+	return nil
+}
+
+// TestWorktreeRequiresOptIn verifies isolation stays off unless the
+// config enables it.
+func TestWorktreeRequiresOptIn(t *testing.T) {
+	m := initTestRepo(t)
+	m.cfg.IsolatedWorktrees = false
+	if _, err := m.Worktree("ALPHA"); err == nil {
+		t.Fatal("worktree created without opt-in")
+	}
+}
@@ -0,0 +1,69 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Command runs a local executable for each event. The invocation is
+// sandboxed as far as a process boundary allows: the argv is executed
+// directly without a shell, the environment is reduced to PATH plus the
+// JIRA_CDC_* event variables, and the working directory is a throwaway
+// temp dir so the hook cannot accidentally write into the mirror clone.
+type Command struct {
+	argv []string
+}
+
+// NewCommand returns a Command hook running argv. An empty argv is
+// rejected by the admission webhook; here it simply yields a hook that
+// always errors.
+func NewCommand(argv []string) *Command {
+	return &Command{argv: append([]string(nil), argv...)}
+}
+
+// Name identifies the hook by its executable.
+func (c *Command) Name() string {
+	if len(c.argv) == 0 {
+		return "command"
+	}
+	return "command " + c.argv[0]
+}
+
+// Run executes the command with the event in its environment. The
+// context cancels the process when the delivery timeout elapses.
+func (c *Command) Run(ctx context.Context, event Event) error {
+	if len(c.argv) == 0 {
+		return fmt.Errorf("hooks: command has no argv")
+	}
+	dir, err := os.MkdirTemp("", "jira-cdc-hook-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.CommandContext(ctx, c.argv[0], c.argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"JIRA_CDC_RESOURCE=" + event.Resource,
+		"JIRA_CDC_PROJECT=" + event.Project,
+		"JIRA_CDC_ISSUE_KEY=" + event.IssueKey,
+		"JIRA_CDC_COMMIT=" + event.Commit,
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("hooks: %s: %w (output: %s)", c.argv[0], err, truncate(output, 512))
+	}
+	return nil
+}
+
+// truncate bounds captured command output so a chatty hook cannot blow
+// up error messages and events.
+func truncate(output []byte, limit int) string {
+	if len(output) <= limit {
+		return string(output)
+	}
+	return string(output[:limit]) + "..."
+}
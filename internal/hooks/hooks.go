@@ -0,0 +1,143 @@
+// Package hooks runs post-sync processing hooks — HTTP webhooks and
+// sandboxed local commands — after issues are mirrored, so downstream
+// automation such as CI pipelines or agent execution environments can
+// react to fresh context without polling the repository.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	syncpkg "sync"
+	"time"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+)
+
+// Event describes one synced unit delivered to a hook. Per-issue hooks
+// receive one event per mirrored issue; per-operation hooks receive a
+// single event with IssueKey and Project empty.
+type Event struct {
+	// Resource is the namespace/name of the owning JiraCDC resource.
+	Resource string `json:"resource,omitempty"`
+	// Project is the synced issue's JIRA project key.
+	Project string `json:"project,omitempty"`
+	// IssueKey is the synced issue. Empty for operation-scoped events.
+	IssueKey string `json:"issueKey,omitempty"`
+	// Commit is the git commit hash containing the write.
+	Commit string `json:"commit,omitempty"`
+	// Time is when the event was emitted.
+	Time time.Time `json:"time"`
+}
+
+// PostSyncHook is invoked after issues are written and committed.
+// Implementations must honour the context deadline; the runner bounds
+// every delivery so a slow hook cannot stall the sync.
+type PostSyncHook interface {
+	// Name identifies the hook in warnings and errors.
+	Name() string
+	// Run delivers one event.
+	Run(ctx context.Context, event Event) error
+}
+
+// DefaultTimeout bounds one hook delivery, including retries, when the
+// runner has no explicit timeout.
+const DefaultTimeout = 30 * time.Second
+
+// Runner fans post-sync events out to the configured hooks. Each
+// delivery is retried with backoff and bounded by Timeout. Failures are
+// recorded for retrieval via Failures and do not propagate unless
+// Require is set, mirroring how mirror push failures are handled.
+type Runner struct {
+	// Resource labels every event with the owning JiraCDC resource.
+	Resource string
+	// Issue hooks run once per synced issue; Operation hooks run once
+	// per completed sync operation.
+	Issue     []PostSyncHook
+	Operation []PostSyncHook
+	// Timeout bounds one delivery including retries. Zero uses
+	// DefaultTimeout.
+	Timeout time.Duration
+	// Require escalates hook failures to sync failures.
+	Require bool
+
+	// mu guards failures, the warnings recorded since the last Reset.
+	mu       syncpkg.Mutex
+	failures []string
+}
+
+// IssueSynced delivers one synced issue to the issue-scoped hooks. A
+// nil runner is a no-op, so callers need no configuration check.
+func (r *Runner) IssueSynced(ctx context.Context, project, issueKey, commit string) error {
+	if r == nil || len(r.Issue) == 0 {
+		return nil
+	}
+	return r.deliver(ctx, r.Issue, Event{
+		Resource: r.Resource,
+		Project:  project,
+		IssueKey: issueKey,
+		Commit:   commit,
+		Time:     time.Now(),
+	})
+}
+
+// OperationComplete delivers one completed sync operation to the
+// operation-scoped hooks.
+func (r *Runner) OperationComplete(ctx context.Context, commit string) error {
+	if r == nil || len(r.Operation) == 0 {
+		return nil
+	}
+	return r.deliver(ctx, r.Operation, Event{
+		Resource: r.Resource,
+		Commit:   commit,
+		Time:     time.Now(),
+	})
+}
+
+// deliver invokes each hook with retries under the per-delivery
+// timeout. With Require unset, a hook that exhausts its retries is
+// recorded as a warning and the remaining hooks still run.
+func (r *Runner) deliver(ctx context.Context, targets []PostSyncHook, event Event) error {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for _, hook := range targets {
+		hctx, cancel := context.WithTimeout(ctx, timeout)
+		retries := cdcerrors.NewRetryManager(cdcerrors.RetryConfig{})
+		err := retries.Do(hctx, func(ctx context.Context) error {
+			return hook.Run(ctx, event)
+		})
+		cancel()
+		if err == nil {
+			continue
+		}
+		if r.Require {
+			return fmt.Errorf("hooks: %s: %w", hook.Name(), err)
+		}
+		r.mu.Lock()
+		r.failures = append(r.failures, fmt.Sprintf("%s: %v", hook.Name(), err))
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// Reset clears recorded failures at the start of an operation.
+func (r *Runner) Reset() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.failures = nil
+	r.mu.Unlock()
+}
+
+// Failures returns the hook deliveries that failed since the last
+// Reset. Empty means every delivery succeeded.
+func (r *Runner) Failures() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.failures...)
+}
@@ -0,0 +1,103 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunnerDeliversIssueEvents verifies issue-scoped webhook hooks
+// receive one JSON event per synced issue carrying the key and commit.
+func TestRunnerDeliversIssueEvents(t *testing.T) {
+	var events []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events = append(events, event)
+	}))
+	defer server.Close()
+
+	runner := &Runner{
+		Resource: "default/test",
+		Issue:    []PostSyncHook{NewWebhook(server.URL)},
+	}
+	for _, key := range []string{"TEST-1", "TEST-2"} {
+		if err := runner.IssueSynced(context.Background(), "TEST", key, "abc123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].IssueKey != "TEST-1" || events[0].Commit != "abc123" {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+	if events[0].Resource != "default/test" || events[0].Project != "TEST" {
+		t.Errorf("event missing resource labels: %+v", events[0])
+	}
+	if failures := runner.Failures(); len(failures) != 0 {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+}
+
+// TestRunnerRecordsFailuresUnlessRequired verifies a failing hook is
+// recorded as a warning by default and escalated when Require is set.
+func TestRunnerRecordsFailuresUnlessRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	runner := &Runner{
+		Issue: []PostSyncHook{NewWebhook(server.URL)},
+		// Keep retries short: the timeout cuts the backoff loop.
+		Timeout: 50 * time.Millisecond,
+	}
+	if err := runner.OperationComplete(context.Background(), "abc123"); err != nil {
+		t.Fatalf("operation hooks ran despite none configured: %v", err)
+	}
+	if err := runner.IssueSynced(context.Background(), "TEST", "TEST-1", "abc123"); err != nil {
+		t.Fatalf("non-required hook failure escalated: %v", err)
+	}
+	if failures := runner.Failures(); len(failures) != 1 {
+		t.Fatalf("got %d recorded failures, want 1: %v", len(failures), failures)
+	}
+	runner.Reset()
+	if failures := runner.Failures(); len(failures) != 0 {
+		t.Errorf("failures survived Reset: %v", failures)
+	}
+
+	runner.Require = true
+	if err := runner.IssueSynced(context.Background(), "TEST", "TEST-1", "abc123"); err == nil {
+		t.Fatal("required hook failure did not escalate")
+	}
+}
+
+// TestCommandSandbox verifies the command hook runs without a shell,
+// sees the event in its environment and nothing else from the
+// operator's environment.
+func TestCommandSandbox(t *testing.T) {
+	runner := &Runner{
+		Issue: []PostSyncHook{NewCommand([]string{"env"})},
+	}
+	if err := runner.IssueSynced(context.Background(), "TEST", "TEST-1", "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := NewCommand([]string{"sh", "-c", "echo $JIRA_CDC_ISSUE_KEY; test -z \"$HOME\""})
+	if err := failing.Run(context.Background(), Event{IssueKey: "TEST-2"}); err != nil {
+		t.Fatalf("command saw unexpected environment: %v", err)
+	}
+
+	err := NewCommand([]string{"sh", "-c", "echo oops >&2; exit 3"}).Run(context.Background(), Event{})
+	if err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Fatalf("command failure did not carry its output: %v", err)
+	}
+}
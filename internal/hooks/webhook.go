@@ -0,0 +1,50 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// httpClient bounds individual webhook requests; the runner's delivery
+// timeout covers the retry loop around them.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Webhook posts each event as JSON to an HTTP endpoint.
+type Webhook struct {
+	url string
+}
+
+// NewWebhook returns a Webhook posting to url.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url}
+}
+
+// Name identifies the hook without echoing the URL, which may carry
+// credentials.
+func (w *Webhook) Name() string { return "webhook" }
+
+// Run posts the event body.
+func (w *Webhook) Run(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("hooks: webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+)
+
+// adfNode is one node of an Atlassian Document Format tree, the rich
+// text representation JIRA Cloud's v3 API returns for description and
+// comment bodies.
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Text    string                 `json:"text,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+}
+
+// adfMark is an inline formatting annotation on a text node.
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// adfToMarkdown renders an ADF document as Markdown, covering the node
+// types JIRA produces for issue bodies. Unknown nodes degrade to their
+// text content so no content is silently dropped.
+func adfToMarkdown(doc *adfNode) string {
+	var b strings.Builder
+	renderADFBlocks(&b, doc.Content, "")
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderADFBlocks writes block-level nodes separated by blank lines,
+// prefixing each line for nested contexts like blockquotes.
+func renderADFBlocks(b *strings.Builder, nodes []adfNode, prefix string) {
+	for i, node := range nodes {
+		if i > 0 {
+			b.WriteString(prefix + "\n")
+		}
+		renderADFBlock(b, &node, prefix)
+	}
+}
+
+func renderADFBlock(b *strings.Builder, node *adfNode, prefix string) {
+	switch node.Type {
+	case "paragraph":
+		b.WriteString(prefix + renderADFInline(node.Content) + "\n")
+	case "heading":
+		level := 1
+		if v, ok := node.Attrs["level"].(float64); ok && v >= 1 && v <= 6 {
+			level = int(v)
+		}
+		b.WriteString(prefix + strings.Repeat("#", level) + " " + renderADFInline(node.Content) + "\n")
+	case "codeBlock":
+		language, _ := node.Attrs["language"].(string)
+		b.WriteString(prefix + "```" + language + "\n")
+		for _, child := range node.Content {
+			b.WriteString(prefix + child.Text + "\n")
+		}
+		b.WriteString(prefix + "```\n")
+	case "blockquote":
+		renderADFBlocks(b, node.Content, prefix+"> ")
+	case "bulletList":
+		for _, item := range node.Content {
+			renderADFListItem(b, &item, prefix, "- ")
+		}
+	case "orderedList":
+		for i, item := range node.Content {
+			renderADFListItem(b, &item, prefix, fmt.Sprintf("%d. ", i+1))
+		}
+	case "rule":
+		b.WriteString(prefix + "---\n")
+	case "mediaGroup", "mediaSingle", "panel", "table", "expand":
+		// Containers without a clean Markdown form: render their
+		// children so the content survives, dropping the chrome.
+		renderADFBlocks(b, node.Content, prefix)
+	default:
+		if text := renderADFInline([]adfNode{*node}); text != "" {
+			b.WriteString(prefix + text + "\n")
+		}
+	}
+}
+
+// renderADFListItem renders a listItem's blocks with the marker on the
+// first line and continuation lines indented to match.
+func renderADFListItem(b *strings.Builder, item *adfNode, prefix, marker string) {
+	var inner strings.Builder
+	renderADFBlocks(&inner, item.Content, "")
+	lines := strings.Split(strings.TrimRight(inner.String(), "\n"), "\n")
+	for i, line := range lines {
+		if i == 0 {
+			b.WriteString(prefix + marker + line + "\n")
+		} else {
+			b.WriteString(prefix + strings.Repeat(" ", len(marker)) + line + "\n")
+		}
+	}
+}
+
+// renderADFInline renders inline nodes (text with marks, mentions,
+// links, breaks) to a single line of Markdown.
+func renderADFInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, node := range nodes {
+		switch node.Type {
+		case "text":
+			b.WriteString(applyADFMarks(node.Text, node.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			if text, ok := node.Attrs["text"].(string); ok {
+				b.WriteString(text)
+			} else if id, ok := node.Attrs["id"].(string); ok {
+				// No display text in the node: emit the wiki-markup
+				// form so ResolveMentions can rewrite it.
+				b.WriteString("[~accountid:" + id + "]")
+			}
+		case "emoji":
+			if text, ok := node.Attrs["text"].(string); ok {
+				b.WriteString(text)
+			} else if shortName, ok := node.Attrs["shortName"].(string); ok {
+				b.WriteString(shortName)
+			}
+		case "inlineCard":
+			if u, ok := node.Attrs["url"].(string); ok {
+				b.WriteString("<" + u + ">")
+			}
+		case "status":
+			if text, ok := node.Attrs["text"].(string); ok {
+				b.WriteString("[" + text + "]")
+			}
+		default:
+			b.WriteString(renderADFInline(node.Content))
+		}
+	}
+	return b.String()
+}
+
+// applyADFMarks wraps text in the Markdown equivalents of its marks.
+func applyADFMarks(text string, marks []adfMark) string {
+	for _, mark := range marks {
+		switch mark.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "code":
+			text = "`" + text + "`"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "link":
+			if href, ok := mark.Attrs["href"].(string); ok {
+				text = "[" + text + "](" + href + ")"
+			}
+		}
+	}
+	return text
+}
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sprint is a board sprint as returned by the JIRA agile API.
+type Sprint struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// Agile custom field types as reported by the field metadata endpoint.
+// Sprint and epic link are custom fields whose IDs differ per instance,
+// so they have to be discovered rather than hardcoded.
+const (
+	sprintFieldType   = "com.pyxis.greenhopper.jira:gh-sprint"
+	epicLinkFieldType = "com.pyxis.greenhopper.jira:gh-epic-link"
+)
+
+// fieldMeta is one entry from the field metadata endpoint.
+type fieldMeta struct {
+	ID     string `json:"id"`
+	Schema struct {
+		Custom string `json:"custom"`
+	} `json:"schema"`
+}
+
+// AgileFieldIDs discovers the instance-specific custom field IDs of the
+// sprint and epic link fields. The result is cached for the lifetime of
+// the client; an instance without agile fields yields empty IDs, not an
+// error.
+func (c *Client) AgileFieldIDs(ctx context.Context) (sprintID, epicID string, err error) {
+	c.agileMu.Lock()
+	defer c.agileMu.Unlock()
+	if c.agileResolved {
+		return c.sprintFieldID, c.epicFieldID, nil
+	}
+	var fields []fieldMeta
+	if err := c.get(ctx, c.apiPrefix+"/field", nil, &fields); err != nil {
+		return "", "", err
+	}
+	for _, f := range fields {
+		switch f.Schema.Custom {
+		case sprintFieldType:
+			c.sprintFieldID = f.ID
+		case epicLinkFieldType:
+			c.epicFieldID = f.ID
+		}
+	}
+	c.agileResolved = true
+	return c.sprintFieldID, c.epicFieldID, nil
+}
+
+// sprintPage is the response envelope of the agile sprint endpoint.
+type sprintPage struct {
+	StartAt    int      `json:"startAt"`
+	MaxResults int      `json:"maxResults"`
+	IsLast     bool     `json:"isLast"`
+	Values     []Sprint `json:"values"`
+}
+
+// GetActiveSprints lists the active sprints of a board, following
+// pagination.
+func (c *Client) GetActiveSprints(ctx context.Context, boardID int) ([]Sprint, error) {
+	var sprints []Sprint
+	startAt := 0
+	for {
+		params := url.Values{}
+		params.Set("state", "active")
+		params.Set("startAt", strconv.Itoa(startAt))
+		var page sprintPage
+		path := fmt.Sprintf("/rest/agile/1.0/board/%d/sprint", boardID)
+		if err := c.get(ctx, path, params, &page); err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return sprints, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// GetBoardIssues fetches one page of the issues on an agile board.
+// Boards select issues by filter rather than by project, so the result
+// can span several projects. The response shares the search envelope,
+// letting callers page it exactly like SearchIssues.
+func (c *Client) GetBoardIssues(ctx context.Context, boardID, startAt, maxResults int, extraFields ...string) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	params.Set("fields", strings.Join(append(append([]string{}, defaultSearchFields...), extraFields...), ","))
+
+	var result SearchResult
+	path := fmt.Sprintf("/rest/agile/1.0/board/%d/issue", boardID)
+	if err := c.get(ctx, path, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// greenhopperSprintPattern extracts attributes from the legacy sprint
+// field encoding, a toString dump like
+// "com.atlassian.greenhopper.service.sprint.Sprint@...[id=1,name=Sprint 1,state=ACTIVE,...]".
+var greenhopperSprintPattern = regexp.MustCompile(`(\w+)=([^,\]]*)`)
+
+// SprintNameFromField extracts the sprint name from a sprint custom
+// field value. Modern instances return a list of sprint objects, older
+// ones a list of greenhopper toString dumps; either way the active
+// sprint wins, falling back to the last entry. Issues with no sprint
+// yield "".
+func SprintNameFromField(raw interface{}) string {
+	items, ok := raw.([]interface{})
+	if !ok || len(items) == 0 {
+		return ""
+	}
+	var last string
+	for _, item := range items {
+		name, state := sprintAttrs(item)
+		if name == "" {
+			continue
+		}
+		if state == "active" {
+			return name
+		}
+		last = name
+	}
+	return last
+}
+
+// sprintAttrs extracts the name and normalized state from one sprint
+// field entry.
+func sprintAttrs(item interface{}) (name, state string) {
+	switch v := item.(type) {
+	case map[string]interface{}:
+		name, _ = v["name"].(string)
+		state, _ = v["state"].(string)
+	case string:
+		for _, match := range greenhopperSprintPattern.FindAllStringSubmatch(v, -1) {
+			switch match[1] {
+			case "name":
+				name = match[2]
+			case "state":
+				state = match[2]
+			}
+		}
+	}
+	switch state {
+	case "ACTIVE", "active":
+		state = "active"
+	}
+	return name, state
+}
+
+// EpicKeyFromField extracts the epic issue key from an epic link custom
+// field value. Issues with no epic yield "".
+func EpicKeyFromField(raw interface{}) string {
+	key, _ := raw.(string)
+	return key
+}
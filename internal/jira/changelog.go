@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ChangelogEntry is one field transition from an issue's history.
+type ChangelogEntry struct {
+	Field  string
+	From   string
+	To     string
+	Author string
+	At     string
+}
+
+// changelogPage mirrors both JIRA changelog shapes: the expand=changelog
+// block on an issue (Histories) and the paginated changelog endpoint
+// (Values).
+type changelogPage struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Histories  []changelogHistory `json:"histories"`
+	Values     []changelogHistory `json:"values"`
+}
+
+type changelogHistory struct {
+	Author struct {
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Created string `json:"created"`
+	Items   []struct {
+		Field      string `json:"field"`
+		FromString string `json:"fromString"`
+		ToString   string `json:"toString"`
+	} `json:"items"`
+}
+
+func (h *changelogHistory) entries() []ChangelogEntry {
+	entries := make([]ChangelogEntry, 0, len(h.Items))
+	for _, item := range h.Items {
+		entries = append(entries, ChangelogEntry{
+			Field:  item.Field,
+			From:   item.FromString,
+			To:     item.ToString,
+			Author: h.Author.DisplayName,
+			At:     h.Created,
+		})
+	}
+	return entries
+}
+
+// GetChangelog fetches the full field transition history of an issue,
+// oldest first. The first page comes via expand=changelog; larger
+// changelogs are paginated through the dedicated changelog endpoint
+// where available.
+func (c *Client) GetChangelog(ctx context.Context, issueKey string) ([]ChangelogEntry, error) {
+	params := url.Values{}
+	params.Set("expand", "changelog")
+	params.Set("fields", "none")
+	var issue struct {
+		Changelog changelogPage `json:"changelog"`
+	}
+	if err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(issueKey), params, &issue); err != nil {
+		return nil, err
+	}
+
+	var entries []ChangelogEntry
+	for i := range issue.Changelog.Histories {
+		entries = append(entries, issue.Changelog.Histories[i].entries()...)
+	}
+
+	initial := len(issue.Changelog.Histories)
+	if initial >= issue.Changelog.Total {
+		return entries, nil
+	}
+	histories, err := paginate(ctx, func(startAt int) ([]changelogHistory, int, error) {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(initial+startAt))
+		var page changelogPage
+		err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(issueKey)+"/changelog", params, &page)
+		if err != nil {
+			// Data Center instances do not serve the paginated endpoint;
+			// an empty page stops pagination, returning what the expand
+			// gave us rather than failing.
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return nil, 0, nil
+			}
+			return nil, 0, err
+		}
+		return page.Values, issue.Changelog.Total - initial, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range histories {
+		entries = append(entries, histories[i].entries()...)
+	}
+	return entries, nil
+}
+
+// GetIssueKeyHistory returns the issue's prior keys, oldest first,
+// extracted from the Key field transitions in its changelog. An issue
+// that was never moved between projects returns an empty slice.
+func (c *Client) GetIssueKeyHistory(ctx context.Context, issueKey string) ([]string, error) {
+	entries, err := c.GetChangelog(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, entry := range entries {
+		if entry.Field == "Key" && entry.From != "" {
+			keys = append(keys, entry.From)
+		}
+	}
+	return keys, nil
+}
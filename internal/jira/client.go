@@ -0,0 +1,359 @@
+package jira
+
+import (
+	"context"
+
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auth methods supported against JIRA instances.
+const (
+	// AuthMethodBasic pairs an account email with an API token, the
+	// JIRA Cloud scheme.
+	AuthMethodBasic = "basic"
+	// AuthMethodPAT sends a personal access token as a Bearer header,
+	// the JIRA Server/Data Center scheme.
+	AuthMethodPAT = "pat"
+)
+
+// Config holds connection settings for a JIRA instance.
+type Config struct {
+	// BaseURL is the root URL of the JIRA instance.
+	BaseURL string
+	// AuthMethod selects how requests authenticate: "basic" (default)
+	// or "pat".
+	AuthMethod string
+	// Email is the account used for basic auth with an API token.
+	Email string
+	// APIToken is the JIRA API token paired with Email.
+	APIToken string
+	// Token is the personal access token used with the pat method.
+	Token string
+	// Timeout bounds individual HTTP requests. Defaults to 30s.
+	Timeout time.Duration
+	// RateLimit tunes client-side throttling.
+	RateLimit RateLimitConfig
+	// RateLimiter overrides the default local limiter, e.g. with a
+	// DistributedRateLimiter shared across pods.
+	RateLimiter RateLimiter
+	// Retry tunes the transport's transparent retries of transient
+	// failures (429s, 5xx, network blips). Zero values use the default
+	// policy.
+	Retry cdcerrors.RetryConfig
+	// TLS controls certificate trust for self-hosted instances behind
+	// an internal CA.
+	TLS TLSConfig
+	// Proxy routes requests through an egress proxy. Zero values honor
+	// the standard proxy environment variables.
+	Proxy ProxyConfig
+	// APIVersion selects the REST API generation: 2 (default, wiki
+	// markup bodies) or 3 (JIRA Cloud, ADF bodies rendered to Markdown).
+	APIVersion int
+}
+
+// TLSConfig controls certificate trust for the JIRA connection.
+type TLSConfig struct {
+	// CABundle is PEM certificate data trusted in addition to the
+	// system roots. Empty uses the system roots alone.
+	CABundle []byte
+	// InsecureSkipVerify disables certificate verification entirely.
+	// Strongly discouraged; prefer CABundle.
+	InsecureSkipVerify bool
+}
+
+// httpClient builds the HTTP client for the configured TLS trust and
+// egress proxy. The default client is returned untouched when neither
+// is customized, keeping Go's standard proxy and transport behaviour.
+func (c Config) httpClient() (*http.Client, error) {
+	if len(c.TLS.CABundle) == 0 && !c.TLS.InsecureSkipVerify && !c.Proxy.enabled() {
+		return &http.Client{Timeout: c.Timeout}, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = c.Proxy.proxyFunc()
+	if len(c.TLS.CABundle) > 0 || c.TLS.InsecureSkipVerify {
+		tlsCfg := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+		}
+		if len(c.TLS.CABundle) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(c.TLS.CABundle) {
+				return nil, fmt.Errorf("jira: CA bundle contains no PEM certificates")
+			}
+			tlsCfg.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &http.Client{Timeout: c.Timeout, Transport: transport}, nil
+}
+
+// Client is a minimal JIRA REST API client covering the endpoints the
+// CDC pipeline needs.
+type Client struct {
+	baseURL    string
+	authMethod string
+	email      string
+	apiToken   string
+	token      string
+	httpClient *http.Client
+	limiter    RateLimiter
+	// apiPrefix is the versioned REST path prefix, e.g. "/rest/api/2".
+	// Rate limiting and error handling are identical across versions;
+	// only the prefix and body formats differ.
+	apiPrefix string
+
+	// Discovered agile custom field IDs, cached by AgileFieldIDs.
+	agileMu       sync.Mutex
+	agileResolved bool
+	sprintFieldID string
+	epicFieldID   string
+
+	// Mention display names resolved by ResolveMentions, keyed by
+	// account ID or username. Misses are cached as empty strings.
+	mentionMu    sync.Mutex
+	mentionNames map[string]string
+}
+
+// NewClient constructs a Client from cfg.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("jira: base URL is required")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+	switch cfg.AuthMethod {
+	case "", AuthMethodBasic, AuthMethodPAT:
+	default:
+		return nil, fmt.Errorf("jira: unknown auth method %q", cfg.AuthMethod)
+	}
+	if cfg.AuthMethod == AuthMethodPAT && cfg.Token == "" {
+		return nil, fmt.Errorf("jira: pat auth requires a token")
+	}
+	switch cfg.APIVersion {
+	case 0:
+		cfg.APIVersion = 2
+	case 2, 3:
+	default:
+		return nil, fmt.Errorf("jira: unsupported API version %d, expected 2 or 3", cfg.APIVersion)
+	}
+	limiter := cfg.RateLimiter
+	if limiter == nil {
+		limiter = NewRateLimiter(cfg.RateLimit)
+	}
+	httpClient, err := cfg.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	// Route every request through the middleware transport, so rate
+	// limiting, metrics and retries apply uniformly however a call path
+	// issues its request.
+	httpClient.Transport = NewTransport(httpClient.Transport, limiter,
+		cdcerrors.NewRetryManager(cfg.Retry))
+	return &Client{
+		baseURL:    cfg.BaseURL,
+		authMethod: cfg.AuthMethod,
+		email:      cfg.Email,
+		apiToken:   cfg.APIToken,
+		token:      cfg.Token,
+		httpClient: httpClient,
+		limiter:    limiter,
+		apiPrefix:  fmt.Sprintf("/rest/api/%d", cfg.APIVersion),
+	}, nil
+}
+
+// RateLimitStats exposes a snapshot of the client's limiter activity,
+// e.g. for adaptive batch sizing.
+func (c *Client) RateLimitStats() RateLimiterStats {
+	return c.limiter.GetStats()
+}
+
+// APIError is a non-2xx response from JIRA.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jira: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatusCode exposes the response status for error classification.
+func (e *APIError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// GetProject fetches a project by key, which doubles as a connectivity
+// and permission check.
+func (c *Client) GetProject(ctx context.Context, key string) (*Project, error) {
+	var project Project
+	if err := c.get(ctx, c.apiPrefix+"/project/"+url.PathEscape(key), nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Myself fetches the authenticated user, which is the cheapest
+// authenticated connectivity check JIRA offers. Auth failures are
+// distinguished so operators can tell a bad token (401) from a valid
+// token lacking permissions (403).
+func (c *Client) Myself(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.get(ctx, c.apiPrefix+"/myself", nil, &user); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.StatusCode {
+			case http.StatusUnauthorized:
+				return nil, fmt.Errorf("jira: credentials rejected (bad or expired token): %w", err)
+			case http.StatusForbidden:
+				return nil, fmt.Errorf("jira: credentials accepted but lack permission: %w", err)
+			}
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetIssue fetches a single issue by key.
+func (c *Client) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	var issue Issue
+	if err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(key), nil, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// GetIssueLinks fetches the links of a single issue as flattened,
+// directional references.
+func (c *Client) GetIssueLinks(ctx context.Context, issueKey string) ([]IssueLink, error) {
+	params := url.Values{}
+	params.Set("fields", "issuelinks")
+	var issue Issue
+	if err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(issueKey), params, &issue); err != nil {
+		return nil, err
+	}
+	return FlattenIssueLinks(issue.Fields.IssueLinks), nil
+}
+
+// defaultSearchFields is the set of fields the pipeline always needs.
+// Searches request only these plus any explicitly mapped custom fields,
+// keeping response payloads small.
+var defaultSearchFields = []string{
+	"summary", "description", "status", "issuetype", "priority",
+	"assignee", "reporter", "labels", "components", "created", "updated",
+	"issuelinks", "subtasks",
+}
+
+// SearchIssues runs a JQL search returning one page of results. Any
+// extraFields (e.g. mapped custom field IDs) are requested in addition
+// to the default field set.
+func (c *Client) SearchIssues(ctx context.Context, jql string, startAt, maxResults int, extraFields ...string) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	params.Set("fields", strings.Join(append(append([]string{}, defaultSearchFields...), extraFields...), ","))
+
+	var result SearchResult
+	if err := c.get(ctx, c.apiPrefix+"/search", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ValidateJQL checks a JQL query's syntax by running it with
+// maxResults=0, returning the matched issue count without fetching any
+// issue bodies. Invalid JQL surfaces as an *APIError carrying JIRA's
+// own error message.
+func (c *Client) ValidateJQL(ctx context.Context, jql string) (int, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("maxResults", "0")
+	params.Set("fields", "none")
+
+	var result SearchResult
+	if err := c.get(ctx, c.apiPrefix+"/search", params, &result); err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// DownloadAttachment fetches one attachment's content from its download
+// URL. Downloads go through the shared transport like every other JIRA
+// request, so attachment-heavy issues cannot starve the API budget.
+func (c *Client) DownloadAttachment(ctx context.Context, att Attachment) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, att.Content, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.authMethod == AuthMethodPAT {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.email != "" {
+		req.SetBasicAuth(c.email, c.apiToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jira: download attachment %s: %w", att.Filename, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("jira: read attachment %s: %w", att.Filename, err)
+	}
+	return content, nil
+}
+
+// get issues a plain GET request; rate limiting, metrics and retries
+// are applied by the client's Transport.
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	u := c.baseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authMethod == AuthMethodPAT {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.email != "" {
+		req.SetBasicAuth(c.email, c.apiToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira: request %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
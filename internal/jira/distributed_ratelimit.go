@@ -0,0 +1,233 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// CoordinationBackend shares a request budget between operand pods so
+// they collectively stay inside JIRA's rate limits. Implementations
+// must be safe for concurrent use.
+type CoordinationBackend interface {
+	// Acquire reserves one request slot for the given JIRA instance and
+	// returns how long the caller must wait before proceeding. An error
+	// means the backend is unreachable and the caller should fall back
+	// to local limiting.
+	Acquire(ctx context.Context, instance string) (time.Duration, error)
+}
+
+// DistributedRateLimiter implements RateLimiter by coordinating a
+// shared token budget across pods through a CoordinationBackend. When
+// the backend is unreachable it degrades gracefully to the wrapped
+// local limiter.
+type DistributedRateLimiter struct {
+	local    RateLimiter
+	backend  CoordinationBackend
+	instance string
+
+	mu       sync.Mutex
+	degraded bool
+}
+
+// NewDistributedRateLimiter wraps a local limiter with cross-pod
+// coordination for the given JIRA instance URL.
+func NewDistributedRateLimiter(cfg RateLimitConfig, backend CoordinationBackend, instance string) *DistributedRateLimiter {
+	return &DistributedRateLimiter{
+		local:    NewRateLimiter(cfg),
+		backend:  backend,
+		instance: instance,
+	}
+}
+
+// Wait blocks until a request slot is available in the shared budget,
+// falling back to the local limiter if coordination fails.
+func (d *DistributedRateLimiter) Wait(ctx context.Context, endpoint string) error {
+	for {
+		wait, err := d.backend.Acquire(ctx, d.instance)
+		if err != nil {
+			d.setDegraded(true, err)
+			return d.local.Wait(ctx, endpoint)
+		}
+		d.setDegraded(false, nil)
+		if wait <= 0 {
+			break
+		}
+		// A positive wait means no slot was granted: the window budget is
+		// exhausted. Sleep it out and acquire again so the request is
+		// counted against the new window instead of proceeding uncounted.
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		timer.Stop()
+	}
+	// The local limiter still runs to keep per-pod pacing smooth and to
+	// maintain request statistics.
+	return d.local.Wait(ctx, endpoint)
+}
+
+// HandleResponse delegates server rate-limit signals to the local limiter.
+func (d *DistributedRateLimiter) HandleResponse(resp *http.Response, endpoint string) {
+	d.local.HandleResponse(resp, endpoint)
+}
+
+// GetStats returns the local limiter statistics, annotated with whether
+// cross-pod coordination is currently active.
+func (d *DistributedRateLimiter) GetStats() RateLimiterStats {
+	stats := d.local.GetStats()
+	d.mu.Lock()
+	stats.Coordinated = !d.degraded
+	d.mu.Unlock()
+	return stats
+}
+
+func (d *DistributedRateLimiter) setDegraded(degraded bool, cause error) {
+	d.mu.Lock()
+	changed := d.degraded != degraded
+	d.degraded = degraded
+	d.mu.Unlock()
+	if changed && degraded {
+		log.Log.WithName("jira-ratelimit").Info(
+			"rate limit coordination backend unreachable, degrading to local limiting",
+			"instance", d.instance, "error", cause.Error())
+	}
+}
+
+// ConfigMapBackend coordinates a shared per-window request budget
+// through a ConfigMap-backed counter. It is a simple fixed-window
+// limiter: each JIRA instance gets a counter that resets every window.
+type ConfigMapBackend struct {
+	client    client.Client
+	namespace string
+	name      string
+	// BudgetPerWindow is the number of requests all pods may issue per
+	// window for one JIRA instance.
+	BudgetPerWindow int
+	// Window is the budget window length.
+	Window time.Duration
+}
+
+// NewConfigMapBackend returns a backend storing counters in the named
+// ConfigMap.
+func NewConfigMapBackend(c client.Client, namespace string, budget int, window time.Duration) *ConfigMapBackend {
+	if budget <= 0 {
+		budget = 100
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+	return &ConfigMapBackend{
+		client:          c,
+		namespace:       namespace,
+		name:            "jira-cdc-rate-limits",
+		BudgetPerWindow: budget,
+		Window:          window,
+	}
+}
+
+// Acquire implements CoordinationBackend. Pods race on the counter
+// update, so an optimistic-concurrency conflict means another pod won
+// the write; the read-modify-update is retried rather than degrading to
+// local limiting under exactly the contention coordination exists for.
+func (b *ConfigMapBackend) Acquire(ctx context.Context, instance string) (time.Duration, error) {
+	key := sanitizeInstanceKey(instance)
+
+	var wait time.Duration
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var cm corev1.ConfigMap
+		err := b.client.Get(ctx, types.NamespacedName{Namespace: b.namespace, Name: b.name}, &cm)
+		if apierrors.IsNotFound(err) {
+			cm = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: b.namespace, Name: b.name},
+			}
+			if cerr := b.client.Create(ctx, &cm); cerr != nil {
+				if !apierrors.IsAlreadyExists(cerr) {
+					return fmt.Errorf("jira: create rate limit ConfigMap: %w", cerr)
+				}
+				// Another pod created it first; re-read for its
+				// resourceVersion so the update below stays guarded.
+				if gerr := b.client.Get(ctx, types.NamespacedName{Namespace: b.namespace, Name: b.name}, &cm); gerr != nil {
+					return fmt.Errorf("jira: read rate limit ConfigMap: %w", gerr)
+				}
+			}
+		} else if err != nil {
+			return fmt.Errorf("jira: read rate limit ConfigMap: %w", err)
+		}
+
+		now := time.Now()
+		count, windowStart := parseCounter(cm.Data[key])
+		if now.Sub(windowStart) >= b.Window {
+			count, windowStart = 0, now
+		}
+
+		wait = 0
+		if count >= b.BudgetPerWindow {
+			wait = windowStart.Add(b.Window).Sub(now)
+		} else {
+			count++
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = fmt.Sprintf("%d:%d", count, windowStart.Unix())
+		return b.client.Update(ctx, &cm)
+	})
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return 0, fmt.Errorf("jira: update rate limit ConfigMap: %w", err)
+		}
+		return 0, err
+	}
+	return wait, nil
+}
+
+func parseCounter(value string) (int, time.Time) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return 0, time.Time{}
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, time.Time{}
+	}
+	unix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}
+	}
+	return count, time.Unix(unix, 0)
+}
+
+// sanitizeInstanceKey turns a JIRA base URL into a valid ConfigMap data
+// key.
+func sanitizeInstanceKey(instance string) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(instance, "https://"), "http://")
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	if key == "" {
+		key = "default"
+	}
+	return key
+}
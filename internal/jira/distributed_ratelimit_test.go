@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// TestConfigMapBackendBudget verifies the fixed-window counter: grants
+// within the budget are immediate and counted, an exhausted window
+// yields a wait without consuming the next window's budget, and an
+// expired window resets the counter.
+func TestConfigMapBackendBudget(t *testing.T) {
+	cl := fake.NewClientBuilder().Build()
+	backend := NewConfigMapBackend(cl, "default", 2, time.Minute)
+	ctx := context.Background()
+	const instance = "https://jira.example.com"
+
+	for i := 0; i < 2; i++ {
+		wait, err := backend.Acquire(ctx, instance)
+		if err != nil {
+			t.Fatalf("acquire %d: %v", i+1, err)
+		}
+		if wait != 0 {
+			t.Fatalf("acquire %d waited %v inside the budget", i+1, wait)
+		}
+	}
+
+	wait, err := backend.Acquire(ctx, instance)
+	if err != nil {
+		t.Fatalf("exhausted acquire: %v", err)
+	}
+	if wait <= 0 {
+		t.Fatal("exhausted window granted a slot without waiting")
+	}
+	// The denial must not consume budget from the next window.
+	var cm corev1.ConfigMap
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "jira-cdc-rate-limits"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	key := sanitizeInstanceKey(instance)
+	count, _ := parseCounter(cm.Data[key])
+	if count != 2 {
+		t.Errorf("counter is %d after a denied acquire, want 2", count)
+	}
+
+	// Backdate the window start; the next acquire opens a fresh window.
+	cm.Data[key] = fmt.Sprintf("2:%d", time.Now().Add(-2*time.Minute).Unix())
+	if err := cl.Update(ctx, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if wait, err = backend.Acquire(ctx, instance); err != nil || wait != 0 {
+		t.Fatalf("acquire after window expiry: wait %v, err %v", wait, err)
+	}
+}
+
+// TestConfigMapBackendRetriesConflict verifies a concurrent counter
+// update surfaces as a retry, not as a coordination failure that would
+// degrade the limiter to local-only under contention.
+func TestConfigMapBackendRetriesConflict(t *testing.T) {
+	conflicts := 2
+	cl := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if conflicts > 0 {
+				conflicts--
+				return apierrors.NewConflict(
+					schema.GroupResource{Resource: "configmaps"}, obj.GetName(),
+					fmt.Errorf("the object has been modified"))
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	backend := NewConfigMapBackend(cl, "default", 10, time.Minute)
+
+	wait, err := backend.Acquire(context.Background(), "https://jira.example.com")
+	if err != nil {
+		t.Fatalf("acquire did not absorb update conflicts: %v", err)
+	}
+	if wait != 0 {
+		t.Errorf("acquire waited %v inside the budget", wait)
+	}
+	if conflicts != 0 {
+		t.Errorf("%d injected conflicts left unconsumed", conflicts)
+	}
+}
+
+// countingBackend grants every nth acquire and records how many times
+// it was asked, so tests can prove callers re-acquire after waiting.
+type countingBackend struct {
+	acquires int
+	waits    []time.Duration
+}
+
+func (b *countingBackend) Acquire(ctx context.Context, instance string) (time.Duration, error) {
+	b.acquires++
+	if len(b.waits) == 0 {
+		return 0, nil
+	}
+	wait := b.waits[0]
+	b.waits = b.waits[1:]
+	return wait, nil
+}
+
+// TestDistributedWaitReacquiresAfterWindow verifies a caller that was
+// told to wait goes back to the backend for a counted slot instead of
+// proceeding against the new window uncounted.
+func TestDistributedWaitReacquiresAfterWindow(t *testing.T) {
+	backend := &countingBackend{waits: []time.Duration{time.Millisecond, time.Millisecond}}
+	limiter := NewDistributedRateLimiter(RateLimitConfig{RequestsPerSecond: 1000, Burst: 10}, backend, "https://jira.example.com")
+
+	if err := limiter.Wait(context.Background(), EndpointSearch); err != nil {
+		t.Fatal(err)
+	}
+	if backend.acquires != 3 {
+		t.Errorf("made %d acquires for 2 denials and 1 grant, want 3", backend.acquires)
+	}
+	if stats := limiter.GetStats(); !stats.Coordinated {
+		t.Error("limiter reports degraded after successful coordination")
+	}
+}
+
+// failingBackend simulates an unreachable coordination backend.
+type failingBackend struct{}
+
+func (failingBackend) Acquire(ctx context.Context, instance string) (time.Duration, error) {
+	return 0, fmt.Errorf("connection refused")
+}
+
+// TestDistributedWaitDegradesToLocal verifies backend failures fall
+// back to the local limiter and are reported through the stats.
+func TestDistributedWaitDegradesToLocal(t *testing.T) {
+	limiter := NewDistributedRateLimiter(RateLimitConfig{RequestsPerSecond: 1000, Burst: 10}, failingBackend{}, "https://jira.example.com")
+	if err := limiter.Wait(context.Background(), EndpointSearch); err != nil {
+		t.Fatal(err)
+	}
+	if stats := limiter.GetStats(); stats.Coordinated {
+		t.Error("limiter reports coordinated while the backend is unreachable")
+	}
+}
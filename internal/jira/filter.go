@@ -0,0 +1,39 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Filter is a saved JIRA filter. ID is a string because JIRA returns it
+// as one despite filters being addressed numerically.
+type Filter struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	JQL  string `json:"jql"`
+}
+
+// GetFilter fetches a saved filter by ID. JIRA hides filters the
+// authenticated user cannot view behind a 400 or 404 rather than a
+// plain permission error, so those responses are mapped to an explicit
+// access error operators can act on.
+func (c *Client) GetFilter(ctx context.Context, filterID int) (*Filter, error) {
+	var filter Filter
+	path := c.apiPrefix + "/filter/" + strconv.Itoa(filterID)
+	if err := c.get(ctx, path, nil, &filter); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.StatusCode {
+			case http.StatusBadRequest, http.StatusNotFound:
+				return nil, fmt.Errorf("jira: filter %d does not exist or is private to another user: %w", filterID, err)
+			case http.StatusForbidden:
+				return nil, fmt.Errorf("jira: credentials lack permission to view filter %d: %w", filterID, err)
+			}
+		}
+		return nil, err
+	}
+	return &filter, nil
+}
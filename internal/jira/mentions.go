@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches wiki-markup user mentions: [~accountid:...] on
+// Cloud, [~username] on Server/Data Center.
+var mentionPattern = regexp.MustCompile(`\[~(accountid:)?([^\]]+)\]`)
+
+// ResolveMentions rewrites [~accountid:...] and [~username] mentions in
+// rendered Markdown to @DisplayName, looking names up through the user
+// endpoint. Lookups are cached for the client's lifetime so repeated
+// mentions cost at most one request per account, and each lookup goes
+// through the shared transport like any other call, so the rate
+// limiter applies. Accounts that cannot be resolved — deleted,
+// anonymized, or invisible to the bot — degrade to @<id> rather than
+// failing the render, and the miss is cached so an unknown account is
+// queried at most once.
+func (c *Client) ResolveMentions(ctx context.Context, text string) string {
+	if !strings.Contains(text, "[~") {
+		return text
+	}
+	return mentionPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := mentionPattern.FindStringSubmatch(match)
+		id := groups[2]
+		if name := c.displayName(ctx, id, groups[1] != ""); name != "" {
+			return "@" + name
+		}
+		return "@" + id
+	})
+}
+
+// displayName resolves one user's display name, consulting the mention
+// cache first. Deactivated accounts are labeled as such; an empty
+// result means the account could not be resolved.
+func (c *Client) displayName(ctx context.Context, id string, isAccountID bool) string {
+	c.mentionMu.Lock()
+	name, cached := c.mentionNames[id]
+	c.mentionMu.Unlock()
+	if cached {
+		return name
+	}
+
+	params := url.Values{}
+	if isAccountID {
+		params.Set("accountId", id)
+	} else {
+		params.Set("username", id)
+	}
+	var user struct {
+		DisplayName string `json:"displayName"`
+		Active      bool   `json:"active"`
+	}
+	if err := c.get(ctx, c.apiPrefix+"/user", params, &user); err != nil {
+		user.DisplayName = ""
+	}
+	name = user.DisplayName
+	if name != "" && !user.Active {
+		name += " (deactivated)"
+	}
+
+	c.mentionMu.Lock()
+	if c.mentionNames == nil {
+		c.mentionNames = make(map[string]string)
+	}
+	c.mentionNames[id] = name
+	c.mentionMu.Unlock()
+	return name
+}
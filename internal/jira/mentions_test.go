@@ -0,0 +1,61 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestResolveMentions verifies mentions are rewritten to display names,
+// lookups are cached rather than repeated per mention, and accounts the
+// user endpoint cannot resolve degrade to @<id>.
+func TestResolveMentions(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Query().Get("accountId") == "5b10a2844c20165700ede21g":
+			fmt.Fprint(w, `{"displayName":"Jane Doe","active":true}`)
+		case r.URL.Query().Get("username") == "former.employee":
+			fmt.Fprint(w, `{"displayName":"Former Employee","active":false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errorMessages":["user not found"]}`)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	ctx := context.Background()
+
+	text := "Ping [~accountid:5b10a2844c20165700ede21g] and again " +
+		"[~accountid:5b10a2844c20165700ede21g], plus [~former.employee]."
+	got := client.ResolveMentions(ctx, text)
+	want := "Ping @Jane Doe and again @Jane Doe, plus @Former Employee (deactivated)."
+	if got != want {
+		t.Errorf("resolved text %q, want %q", got, want)
+	}
+	if requests != 2 {
+		t.Errorf("made %d lookups for 2 distinct accounts, want 2", requests)
+	}
+
+	// Unknown accounts degrade to the raw id, and the miss is cached.
+	got = client.ResolveMentions(ctx, "See [~accountid:gone] and [~accountid:gone].")
+	if want := "See @gone and @gone."; got != want {
+		t.Errorf("resolved text %q, want %q", got, want)
+	}
+	if requests != 3 {
+		t.Errorf("made %d lookups in total, want 3 (miss not cached)", requests)
+	}
+
+	// Text without mentions never touches the API.
+	before := requests
+	if got := client.ResolveMentions(ctx, "no mentions here"); got != "no mentions here" {
+		t.Errorf("mention-free text changed to %q", got)
+	}
+	if requests != before {
+		t.Error("mention-free text triggered a lookup")
+	}
+}
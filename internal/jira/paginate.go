@@ -0,0 +1,28 @@
+package jira
+
+import "context"
+
+// paginate drives a startAt/total page loop over fetchPage, collecting
+// every item. fetchPage receives the offset of the next unfetched item
+// and reports the page's items alongside the endpoint's declared total;
+// iteration stops once the total is reached or a page comes back empty,
+// which guards against endpoints that over-report totals. Cancellation
+// is checked between pages, and each page is one request through the
+// client's shared transport, so the rate limiter applies per page like
+// any other call.
+func paginate[T any](ctx context.Context, fetchPage func(startAt int) (items []T, total int, err error)) ([]T, error) {
+	var all []T
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, total, err := fetchPage(len(all))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
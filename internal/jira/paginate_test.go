@@ -0,0 +1,125 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestClient returns a client pointed at a test server.
+func newTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client, err := NewClient(Config{
+		BaseURL:  url,
+		Email:    "bot@example.com",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// TestGetWorklogsPaginates verifies worklogs spanning several pages are
+// collected completely and in order.
+func TestGetWorklogsPaginates(t *testing.T) {
+	entries := []string{"alice", "bob", "carol"}
+	const pageSize = 2
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		end := startAt + pageSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		page := `[`
+		for i, author := range entries[startAt:end] {
+			if i > 0 {
+				page += ","
+			}
+			page += fmt.Sprintf(`{"author":{"displayName":%q},"timeSpentSeconds":60}`, author)
+		}
+		page += `]`
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":%d,"total":%d,"worklogs":%s}`,
+			startAt, pageSize, len(entries), page)
+	}))
+	defer server.Close()
+
+	worklogs, err := newTestClient(t, server.URL).GetWorklogs(context.Background(), "TEST-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worklogs) != len(entries) {
+		t.Fatalf("got %d worklogs, want %d", len(worklogs), len(entries))
+	}
+	for i, author := range entries {
+		if worklogs[i].Author != author {
+			t.Errorf("worklog %d author = %q, want %q", i, worklogs[i].Author, author)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d page requests, want 2", requests)
+	}
+}
+
+// TestBulkFetchPaginates verifies a bulk fetch follows the search
+// envelope across pages when the server returns fewer issues per page
+// than requested.
+func TestBulkFetchPaginates(t *testing.T) {
+	keys := []string{"TEST-1", "TEST-2", "TEST-3"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		end := startAt + 2
+		if end > len(keys) {
+			end = len(keys)
+		}
+		page := `[`
+		for i, key := range keys[startAt:end] {
+			if i > 0 {
+				page += ","
+			}
+			page += fmt.Sprintf(`{"key":%q,"fields":{"summary":"issue"}}`, key)
+		}
+		page += `]`
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":2,"total":%d,"issues":%s}`,
+			startAt, len(keys), page)
+	}))
+	defer server.Close()
+
+	issues, err := newTestClient(t, server.URL).BulkFetch(context.Background(), keys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != len(keys) {
+		t.Fatalf("got %d issues, want %d", len(issues), len(keys))
+	}
+	for i, key := range keys {
+		if issues[i].Key != key {
+			t.Errorf("issue %d = %q, want %q", i, issues[i].Key, key)
+		}
+	}
+}
+
+// TestPaginateStopsOnCancellation verifies cancellation between pages
+// stops the loop instead of fetching the remaining pages.
+func TestPaginateStopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := paginate(ctx, func(startAt int) ([]int, int, error) {
+		calls++
+		cancel()
+		return []int{startAt}, 10, nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("fetchPage ran %d times after cancellation, want 1", calls)
+	}
+}
@@ -0,0 +1,118 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// ProjectMeta describes a project's schema: the statuses, issue types,
+// priorities and components its issues can carry. Consumers use it as a
+// stable reference for interpreting mirrored issue files.
+type ProjectMeta struct {
+	Statuses   []string `yaml:"statuses,omitempty"`
+	IssueTypes []string `yaml:"issueTypes,omitempty"`
+	Priorities []string `yaml:"priorities,omitempty"`
+	Components []string `yaml:"components,omitempty"`
+	// Notes records sections the credentials could not read, so a
+	// partial file explains its own gaps.
+	Notes []string `yaml:"notes,omitempty"`
+}
+
+// wireProjectDetail is the subset of the project endpoint response the
+// metadata fetch consumes.
+type wireProjectDetail struct {
+	IssueTypes []struct {
+		Name string `json:"name"`
+	} `json:"issueTypes"`
+	Components []struct {
+		Name string `json:"name"`
+	} `json:"components"`
+}
+
+// wireTypeStatuses is one entry of the per-issue-type statuses response.
+type wireTypeStatuses struct {
+	Name     string `json:"name"`
+	Statuses []struct {
+		Name string `json:"name"`
+	} `json:"statuses"`
+}
+
+// GetProjectMetadata fetches a project's schema: issue types and
+// components from the project endpoint, statuses from the per-type
+// statuses endpoint, and the instance-wide priorities. A section the
+// credentials cannot read is skipped with an explanatory note rather
+// than failing the whole fetch; any other error aborts it.
+func (c *Client) GetProjectMetadata(ctx context.Context, projectKey string) (ProjectMeta, error) {
+	var meta ProjectMeta
+
+	var detail wireProjectDetail
+	err := c.get(ctx, c.apiPrefix+"/project/"+url.PathEscape(projectKey), nil, &detail)
+	switch {
+	case isPermissionError(err):
+		meta.Notes = append(meta.Notes, "issue types and components omitted: "+err.Error())
+	case err != nil:
+		return ProjectMeta{}, err
+	default:
+		for _, it := range detail.IssueTypes {
+			meta.IssueTypes = append(meta.IssueTypes, it.Name)
+		}
+		for _, comp := range detail.Components {
+			meta.Components = append(meta.Components, comp.Name)
+		}
+	}
+
+	var perType []wireTypeStatuses
+	err = c.get(ctx, c.apiPrefix+"/project/"+url.PathEscape(projectKey)+"/statuses", nil, &perType)
+	switch {
+	case isPermissionError(err):
+		meta.Notes = append(meta.Notes, "statuses omitted: "+err.Error())
+	case err != nil:
+		return ProjectMeta{}, err
+	default:
+		seen := map[string]bool{}
+		for _, ts := range perType {
+			for _, status := range ts.Statuses {
+				if !seen[status.Name] {
+					seen[status.Name] = true
+					meta.Statuses = append(meta.Statuses, status.Name)
+				}
+			}
+		}
+	}
+
+	var priorities []struct {
+		Name string `json:"name"`
+	}
+	err = c.get(ctx, c.apiPrefix+"/priority", nil, &priorities)
+	switch {
+	case isPermissionError(err):
+		meta.Notes = append(meta.Notes, "priorities omitted: "+err.Error())
+	case err != nil:
+		return ProjectMeta{}, err
+	default:
+		for _, p := range priorities {
+			meta.Priorities = append(meta.Priorities, p.Name)
+		}
+	}
+
+	// Sort each section so repeated fetches of unchanged metadata render
+	// byte-identical files.
+	sort.Strings(meta.Statuses)
+	sort.Strings(meta.IssueTypes)
+	sort.Strings(meta.Priorities)
+	sort.Strings(meta.Components)
+	return meta, nil
+}
+
+// isPermissionError reports whether an error is a JIRA 401/403, i.e. the
+// credentials lack access rather than the request being wrong.
+func isPermissionError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
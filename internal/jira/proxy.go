@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig routes JIRA requests through an HTTP egress proxy, for
+// clusters whose network policy blocks direct internet access. Zero
+// values fall back to the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment variables, which the default transport already honors.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy URL used for plain-HTTP requests.
+	HTTPProxy string
+	// HTTPSProxy is the proxy URL used for HTTPS requests.
+	HTTPSProxy string
+	// NoProxy lists comma-separated hosts, domain suffixes, and CIDRs
+	// that bypass the proxy.
+	NoProxy string
+}
+
+// enabled reports whether an explicit proxy is configured.
+func (p ProxyConfig) enabled() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != ""
+}
+
+// proxyFunc builds the transport's proxy callback: the configured
+// proxies with NoProxy exceptions when set, otherwise the process
+// environment.
+func (p ProxyConfig) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if !p.enabled() {
+		return http.ProxyFromEnvironment
+	}
+	proxy := (&httpproxy.Config{
+		HTTPProxy:  p.HTTPProxy,
+		HTTPSProxy: p.HTTPSProxy,
+		NoProxy:    p.NoProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// RedactURL strips credentials from a URL for log and warning text, so
+// a proxy URL carrying basic-auth credentials can be echoed safely.
+// Strings that do not parse as URLs are masked conservatively.
+func RedactURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil {
+		if u.User == nil {
+			return raw
+		}
+		u.User = url.User("xxxxx")
+		return u.String()
+	}
+	if at := strings.LastIndex(raw, "@"); at >= 0 {
+		if scheme := strings.Index(raw, "://"); scheme >= 0 && scheme+3 < at {
+			return raw[:scheme+3] + "xxxxx" + raw[at:]
+		}
+	}
+	return raw
+}
@@ -0,0 +1,238 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Endpoint labels used to select per-endpoint rate limit buckets.
+const (
+	EndpointSearch     = "search"
+	EndpointIssue      = "issue"
+	EndpointProject    = "project"
+	EndpointMyself     = "myself"
+	EndpointField      = "field"
+	EndpointAgile      = "agile"
+	EndpointAttachment = "attachment"
+	EndpointFilter     = "filter"
+)
+
+// RateLimitConfig tunes client-side throttling of JIRA API calls.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained request rate allowed against JIRA.
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests that may be issued at once.
+	Burst int
+	// PerEndpoint optionally overrides the sustained rate for specific
+	// endpoint labels (EndpointSearch, EndpointIssue, ...). Endpoints
+	// without an entry share the global bucket.
+	PerEndpoint map[string]float64
+}
+
+// DefaultRateLimitConfig is a conservative default suitable for JIRA Cloud.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{RequestsPerSecond: 5, Burst: 10}
+}
+
+// EndpointStats is limiter activity for a single endpoint bucket.
+type EndpointStats struct {
+	Requests      int64
+	Throttled     int64
+	TotalWait     time.Duration
+	LastRateLimit time.Time
+	// Remaining is the most recent X-RateLimit-Remaining value reported
+	// by the server for this endpoint, or -1 if never seen.
+	Remaining int64
+	// LastServerReset is the most recent server-reported end of the
+	// current rate-limit window for this endpoint, zero if never seen.
+	LastServerReset time.Time
+	// ServerLimitHits counts 429 responses the server returned for this
+	// endpoint.
+	ServerLimitHits int64
+}
+
+// RateLimiterStats is a point-in-time snapshot of limiter activity.
+type RateLimiterStats struct {
+	Requests      int64
+	Throttled     int64
+	TotalWait     time.Duration
+	LastRateLimit time.Time
+	// LastServerReset is the most recent server-reported end of the
+	// current rate-limit window, zero if never seen. Operations finding
+	// the remaining budget insufficient can delay until this time.
+	LastServerReset time.Time
+	// ServerLimitHits counts 429 responses received from the server,
+	// across all endpoints.
+	ServerLimitHits int64
+	// BurstTokens is the number of tokens currently available in the
+	// global bucket, i.e. how many requests could be issued immediately
+	// without throttling.
+	BurstTokens float64
+	// EffectiveLimit is the sustained requests-per-second rate the global
+	// bucket currently enforces.
+	EffectiveLimit float64
+	// Coordinated reports whether the limiter is currently sharing its
+	// budget with other pods (false for purely local limiters and for
+	// distributed limiters running degraded).
+	Coordinated bool
+	// PerEndpoint breaks activity down by endpoint label.
+	PerEndpoint map[string]EndpointStats
+}
+
+// RateLimiter gates outbound JIRA requests and reacts to server-side
+// rate-limit signals.
+type RateLimiter interface {
+	// Wait blocks until a request to the labelled endpoint may proceed
+	// or the context is cancelled.
+	Wait(ctx context.Context, endpoint string) error
+	// HandleResponse inspects a JIRA response for rate-limit headers and
+	// records them against the labelled endpoint.
+	HandleResponse(resp *http.Response, endpoint string)
+	// GetStats returns a snapshot of limiter activity.
+	GetStats() RateLimiterStats
+}
+
+type rateLimiter struct {
+	global    *rate.Limiter
+	endpoints map[string]*rate.Limiter
+
+	mu            sync.Mutex
+	stats         RateLimiterStats
+	endpointStats map[string]*EndpointStats
+}
+
+// NewRateLimiter returns a token-bucket RateLimiter for the given
+// config, with dedicated buckets for endpoints listed in PerEndpoint.
+func NewRateLimiter(cfg RateLimitConfig) RateLimiter {
+	if cfg.RequestsPerSecond <= 0 {
+		perEndpoint := cfg.PerEndpoint
+		cfg = DefaultRateLimitConfig()
+		cfg.PerEndpoint = perEndpoint
+	}
+	endpoints := make(map[string]*rate.Limiter, len(cfg.PerEndpoint))
+	for endpoint, rps := range cfg.PerEndpoint {
+		if rps <= 0 {
+			continue
+		}
+		burst := int(rps)
+		if burst < 1 {
+			burst = 1
+		}
+		endpoints[endpoint] = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+	return &rateLimiter{
+		global:        rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst),
+		endpoints:     endpoints,
+		endpointStats: map[string]*EndpointStats{},
+	}
+}
+
+// bucket returns the limiter for an endpoint, falling back to the
+// global bucket when the endpoint has no dedicated configuration.
+func (r *rateLimiter) bucket(endpoint string) *rate.Limiter {
+	if l, ok := r.endpoints[endpoint]; ok {
+		return l
+	}
+	return r.global
+}
+
+func (r *rateLimiter) endpointEntry(endpoint string) *EndpointStats {
+	es, ok := r.endpointStats[endpoint]
+	if !ok {
+		es = &EndpointStats{Remaining: -1}
+		r.endpointStats[endpoint] = es
+	}
+	return es
+}
+
+func (r *rateLimiter) Wait(ctx context.Context, endpoint string) error {
+	start := time.Now()
+	if err := r.bucket(endpoint).Wait(ctx); err != nil {
+		return err
+	}
+	waited := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Requests++
+	es := r.endpointEntry(endpoint)
+	es.Requests++
+	if waited > time.Millisecond {
+		r.stats.Throttled++
+		r.stats.TotalWait += waited
+		es.Throttled++
+		es.TotalWait += waited
+	}
+	return nil
+}
+
+func (r *rateLimiter) HandleResponse(resp *http.Response, endpoint string) {
+	if resp == nil {
+		return
+	}
+	r.mu.Lock()
+	es := r.endpointEntry(endpoint)
+	if remaining, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Remaining"), 10, 64); err == nil {
+		es.Remaining = remaining
+	}
+	if reset := parseResetTime(resp.Header.Get("X-RateLimit-Reset"), time.Now()); !reset.IsZero() {
+		r.stats.LastServerReset = reset
+		es.LastServerReset = reset
+	}
+	rateLimited := resp.StatusCode == http.StatusTooManyRequests
+	if rateLimited {
+		now := time.Now()
+		r.stats.LastRateLimit = now
+		r.stats.ServerLimitHits++
+		es.LastRateLimit = now
+		es.ServerLimitHits++
+	}
+	r.mu.Unlock()
+
+	if rateLimited {
+		// Honour Retry-After by draining the endpoint's bucket.
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			bucket := r.bucket(endpoint)
+			bucket.ReserveN(time.Now(), bucket.Burst())
+		}
+	}
+}
+
+// parseResetTime interprets an X-RateLimit-Reset header, which servers
+// send either as an absolute timestamp (epoch seconds or RFC3339) or as
+// seconds remaining in the current window.
+func parseResetTime(value string, now time.Time) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		// Small values are a delta; anything resembling an epoch is
+		// absolute.
+		if secs < 1e6 {
+			return now.Add(time.Duration(secs) * time.Second)
+		}
+		return time.Unix(secs, 0)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+func (r *rateLimiter) GetStats() RateLimiterStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats
+	stats.BurstTokens = r.global.Tokens()
+	stats.EffectiveLimit = float64(r.global.Limit())
+	stats.PerEndpoint = make(map[string]EndpointStats, len(r.endpointStats))
+	for endpoint, es := range r.endpointStats {
+		stats.PerEndpoint[endpoint] = *es
+	}
+	return stats
+}
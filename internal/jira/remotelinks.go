@@ -0,0 +1,46 @@
+package jira
+
+import (
+	"context"
+	"net/url"
+)
+
+// RemoteLink is one remote issue link: a pointer to a URL outside the
+// issue's own instance, such as a Confluence page, a web resource, or an
+// issue in another JIRA instance.
+type RemoteLink struct {
+	// Title is the human-readable link title.
+	Title string
+	// URL is the link target.
+	URL string
+}
+
+// wireRemoteLink is one entry of the remote link endpoint's response,
+// which is a plain array rather than a paginated envelope.
+type wireRemoteLink struct {
+	Object struct {
+		URL   string `json:"url"`
+		Title string `json:"title"`
+	} `json:"object"`
+}
+
+// GetRemoteLinks fetches an issue's remote links. An issue without
+// remote links returns an empty slice; instances with remote linking
+// disabled return an error the caller is expected to tolerate.
+func (c *Client) GetRemoteLinks(ctx context.Context, issueKey string) ([]RemoteLink, error) {
+	var entries []wireRemoteLink
+	if err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(issueKey)+"/remotelink", nil, &entries); err != nil {
+		return nil, err
+	}
+	links := make([]RemoteLink, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Object.URL == "" {
+			continue
+		}
+		links = append(links, RemoteLink{
+			Title: entry.Object.Title,
+			URL:   entry.Object.URL,
+		})
+	}
+	return links, nil
+}
@@ -0,0 +1,44 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestGetRemoteLinks verifies remote links are mapped from the wire
+// form and entries without a URL are dropped.
+func TestGetRemoteLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1/remotelink" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"object":{"url":"https://wiki.example.com/page","title":"Design doc"}},
+			{"object":{"url":"https://other.example.com/browse/OTH-9","title":"OTH-9"}},
+			{"object":{"title":"no url"}}
+		]`)
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	links, err := client.GetRemoteLinks(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("GetRemoteLinks: %v", err)
+	}
+	want := []RemoteLink{
+		{Title: "Design doc", URL: "https://wiki.example.com/page"},
+		{Title: "OTH-9", URL: "https://other.example.com/browse/OTH-9"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("got %d links, want %d", len(links), len(want))
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, links[i], want[i])
+		}
+	}
+}
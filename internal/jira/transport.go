@@ -0,0 +1,101 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// EndpointOther labels requests whose path matches no known endpoint.
+const EndpointOther = "other"
+
+// Transport is an http.RoundTripper applying the client's cross-cutting
+// concerns to every outbound request: rate limiting before the call,
+// server rate-limit signal handling and metrics after it, and
+// exponential-backoff retries of transient failures. Centralizing them
+// in the transport means no call path can forget rate limiting or
+// metrics, however the request is issued.
+type Transport struct {
+	base    http.RoundTripper
+	limiter RateLimiter
+	retries *cdcerrors.RetryManager
+}
+
+// NewTransport wraps base with the client middleware. A nil base uses
+// http.DefaultTransport.
+func NewTransport(base http.RoundTripper, limiter RateLimiter, retries *cdcerrors.RetryManager) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base, limiter: limiter, retries: retries}
+}
+
+// RoundTrip issues the request through the rate limiter, records
+// per-endpoint metrics, and retries transient failures. Requests are
+// cloned per attempt; every request the client issues is a bodyless
+// GET, so replaying is always safe.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointForPath(req.URL.Path)
+	var resp *http.Response
+	err := t.retries.Do(req.Context(), func(ctx context.Context) error {
+		if err := t.limiter.Wait(ctx, endpoint); err != nil {
+			return err
+		}
+		start := time.Now()
+		r, err := t.base.RoundTrip(req.Clone(ctx))
+		if err != nil {
+			metrics.RecordJiraAPIRequest(endpoint, "error", time.Since(start))
+			return err
+		}
+		t.limiter.HandleResponse(r, endpoint)
+		metrics.RecordJiraAPIRequest(endpoint, strconv.Itoa(r.StatusCode), time.Since(start))
+		if r.StatusCode == http.StatusTooManyRequests {
+			metrics.RecordJiraAPIRateLimit(endpoint)
+		}
+		// Transient statuses become errors so the retry loop backs off
+		// and replays; everything else — success or a definite failure —
+		// passes through for the caller to interpret.
+		if r.StatusCode == http.StatusTooManyRequests || r.StatusCode >= 500 {
+			body, _ := io.ReadAll(io.LimitReader(r.Body, 2048))
+			r.Body.Close()
+			return &APIError{StatusCode: r.StatusCode, Body: string(body)}
+		}
+		resp = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// endpointForPath derives the rate-limit endpoint label from a request
+// path, mirroring the labels callers historically passed by hand.
+func endpointForPath(path string) string {
+	switch {
+	case strings.Contains(path, "/rest/agile/"):
+		return EndpointAgile
+	case strings.Contains(path, "/attachment"):
+		return EndpointAttachment
+	case strings.Contains(path, "/search"):
+		return EndpointSearch
+	case strings.Contains(path, "/issue/"):
+		return EndpointIssue
+	case strings.Contains(path, "/project"):
+		return EndpointProject
+	case strings.Contains(path, "/myself"):
+		return EndpointMyself
+	case strings.Contains(path, "/filter/"):
+		return EndpointFilter
+	case strings.Contains(path, "/field"), strings.Contains(path, "/priority"):
+		return EndpointField
+	default:
+		return EndpointOther
+	}
+}
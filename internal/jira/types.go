@@ -0,0 +1,223 @@
+package jira
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Issue is a JIRA issue as returned by the REST API, limited to the
+// fields the CDC pipeline consumes.
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Self   string      `json:"self"`
+	Fields IssueFields `json:"fields"`
+}
+
+// IssueFields holds the subset of JIRA issue fields that are mirrored
+// into git.
+type IssueFields struct {
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Status      Status      `json:"status"`
+	IssueType   IssueType   `json:"issuetype"`
+	Priority    *Priority   `json:"priority,omitempty"`
+	Assignee    *User       `json:"assignee,omitempty"`
+	Reporter    *User       `json:"reporter,omitempty"`
+	Labels      []string    `json:"labels,omitempty"`
+	Components  []Component `json:"components,omitempty"`
+	Created     string      `json:"created"`
+	Updated     string      `json:"updated"`
+
+	IssueLinks []RawIssueLink `json:"issuelinks,omitempty"`
+	Subtasks   []IssueRef     `json:"subtasks,omitempty"`
+
+	// Attachment lists the issue's file attachments. Present only when
+	// the attachment field was requested.
+	Attachment []Attachment `json:"attachment,omitempty"`
+
+	// TimeTracking holds the issue's aggregated time-tracking figures.
+	// Nil when the field was not requested or the project does not track
+	// time.
+	TimeTracking *TimeTracking `json:"timetracking,omitempty"`
+
+	// Custom holds the raw values of any "customfield_*" entries present
+	// in the response, keyed by field ID. Only fields explicitly
+	// requested via a field mapping appear here.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes the typed fields and additionally captures any
+// custom field values into Custom. The description is accepted both as
+// plain text (v2 API) and as an ADF document (v3 API), which is
+// rendered to Markdown.
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type plain IssueFields
+	var typed struct {
+		plain
+		Description json.RawMessage `json:"description"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	*f = IssueFields(typed.plain)
+	description, err := decodeBody(typed.Description)
+	if err != nil {
+		return err
+	}
+	f.Description = description
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for id, value := range raw {
+		if !strings.HasPrefix(id, "customfield_") || string(value) == "null" {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(value, &v); err != nil {
+			continue
+		}
+		if f.Custom == nil {
+			f.Custom = make(map[string]interface{})
+		}
+		f.Custom[id] = v
+	}
+	return nil
+}
+
+// decodeBody decodes a body field that is either a JSON string (the v2
+// wire format) or an ADF document object (the v3 wire format).
+func decodeBody(data []byte) (string, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || trimmed == "null" {
+		return "", nil
+	}
+	if trimmed[0] == '{' {
+		var doc adfNode
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return "", err
+		}
+		return adfToMarkdown(&doc), nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// TimeTracking is the aggregated time-tracking block of an issue.
+type TimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate,omitempty"`
+	RemainingEstimate string `json:"remainingEstimate,omitempty"`
+	TimeSpent         string `json:"timeSpent,omitempty"`
+}
+
+// RawIssueLink is the wire form of an entry in a JIRA issue's
+// "issuelinks" field. Exactly one of InwardIssue/OutwardIssue is set.
+type RawIssueLink struct {
+	Type         IssueLinkType `json:"type"`
+	InwardIssue  *IssueRef     `json:"inwardIssue,omitempty"`
+	OutwardIssue *IssueRef     `json:"outwardIssue,omitempty"`
+}
+
+// IssueLinkType names a link relationship from both directions, e.g.
+// "blocks" / "is blocked by".
+type IssueLinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// IssueRef is a lightweight reference to another issue.
+type IssueRef struct {
+	ID  string `json:"id,omitempty"`
+	Key string `json:"key"`
+}
+
+// Attachment is a file attached to an issue.
+type Attachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	// Content is the authenticated download URL for the file.
+	Content string `json:"content"`
+}
+
+// IssueLink is a flattened, directional view of a link from one issue
+// to another.
+type IssueLink struct {
+	// Type is the directional relationship as seen from the source
+	// issue, e.g. "blocks" or "is blocked by".
+	Type string
+	// Key is the linked issue's key.
+	Key string
+}
+
+// FlattenIssueLinks converts raw issuelinks entries into directional
+// IssueLink values as seen from the issue that owns them.
+func FlattenIssueLinks(raw []RawIssueLink) []IssueLink {
+	var links []IssueLink
+	for _, rl := range raw {
+		switch {
+		case rl.OutwardIssue != nil:
+			links = append(links, IssueLink{Type: rl.Type.Outward, Key: rl.OutwardIssue.Key})
+		case rl.InwardIssue != nil:
+			links = append(links, IssueLink{Type: rl.Type.Inward, Key: rl.InwardIssue.Key})
+		}
+	}
+	return links
+}
+
+// Status is a JIRA workflow status.
+type Status struct {
+	Name           string         `json:"name"`
+	StatusCategory StatusCategory `json:"statusCategory"`
+}
+
+// StatusCategory groups statuses into To Do / In Progress / Done.
+type StatusCategory struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// IssueType identifies the kind of work item (Epic, Story, Task, Bug, ...).
+type IssueType struct {
+	Name    string `json:"name"`
+	Subtask bool   `json:"subtask"`
+}
+
+// Priority is a JIRA issue priority.
+type Priority struct {
+	Name string `json:"name"`
+}
+
+// User is a JIRA account reference.
+type User struct {
+	AccountID    string `json:"accountId,omitempty"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// Component is a JIRA project component.
+type Component struct {
+	Name string `json:"name"`
+}
+
+// Project is a JIRA project reference.
+type Project struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// SearchResult is the response envelope of the JIRA search endpoint.
+type SearchResult struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
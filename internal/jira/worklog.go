@@ -0,0 +1,62 @@
+package jira
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Worklog is one time-tracking entry logged against an issue.
+type Worklog struct {
+	// Author is the display name of who logged the time.
+	Author string
+	// TimeSpent is the human-readable duration, e.g. "2h 30m".
+	TimeSpent string
+	// TimeSpentSeconds is the logged duration in seconds.
+	TimeSpentSeconds int
+	// Started is when the logged work began.
+	Started string
+}
+
+// worklogPage is the response envelope of the issue worklog endpoint.
+type worklogPage struct {
+	StartAt    int           `json:"startAt"`
+	MaxResults int           `json:"maxResults"`
+	Total      int           `json:"total"`
+	Worklogs   []wireWorklog `json:"worklogs"`
+}
+
+type wireWorklog struct {
+	Author struct {
+		DisplayName string `json:"displayName"`
+	} `json:"author"`
+	Started          string `json:"started"`
+	TimeSpent        string `json:"timeSpent"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+}
+
+// GetWorklogs fetches all worklog entries of an issue, oldest first,
+// paginating through the worklog endpoint. An issue without worklogs
+// returns an empty slice.
+func (c *Client) GetWorklogs(ctx context.Context, issueKey string) ([]Worklog, error) {
+	entries, err := paginate(ctx, func(startAt int) ([]wireWorklog, int, error) {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		var page worklogPage
+		err := c.get(ctx, c.apiPrefix+"/issue/"+url.PathEscape(issueKey)+"/worklog", params, &page)
+		return page.Worklogs, page.Total, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	worklogs := make([]Worklog, 0, len(entries))
+	for _, wl := range entries {
+		worklogs = append(worklogs, Worklog{
+			Author:           wl.Author.DisplayName,
+			TimeSpent:        wl.TimeSpent,
+			TimeSpentSeconds: wl.TimeSpentSeconds,
+			Started:          wl.Started,
+		})
+	}
+	return worklogs, nil
+}
@@ -0,0 +1,431 @@
+// Package metrics defines the Prometheus collectors exported by the
+// operator, registered with the controller-runtime metrics registry.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// traceIDKey carries a trace ID through a context for exemplar
+// attachment.
+type traceIDKey struct{}
+
+// ContextWithTraceID returns a context carrying a trace ID for metric
+// exemplars.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFromContext extracts the trace ID set by ContextWithTraceID,
+// or "" when tracing is not enabled for this operation.
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+var (
+	// CircuitBreakerState reports the state of each component circuit
+	// breaker: 0 closed, 1 half-open, 2 open.
+	CircuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_circuit_breaker_state",
+			Help: "State of component circuit breakers (0=closed, 1=half-open, 2=open).",
+		},
+		[]string{"component"},
+	)
+
+	// HealthCheckTotal counts connectivity probe outcomes per component.
+	HealthCheckTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_health_check_total",
+			Help: "Connectivity health check outcomes by component.",
+		},
+		[]string{"component", "result"},
+	)
+
+	// ComponentHealth reports the latest probe result per component
+	// (1 healthy, 0 unhealthy).
+	ComponentHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_component_health",
+			Help: "Latest connectivity health per component (1=healthy, 0=unhealthy).",
+		},
+		[]string{"component"},
+	)
+
+	// GitOperationDuration observes how long git operations take, by
+	// operation name (clone, pull, push).
+	GitOperationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jira_cdc_git_operation_duration_seconds",
+			Help:    "Duration of git operations by operation name.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+		},
+		[]string{"operation"},
+	)
+
+	// MirrorPushDuration observes how long pushes to each configured
+	// mirror remote take.
+	MirrorPushDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jira_cdc_git_mirror_push_duration_seconds",
+			Help:    "Duration of git pushes to mirror remotes, by remote URL.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+		},
+		[]string{"remote"},
+	)
+
+	// PushRetryTotal counts git push attempts retried after a
+	// non-fast-forward rejection.
+	PushRetryTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_git_push_retries_total",
+			Help: "Git push retries after non-fast-forward rejections.",
+		},
+	)
+
+	// GitFilesModifiedTotal counts issue files actually written into the
+	// working tree. Writes skipped by delta detection, because the
+	// rendered content matched the stored file, are not counted.
+	GitFilesModifiedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_git_files_modified_total",
+			Help: "Issue files written to the working tree, excluding content-identical skips.",
+		},
+	)
+
+	// IssueE2ELatency observes per-issue end-to-end sync latency, from
+	// the start of the JIRA fetch to the git commit.
+	IssueE2ELatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jiracdc_issue_e2e_latency_seconds",
+			Help:    "End-to-end issue sync latency from JIRA fetch start to git commit.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+		[]string{"instance", "project"},
+	)
+
+	// WatchedSecretsTotal reports how many credential secrets each
+	// JiraCDC resource references, all of which are watched for
+	// rotation.
+	WatchedSecretsTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_watched_secrets",
+			Help: "Credential secrets watched for rotation, per resource.",
+		},
+		[]string{"resource"},
+	)
+
+	// TaskQueueDepth reports how many submitted tasks are waiting for a
+	// worker, so operators can see backpressure and size concurrency
+	// limits.
+	TaskQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jiracdc_task_queue_depth",
+			Help: "Tasks enqueued and not yet picked up by a worker.",
+		},
+		[]string{"type", "project"},
+	)
+
+	// TaskQueueWait observes how long tasks sat in the queue before a
+	// worker picked them up, including tasks that were then cancelled
+	// or skipped.
+	TaskQueueWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jiracdc_task_queue_wait_seconds",
+			Help:    "Time tasks spent queued before dequeue.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+		},
+		[]string{"type", "project"},
+	)
+
+	// SyncBatchSize reports the effective adaptive batch size per
+	// project.
+	SyncBatchSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_sync_batch_size",
+			Help: "Effective JIRA search batch size per project.",
+		},
+		[]string{"project"},
+	)
+
+	// AttachmentBytesTotal counts bytes of attachment content downloaded
+	// from JIRA.
+	AttachmentBytesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_attachment_bytes_total",
+			Help: "Attachment bytes downloaded from JIRA.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterRequestsAllowed reports the cumulative number of
+	// requests the JIRA rate limiter has let through.
+	RateLimiterRequestsAllowed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_requests_allowed",
+			Help: "Cumulative requests admitted by the JIRA rate limiter.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterRequestsThrottled reports how many of those requests had
+	// to wait for a token before proceeding.
+	RateLimiterRequestsThrottled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_requests_throttled",
+			Help: "Cumulative requests delayed by the JIRA rate limiter.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterServerLimitHits reports how many 429 responses JIRA has
+	// returned despite client-side limiting.
+	RateLimiterServerLimitHits = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_server_limit_hits",
+			Help: "Cumulative 429 responses received from JIRA.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterBurstTokens reports how many requests could be issued
+	// immediately without throttling.
+	RateLimiterBurstTokens = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_burst_tokens",
+			Help: "Tokens currently available in the JIRA rate limiter's global bucket.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterAverageWait reports the mean time throttled requests
+	// spent waiting for a token.
+	RateLimiterAverageWait = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_average_wait_seconds",
+			Help: "Mean wait of requests throttled by the JIRA rate limiter.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// RateLimiterEffectiveLimit reports the sustained requests-per-second
+	// rate the limiter currently enforces, so operators can compare the
+	// configured RequestsPerSecond against observed throttling.
+	RateLimiterEffectiveLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_rate_limiter_effective_limit",
+			Help: "Sustained requests-per-second rate the JIRA rate limiter enforces.",
+		},
+		[]string{"instance", "project"},
+	)
+
+	// JiraAPIRequestsTotal counts JIRA API requests by endpoint label
+	// and response status, recorded by the client transport so no call
+	// path can bypass it.
+	JiraAPIRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_jira_api_requests_total",
+			Help: "JIRA API requests by endpoint and response status.",
+		},
+		[]string{"endpoint", "status"},
+	)
+
+	// JiraAPIRequestDuration observes JIRA API request latency by
+	// endpoint label.
+	JiraAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jira_cdc_jira_api_request_duration_seconds",
+			Help:    "JIRA API request latency by endpoint.",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 12),
+		},
+		[]string{"endpoint"},
+	)
+
+	// JiraAPIRateLimitTotal counts 429 responses by endpoint label.
+	JiraAPIRateLimitTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jira_cdc_jira_api_rate_limited_total",
+			Help: "JIRA API requests rejected with 429, by endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+
+	// LeaderStatus reports whether this replica holds the controller
+	// leader lease (1 leading, 0 standby), so HA deployments can see
+	// which pod schedules syncs.
+	LeaderStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jira_cdc_leader",
+			Help: "Whether this replica is the elected leader (1=leading, 0=standby).",
+		},
+		[]string{"pod"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		CircuitBreakerState,
+		HealthCheckTotal,
+		ComponentHealth,
+		GitOperationDuration,
+		MirrorPushDuration,
+		PushRetryTotal,
+		GitFilesModifiedTotal,
+		IssueE2ELatency,
+		WatchedSecretsTotal,
+		TaskQueueDepth,
+		TaskQueueWait,
+		SyncBatchSize,
+		AttachmentBytesTotal,
+		RateLimiterRequestsAllowed,
+		RateLimiterRequestsThrottled,
+		RateLimiterServerLimitHits,
+		RateLimiterBurstTokens,
+		RateLimiterAverageWait,
+		RateLimiterEffectiveLimit,
+		JiraAPIRequestsTotal,
+		JiraAPIRequestDuration,
+		JiraAPIRateLimitTotal,
+		LeaderStatus,
+	)
+}
+
+// RecordHealthCheck counts one probe outcome for a component.
+func RecordHealthCheck(component string, healthy bool) {
+	result := "success"
+	if !healthy {
+		result = "failure"
+	}
+	HealthCheckTotal.WithLabelValues(component, result).Inc()
+}
+
+// SetComponentHealth updates the component health gauge.
+func SetComponentHealth(component string, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	ComponentHealth.WithLabelValues(component).Set(value)
+}
+
+// RecordGitOperation observes the duration of one git operation.
+func RecordGitOperation(operation string, d time.Duration) {
+	GitOperationDuration.WithLabelValues(operation).Observe(d.Seconds())
+}
+
+// RecordMirrorPush observes the duration of one mirror push.
+func RecordMirrorPush(remote string, d time.Duration) {
+	MirrorPushDuration.WithLabelValues(remote).Observe(d.Seconds())
+}
+
+// RecordPushRetry counts one push retry after a rejection.
+func RecordPushRetry() {
+	PushRetryTotal.Inc()
+}
+
+// RecordGitFileModified counts one issue file actually written.
+func RecordGitFileModified() {
+	GitFilesModifiedTotal.Inc()
+}
+
+// RecordJiraAPIRequest counts one JIRA API request and observes its
+// latency.
+func RecordJiraAPIRequest(endpoint, status string, d time.Duration) {
+	JiraAPIRequestsTotal.WithLabelValues(endpoint, status).Inc()
+	JiraAPIRequestDuration.WithLabelValues(endpoint).Observe(d.Seconds())
+}
+
+// RecordJiraAPIRateLimit counts one 429 response from JIRA.
+func RecordJiraAPIRateLimit(endpoint string) {
+	JiraAPIRateLimitTotal.WithLabelValues(endpoint).Inc()
+}
+
+// RecordIssueE2ELatency observes one issue's end-to-end sync latency.
+// When a trace ID is supplied and the underlying observer supports
+// exemplars, the observation carries a trace_id exemplar so slow issues
+// can be correlated to traces; otherwise the exemplar is silently
+// dropped.
+func RecordIssueE2ELatency(instance, project string, d time.Duration, traceID string) {
+	obs := IssueE2ELatency.WithLabelValues(instance, project)
+	if traceID != "" {
+		if eo, ok := obs.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(d.Seconds(), prometheus.Labels{"trace_id": traceID})
+			return
+		}
+	}
+	obs.Observe(d.Seconds())
+}
+
+// SetWatchedSecrets updates the watched secret count for a resource.
+func SetWatchedSecrets(resource string, count int) {
+	WatchedSecretsTotal.WithLabelValues(resource).Set(float64(count))
+}
+
+// TaskEnqueued bumps the queue depth for a task entering the queue.
+func TaskEnqueued(taskType, project string) {
+	TaskQueueDepth.WithLabelValues(taskType, project).Inc()
+}
+
+// TaskDequeued drops the queue depth for a task leaving the queue —
+// whether it then runs, is skipped at shutdown, or was cancelled while
+// pending — and observes its queue wait.
+func TaskDequeued(taskType, project string, wait time.Duration) {
+	TaskQueueDepth.WithLabelValues(taskType, project).Dec()
+	TaskQueueWait.WithLabelValues(taskType, project).Observe(wait.Seconds())
+}
+
+// RetagQueuedTask moves a queued task's depth between project labels
+// when its project is tagged after submission, keeping the later
+// decrement matched.
+func RetagQueuedTask(taskType, oldProject, newProject string) {
+	TaskQueueDepth.WithLabelValues(taskType, oldProject).Dec()
+	TaskQueueDepth.WithLabelValues(taskType, newProject).Inc()
+}
+
+// AddAttachmentBytes counts downloaded attachment content.
+func AddAttachmentBytes(instance, project string, n int) {
+	AttachmentBytesTotal.WithLabelValues(instance, project).Add(float64(n))
+}
+
+// SetRateLimiterStats updates the rate limiter gauges from one stats
+// snapshot. The counts are cumulative over the limiter's lifetime, so
+// callers pass totals rather than deltas.
+func SetRateLimiterStats(instance, project string, allowed, throttled, serverLimitHits int64, burstTokens, averageWait, effectiveLimit float64) {
+	RateLimiterRequestsAllowed.WithLabelValues(instance, project).Set(float64(allowed))
+	RateLimiterRequestsThrottled.WithLabelValues(instance, project).Set(float64(throttled))
+	RateLimiterServerLimitHits.WithLabelValues(instance, project).Set(float64(serverLimitHits))
+	RateLimiterBurstTokens.WithLabelValues(instance, project).Set(burstTokens)
+	RateLimiterAverageWait.WithLabelValues(instance, project).Set(averageWait)
+	RateLimiterEffectiveLimit.WithLabelValues(instance, project).Set(effectiveLimit)
+}
+
+// SetLeaderStatus updates the leadership gauge for this pod.
+func SetLeaderStatus(pod string, leading bool) {
+	value := 0.0
+	if leading {
+		value = 1
+	}
+	LeaderStatus.WithLabelValues(pod).Set(value)
+}
+
+// SetSyncBatchSize updates the effective batch size gauge for a project.
+func SetSyncBatchSize(project string, size int) {
+	SyncBatchSize.WithLabelValues(project).Set(float64(size))
+}
+
+// BreakerStateValue maps a breaker state name to its gauge value.
+func BreakerStateValue(state string) float64 {
+	switch state {
+	case "open":
+		return 2
+	case "half-open":
+		return 1
+	default:
+		return 0
+	}
+}
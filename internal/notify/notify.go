@@ -0,0 +1,78 @@
+// Package notify posts sync lifecycle notifications to external
+// services such as Slack or generic webhooks. Notifications are
+// best-effort: failures are logged by callers and never fail a sync.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Event statuses notifiers can subscribe to.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Event describes the outcome of a sync operation.
+type Event struct {
+	// Resource is the namespace/name of the JiraCDC resource.
+	Resource string `json:"resource"`
+	// Projects are the mirrored JIRA project keys.
+	Projects []string `json:"projects"`
+	// Status is StatusCompleted or StatusFailed.
+	Status string `json:"status"`
+	// Message carries failure details or a degraded summary.
+	Message string `json:"message,omitempty"`
+	// SyncedIssues is the issue count from the operation.
+	SyncedIssues int `json:"syncedIssues"`
+	// Time is when the operation finished.
+	Time time.Time `json:"time"`
+}
+
+// Notifier delivers one event to an external service.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// DefaultTemplate is the message template used when none is configured.
+const DefaultTemplate = `jira-cdc {{.Resource}}: sync {{.Status}} for {{join .Projects}} ({{.SyncedIssues}} issues){{if .Message}}: {{.Message}}{{end}}`
+
+// ParseTemplate parses a message template, exposing a "join" helper for
+// the project list.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("notification").Funcs(template.FuncMap{
+		"join": func(values []string) string { return strings.Join(values, ", ") },
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("notify: parse message template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func renderTemplate(tmpl *template.Template, event Event) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, event); err != nil {
+		return "", fmt.Errorf("notify: render message: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// httpClient bounds notification requests so a slow receiver cannot
+// hold up the caller's retry loop indefinitely.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+func checkResponse(resp *http.Response, service string) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: %s returned status %d", service, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"text/template"
+)
+
+// SlackNotifier posts rendered event messages to a Slack incoming
+// webhook.
+type SlackNotifier struct {
+	webhookURL string
+	tmpl       *template.Template
+}
+
+// NewSlack returns a SlackNotifier posting to webhookURL, rendering
+// messages with messageTemplate (empty means DefaultTemplate).
+func NewSlack(webhookURL, messageTemplate string) (*SlackNotifier, error) {
+	tmpl, err := ParseTemplate(messageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &SlackNotifier{webhookURL: webhookURL, tmpl: tmpl}, nil
+}
+
+// Notify posts the event as a Slack message.
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	text, err := renderTemplate(n.tmpl, event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp, "slack")
+}
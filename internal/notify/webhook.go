@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// WebhookNotifier posts the raw event as JSON to a generic HTTP
+// endpoint.
+type WebhookNotifier struct {
+	url string
+}
+
+// NewWebhook returns a WebhookNotifier posting to url.
+func NewWebhook(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url}
+}
+
+// Notify posts the event body.
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp, "webhook")
+}
@@ -0,0 +1,58 @@
+// Package storage abstracts the destination mirrored issue files are
+// written to, so the sync engine can target object storage as well as
+// the default git repository.
+package storage
+
+import (
+	"context"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+)
+
+// SyncTarget is a storage backend issue files are synced into. The git
+// working tree is the default implementation; object-store backends
+// write each issue as one object instead.
+type SyncTarget interface {
+	// WriteIssue renders one issue and stores it, replacing any previous
+	// version.
+	WriteIssue(ctx context.Context, data *git.IssueData) error
+	// DeleteIssue removes the stored form of an issue, reporting whether
+	// anything was removed.
+	DeleteIssue(ctx context.Context, key string) (bool, error)
+	// Commit makes previously written changes durable as one unit with
+	// the given message, returning a backend-specific revision
+	// identifier. Backends without a grouping concept persist each write
+	// immediately and return an empty identifier.
+	Commit(ctx context.Context, message string) (string, error)
+}
+
+// GitTarget adapts the git manager to SyncTarget. Repository lifecycle
+// concerns — cloning, pushing, drift detection — stay on the manager
+// itself; only the issue write/delete/commit path goes through the
+// interface.
+type GitTarget struct {
+	m *git.Manager
+}
+
+// NewGitTarget returns the default SyncTarget writing into m's working
+// tree.
+func NewGitTarget(m *git.Manager) *GitTarget {
+	return &GitTarget{m: m}
+}
+
+// WriteIssue writes the issue file into the working tree.
+func (t *GitTarget) WriteIssue(ctx context.Context, data *git.IssueData) error {
+	_, err := t.m.CreateIssueFile(data)
+	return err
+}
+
+// DeleteIssue removes the issue's file from the working tree.
+func (t *GitTarget) DeleteIssue(ctx context.Context, key string) (bool, error) {
+	removed, err := t.m.RemoveIssueFile(key)
+	return removed != "", err
+}
+
+// Commit stages and commits every working tree change.
+func (t *GitTarget) Commit(ctx context.Context, message string) (string, error) {
+	return t.m.CommitAll(message)
+}
@@ -0,0 +1,94 @@
+package sync
+
+import (
+	syncpkg "sync"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// growStreak is how many consecutive clean pages are required before the
+// batch size is grown, keeping adjustments gradual.
+const growStreak = 3
+
+// adaptiveBatcher tunes the JIRA search page size between configured
+// bounds based on rate limiter feedback: server 429s halve the batch,
+// client-side throttling trims it, and a sustained clean run grows it
+// back. Changes are deliberately small to avoid oscillation. It is safe
+// for concurrent use by parallel project workers.
+type adaptiveBatcher struct {
+	mu       syncpkg.Mutex
+	min, max int
+	size     int
+
+	cleanStreak   int
+	lastThrottled int64
+	lastRateLimit time.Time
+}
+
+func newAdaptiveBatcher(initial, min, max int) *adaptiveBatcher {
+	if min <= 0 {
+		min = 10
+	}
+	if max <= 0 {
+		max = 100
+	}
+	if min > max {
+		min = max
+	}
+	if initial < min {
+		initial = min
+	}
+	if initial > max {
+		initial = max
+	}
+	return &adaptiveBatcher{min: min, max: max, size: initial}
+}
+
+// current returns the effective batch size.
+func (b *adaptiveBatcher) current() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// observe adjusts the batch size from a limiter snapshot taken after a
+// page of work and returns the new size.
+func (b *adaptiveBatcher) observe(stats jira.RateLimiterStats) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hit429 := stats.LastRateLimit.After(b.lastRateLimit)
+	throttled := stats.Throttled - b.lastThrottled
+	b.lastRateLimit = stats.LastRateLimit
+	b.lastThrottled = stats.Throttled
+
+	switch {
+	case hit429:
+		b.size = maxInt(b.min, b.size/2)
+		b.cleanStreak = 0
+	case throttled > 0:
+		b.size = maxInt(b.min, b.size-maxInt(1, b.size/4))
+		b.cleanStreak = 0
+	default:
+		b.cleanStreak++
+		if b.cleanStreak >= growStreak {
+			b.size = minInt(b.max, b.size+maxInt(1, b.size/10))
+			b.cleanStreak = 0
+		}
+	}
+	return b.size
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
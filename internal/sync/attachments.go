@@ -0,0 +1,65 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	syncpkg "sync"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// downloadAttachments mirrors an issue's attachments into the working
+// tree through a bounded worker pool, so media-heavy issues download in
+// parallel while the shared rate limiter keeps the total request rate
+// honest. Each download retries like an issue write; the issue fails
+// only when an attachment is still failing after those retries. The
+// pool inherits ctx, so cancelling the parent operation cancels every
+// in-flight download.
+func (e *Engine) downloadAttachments(ctx context.Context, project, issueKey string, attachments []jira.Attachment) error {
+	if !e.syncAttachments || len(attachments) == 0 {
+		return nil
+	}
+
+	var mu syncpkg.Mutex
+	var failed []string
+	jobs := make([]func(), 0, len(attachments))
+	for _, att := range attachments {
+		att := att
+		jobs = append(jobs, func() {
+			if ctx.Err() != nil {
+				return
+			}
+			err := e.retries.Do(ctx, func(ctx context.Context) error {
+				return e.execJira(ctx, func(ctx context.Context) error {
+					content, derr := e.jira.DownloadAttachment(ctx, att)
+					if derr != nil {
+						return derr
+					}
+					if _, werr := e.git.WriteAttachment(issueKey, att.Filename, content); werr != nil {
+						return werr
+					}
+					metrics.AddAttachmentBytes(e.instance, project, len(content))
+					return nil
+				})
+			})
+			if err != nil {
+				mu.Lock()
+				failed = append(failed, att.Filename)
+				mu.Unlock()
+			}
+		})
+	}
+	runWorkers(e.attachConcurrency, jobs)
+
+	e.tracker.AddDetail(ProgressDetail{Attachments: len(attachments) - len(failed)})
+	if len(failed) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("sync: %d of %d attachments failed for %s: %s",
+			len(failed), len(attachments), issueKey, strings.Join(failed, ", "))
+	}
+	return nil
+}
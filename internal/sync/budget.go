@@ -0,0 +1,97 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// Operation types accepted by EstimateRequests.
+const (
+	OperationBootstrap = "bootstrap"
+	OperationIssueSync = "issue-sync"
+)
+
+// EstimateRequests approximates how many JIRA API requests an operation
+// will issue for issueCount issues. Bootstraps page searches at the
+// default batch size of 50 plus fixed validation overhead; targeted
+// issue syncs fetch each issue and its links individually. The estimate
+// deliberately ignores optional per-issue extras like changelog fetches
+// — it only needs to be good enough to decide whether starting now
+// would immediately hit the server rate limit.
+func EstimateRequests(operationType string, issueCount int) int {
+	switch operationType {
+	case OperationIssueSync:
+		return issueCount * 2
+	case OperationBootstrap:
+		return issueCount/50 + 3
+	default:
+		return issueCount
+	}
+}
+
+// awaitRateBudget checks the server-reported rate-limit budget before a
+// full sync starts. With comfortably enough remaining requests the sync
+// starts immediately; a tight budget halves task parallelism instead,
+// and an insufficient one delays the start until the server-reported
+// window reset. The decision is surfaced as the operation's first
+// progress message. A reduced parallelism lasts for the engine's
+// lifetime, which is one reconcile — the next rebuild restores the
+// configured value.
+func (e *Engine) awaitRateBudget(ctx context.Context) error {
+	stats := e.jira.RateLimitStats()
+	remaining := int64(-1)
+	if es, ok := stats.PerEndpoint[jira.EndpointSearch]; ok {
+		remaining = es.Remaining
+	}
+	estimate := EstimateRequests(OperationBootstrap, e.lastIssueCount())
+	switch {
+	case remaining < 0 || int(remaining) >= estimate:
+		e.tracker.Update("rate-budget", TaskRunning,
+			fmt.Sprintf("starting immediately: estimated %d requests within rate budget", estimate))
+		return nil
+	case int(remaining) >= estimate/2:
+		if e.executor.MaxParallelism > 1 {
+			e.executor.MaxParallelism = (e.executor.MaxParallelism + 1) / 2
+		}
+		e.tracker.Update("rate-budget", TaskRunning,
+			fmt.Sprintf("rate budget tight (%d remaining of ~%d needed): reducing parallelism to %d",
+				remaining, estimate, e.executor.MaxParallelism))
+		return nil
+	default:
+		wait := time.Until(stats.LastServerReset)
+		if wait <= 0 {
+			// No usable reset time; the client-side limiter will pace
+			// the requests that the server budget cannot absorb.
+			e.tracker.Update("rate-budget", TaskRunning,
+				fmt.Sprintf("rate budget low (%d remaining of ~%d needed): starting paced by client limiter",
+					remaining, estimate))
+			return nil
+		}
+		e.tracker.Update("rate-budget", TaskRunning,
+			fmt.Sprintf("rate budget exhausted (%d remaining of ~%d needed): delaying start %s until window reset",
+				remaining, estimate, wait.Round(time.Second)))
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+}
+
+// lastIssueCount sums the per-project issue counts observed on the
+// previous cycle, the best available estimate of the next cycle's size.
+// A first bootstrap has no history and estimates low, which only costs
+// it the pre-check.
+func (e *Engine) lastIssueCount() int {
+	total := 0
+	for _, n := range e.tracker.Snapshot().PerProject {
+		total += n
+	}
+	return total
+}
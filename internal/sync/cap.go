@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+)
+
+// checkIssueCap counts the issues an operation would cover — one
+// maxResults=0 search per scope, fetching no issue bodies — and aborts
+// with ErrTooManyIssues when the count exceeds MaxIssuesPerOperation,
+// unless AllowLargeSync overrides the cap. The discovered count is
+// recorded either way for status reporting. Incremental cycles count
+// their incremental window; a checkpoint resume may scan more than was
+// counted, the cap being a guard against misconfiguration rather than
+// an exact budget. No cap configured skips the pre-flight entirely.
+func (e *Engine) checkIssueCap(ctx context.Context) error {
+	if e.maxIssues <= 0 {
+		return nil
+	}
+	incremental := e.incremental && !e.incrSince.IsZero()
+	total := 0
+	count := func(jql string) error {
+		return e.execJira(ctx, func(ctx context.Context) error {
+			n, err := e.jira.ValidateJQL(ctx, jql)
+			if err != nil {
+				return err
+			}
+			total += n
+			return nil
+		})
+	}
+	for _, project := range e.projectKeys {
+		jql := e.searchJQL(project)
+		if incremental {
+			jql = e.incrementalJQL(project)
+		}
+		if err := count(jql); err != nil {
+			return fmt.Errorf("sync: count issues for %s: %w", project, err)
+		}
+	}
+	if e.filterID != 0 {
+		jql, err := e.resolveFilterJQL(ctx)
+		if err != nil {
+			return fmt.Errorf("sync: resolve filter %d: %w", e.filterID, err)
+		}
+		if err := count(jql); err != nil {
+			return fmt.Errorf("sync: count issues for filter %d: %w", e.filterID, err)
+		}
+	}
+	if e.boardID != 0 {
+		err := e.execJira(ctx, func(ctx context.Context) error {
+			page, berr := e.jira.GetBoardIssues(ctx, e.boardID, 0, 1)
+			if berr != nil {
+				return berr
+			}
+			total += page.Total
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("sync: count issues for board %d: %w", e.boardID, err)
+		}
+	}
+	e.discovered = total
+	if total > e.maxIssues && !e.allowLarge {
+		return fmt.Errorf("%w: %d issues in scope, cap is %d; set allowLargeSync to proceed",
+			ErrTooManyIssues, total, e.maxIssues)
+	}
+	return nil
+}
+
+// DiscoveredIssues returns the issue count the last operation's
+// pre-flight cap check found in scope. Zero when no cap is configured.
+func (e *Engine) DiscoveredIssues() int {
+	return e.discovered
+}
@@ -0,0 +1,77 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// TestIssueCapFailsFast verifies a scope whose discovered count exceeds
+// the cap aborts before any issue bodies are fetched, that
+// AllowLargeSync overrides the abort, and that the count is recorded
+// for status either way.
+func TestIssueCapFailsFast(t *testing.T) {
+	const hugeTotal = 1000000
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"startAt":0,"maxResults":0,"total":%d,"issues":[]}`, hugeTotal)
+	}))
+	defer server.Close()
+	client, err := jira.NewClient(jira.Config{
+		BaseURL:  server.URL,
+		Email:    "bot@example.com",
+		APIToken: "token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newCapped := func(allowLarge bool) *Engine {
+		engine, err := NewEngine(client, nil, Options{
+			ProjectKeys:           []string{"TEST"},
+			MaxIssuesPerOperation: 10000,
+			AllowLargeSync:        allowLarge,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return engine
+	}
+
+	engine := newCapped(false)
+	err = engine.checkIssueCap(context.Background())
+	if !errors.Is(err, ErrTooManyIssues) {
+		t.Fatalf("got error %v, want ErrTooManyIssues", err)
+	}
+	if engine.DiscoveredIssues() != hugeTotal {
+		t.Errorf("discovered %d issues, want %d", engine.DiscoveredIssues(), hugeTotal)
+	}
+
+	engine = newCapped(true)
+	if err := engine.checkIssueCap(context.Background()); err != nil {
+		t.Fatalf("allowLargeSync did not override the cap: %v", err)
+	}
+	if engine.DiscoveredIssues() != hugeTotal {
+		t.Errorf("discovered %d issues, want %d", engine.DiscoveredIssues(), hugeTotal)
+	}
+
+	// No cap configured skips the pre-flight count entirely.
+	uncapped, err := NewEngine(client, nil, Options{ProjectKeys: []string{"TEST"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := requests
+	if err := uncapped.checkIssueCap(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != before {
+		t.Errorf("uncapped engine made %d count requests, want 0", requests-before)
+	}
+}
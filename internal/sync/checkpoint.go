@@ -0,0 +1,69 @@
+package sync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Checkpoint records how far a sync operation progressed so an
+// interrupted bootstrap can resume instead of restarting from scratch.
+type Checkpoint struct {
+	// ProjectKey is the project the checkpoint belongs to.
+	ProjectKey string `json:"projectKey"`
+	// LastIssueKey is the last issue successfully written to git.
+	LastIssueKey string `json:"lastIssueKey"`
+	// StartAt is the JIRA search offset to resume from.
+	StartAt int `json:"startAt"`
+	// UpdatedAt is when the checkpoint was last persisted.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// loadCheckpoint reads a checkpoint from path. It returns (nil, nil)
+// when no checkpoint exists.
+func loadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sync: read checkpoint %s: %w", path, err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		// A corrupt checkpoint is treated as absent; the operation falls
+		// back to a full scan.
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+// save atomically persists the checkpoint to path.
+func (c *Checkpoint) save(path string) error {
+	c.UpdatedAt = time.Now()
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("sync: write checkpoint %s: %w", path, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// removeCheckpoint deletes a persisted checkpoint, ignoring absence.
+func removeCheckpoint(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
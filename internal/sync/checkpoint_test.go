@@ -0,0 +1,203 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+)
+
+// TestCheckpointRoundTrip verifies save and load agree, absence and
+// removal are clean, and no temporary file is left behind by the
+// atomic write.
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "checkpoint.json")
+
+	cp, err := loadCheckpoint(path)
+	if err != nil || cp != nil {
+		t.Fatalf("missing checkpoint loaded as (%v, %v), want (nil, nil)", cp, err)
+	}
+
+	saved := &Checkpoint{ProjectKey: "PROJ", LastIssueKey: "PROJ-42", StartAt: 150}
+	if err := saved.save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary checkpoint file left behind")
+	}
+
+	cp, err = loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.ProjectKey != "PROJ" || cp.LastIssueKey != "PROJ-42" || cp.StartAt != 150 {
+		t.Errorf("loaded checkpoint %+v does not match what was saved", cp)
+	}
+	if cp.UpdatedAt.IsZero() || time.Since(cp.UpdatedAt) > time.Minute {
+		t.Errorf("checkpoint UpdatedAt %v was not stamped on save", cp.UpdatedAt)
+	}
+
+	if err := removeCheckpoint(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := removeCheckpoint(path); err != nil {
+		t.Errorf("second removal of an absent checkpoint: %v", err)
+	}
+	if cp, _ = loadCheckpoint(path); cp != nil {
+		t.Error("checkpoint survives removal")
+	}
+}
+
+// TestCheckpointCorruptFallsBack verifies an unreadable checkpoint is
+// treated as absent, so the sync falls back to a full scan instead of
+// failing.
+func TestCheckpointCorruptFallsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := loadCheckpoint(path)
+	if err != nil || cp != nil {
+		t.Errorf("corrupt checkpoint loaded as (%v, %v), want (nil, nil)", cp, err)
+	}
+}
+
+// newCheckpointTestEngine builds an engine over a local repository
+// containing one committed issue file, so resume validation can check
+// the working tree.
+func newCheckpointTestEngine(t *testing.T, opts Options) *Engine {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "repo")
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := git.NewManager(git.Config{
+		URL:        dir,
+		Branch:     "master",
+		LocalPath:  dir,
+		FileLayout: "{project}/{key}.md",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Open(); err != nil {
+		t.Fatal(err)
+	}
+	rel, err := manager.CreateIssueFile(&git.IssueData{
+		Key: "PROJ-42", Project: "PROJ", Summary: "Answer", IssueType: "Task", Status: "Done",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add(rel); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Commit("sync: add PROJ-42", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@localhost", When: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := NewEngine(nil, manager, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return engine
+}
+
+// TestLoadResumeValidation verifies a checkpoint only resumes when it
+// names a configured project and its last issue is actually present in
+// git; anything else falls back to a full scan.
+func TestLoadResumeValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	opts := Options{
+		ProjectKeys:          []string{"PROJ"},
+		CheckpointPath:       path,
+		ResumeFromCheckpoint: true,
+	}
+
+	engine := newCheckpointTestEngine(t, opts)
+	if err := (&Checkpoint{ProjectKey: "PROJ", LastIssueKey: "PROJ-42", StartAt: 100}).save(path); err != nil {
+		t.Fatal(err)
+	}
+	cp := engine.loadResume()
+	if cp == nil || cp.StartAt != 100 || cp.LastIssueKey != "PROJ-42" {
+		t.Fatalf("valid checkpoint did not resume: %+v", cp)
+	}
+
+	// A checkpoint for a project this engine does not sync is ignored.
+	if err := (&Checkpoint{ProjectKey: "OTHER", LastIssueKey: "OTHER-1", StartAt: 10}).save(path); err != nil {
+		t.Fatal(err)
+	}
+	if cp := engine.loadResume(); cp != nil {
+		t.Errorf("checkpoint for an unconfigured project resumed: %+v", cp)
+	}
+
+	// An issue missing from the tree means the offset is stale; the
+	// checkpoint is discarded, not just skipped.
+	if err := (&Checkpoint{ProjectKey: "PROJ", LastIssueKey: "PROJ-999", StartAt: 10}).save(path); err != nil {
+		t.Fatal(err)
+	}
+	if cp := engine.loadResume(); cp != nil {
+		t.Errorf("checkpoint for an absent issue resumed: %+v", cp)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("stale checkpoint was not discarded")
+	}
+
+	// Resume disabled leaves a valid checkpoint untouched.
+	if err := (&Checkpoint{ProjectKey: "PROJ", LastIssueKey: "PROJ-42", StartAt: 100}).save(path); err != nil {
+		t.Fatal(err)
+	}
+	opts.ResumeFromCheckpoint = false
+	engine = newCheckpointTestEngine(t, opts)
+	if cp := engine.loadResume(); cp != nil {
+		t.Errorf("resume disabled but checkpoint %+v was used", cp)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("disabled resume removed the checkpoint: %v", err)
+	}
+}
+
+// TestSaveCheckpointGating verifies checkpoints only persist for
+// serial scans with a real position: parallel project workers finish
+// in no fixed order, so their offsets are not worth trusting.
+func TestSaveCheckpointGating(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	opts := Options{ProjectKeys: []string{"PROJ"}, CheckpointPath: path}
+
+	engine := newCheckpointTestEngine(t, opts)
+	engine.saveCheckpoint("PROJ", "", 50)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("checkpoint saved without a last issue key")
+	}
+
+	engine.saveCheckpoint("PROJ", "PROJ-42", 50)
+	cp, err := loadCheckpoint(path)
+	if err != nil || cp == nil {
+		t.Fatalf("serial checkpoint not persisted: (%v, %v)", cp, err)
+	}
+	if cp.StartAt != 50 {
+		t.Errorf("persisted offset %d, want 50", cp.StartAt)
+	}
+
+	if err := removeCheckpoint(path); err != nil {
+		t.Fatal(err)
+	}
+	opts.MaxProjectConcurrency = 2
+	engine = newCheckpointTestEngine(t, opts)
+	engine.saveCheckpoint("PROJ", "PROJ-42", 50)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("checkpoint saved despite parallel project workers")
+	}
+}
@@ -0,0 +1,105 @@
+package sync
+
+import (
+	"sort"
+	syncpkg "sync"
+	"time"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+)
+
+// DeadLetterEntry records an issue whose sync retries were exhausted by
+// a non-transient failure.
+type DeadLetterEntry struct {
+	ProjectKey string               `json:"projectKey"`
+	IssueKey   string               `json:"issueKey"`
+	Class      cdcerrors.ErrorClass `json:"class"`
+	Error      string               `json:"error"`
+	Attempts   int                  `json:"attempts"`
+	FirstSeen  time.Time            `json:"firstSeen"`
+	LastSeen   time.Time            `json:"lastSeen"`
+}
+
+// DeadLetterStore tracks issues that permanently fail to sync so they
+// can be skipped on subsequent reconciles and retried explicitly. It is
+// safe for concurrent use.
+type DeadLetterStore struct {
+	mu      syncpkg.Mutex
+	entries map[string]map[string]*DeadLetterEntry // projectKey -> issueKey -> entry
+}
+
+// NewDeadLetterStore returns an empty store.
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{entries: map[string]map[string]*DeadLetterEntry{}}
+}
+
+// Record adds or updates a dead-letter entry. Transient errors are
+// never recorded; callers should only pass errors whose retries are
+// exhausted.
+func (s *DeadLetterStore) Record(projectKey, issueKey string, class cdcerrors.ErrorClass, err error, attempts int) {
+	if class.IsRetryable() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project, ok := s.entries[projectKey]
+	if !ok {
+		project = map[string]*DeadLetterEntry{}
+		s.entries[projectKey] = project
+	}
+	now := time.Now()
+	if entry, ok := project[issueKey]; ok {
+		entry.Class = class
+		entry.Error = err.Error()
+		entry.Attempts += attempts
+		entry.LastSeen = now
+		return
+	}
+	project[issueKey] = &DeadLetterEntry{
+		ProjectKey: projectKey,
+		IssueKey:   issueKey,
+		Class:      class,
+		Error:      err.Error(),
+		Attempts:   attempts,
+		FirstSeen:  now,
+		LastSeen:   now,
+	}
+}
+
+// IsDead reports whether an issue is currently dead-lettered and should
+// be skipped.
+func (s *DeadLetterStore) IsDead(projectKey, issueKey string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entries[projectKey][issueKey]
+	return ok
+}
+
+// List returns the dead-letter entries for a project, ordered by issue
+// key.
+func (s *DeadLetterStore) List(projectKey string) []DeadLetterEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := s.entries[projectKey]
+	entries := make([]DeadLetterEntry, 0, len(project))
+	for _, entry := range project {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].IssueKey < entries[j].IssueKey })
+	return entries
+}
+
+// RetryFailures clears the project's dead-letter entries so the next
+// reconcile re-attempts them, returning the issue keys re-enqueued.
+func (s *DeadLetterStore) RetryFailures(projectKey string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := s.entries[projectKey]
+	keys := make([]string, 0, len(project))
+	for key := range project {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	delete(s.entries, projectKey)
+	return keys
+}
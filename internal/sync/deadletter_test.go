@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	gosync "sync"
+	"testing"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+)
+
+// TestDeadLetterRecord verifies permanent failures are recorded and
+// skipped, repeats accumulate attempts on one entry, and transient
+// errors never dead-letter.
+func TestDeadLetterRecord(t *testing.T) {
+	store := NewDeadLetterStore()
+
+	store.Record("PROJ", "PROJ-1", cdcerrors.ClassTransient, errors.New("timeout"), 3)
+	if store.IsDead("PROJ", "PROJ-1") {
+		t.Error("transient failure was dead-lettered")
+	}
+
+	store.Record("PROJ", "PROJ-1", cdcerrors.ClassValidation, errors.New("field missing"), 1)
+	if !store.IsDead("PROJ", "PROJ-1") {
+		t.Error("permanent failure was not dead-lettered")
+	}
+	if store.IsDead("PROJ", "PROJ-2") || store.IsDead("OTHER", "PROJ-1") {
+		t.Error("unrelated issues report as dead")
+	}
+
+	// A repeat failure updates the existing entry rather than adding a
+	// second one.
+	store.Record("PROJ", "PROJ-1", cdcerrors.ClassPermanent, errors.New("gone"), 2)
+	entries := store.List("PROJ")
+	if len(entries) != 1 {
+		t.Fatalf("repeat failure created %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Attempts != 3 {
+		t.Errorf("attempts %d, want accumulated 3", entry.Attempts)
+	}
+	if entry.Class != cdcerrors.ClassPermanent || entry.Error != "gone" {
+		t.Errorf("entry not updated to the latest failure: %+v", entry)
+	}
+	if entry.LastSeen.Before(entry.FirstSeen) {
+		t.Errorf("LastSeen %v precedes FirstSeen %v", entry.LastSeen, entry.FirstSeen)
+	}
+}
+
+// TestDeadLetterListOrdering verifies List returns a project's entries
+// ordered by issue key and leaves other projects alone.
+func TestDeadLetterListOrdering(t *testing.T) {
+	store := NewDeadLetterStore()
+	for _, key := range []string{"PROJ-9", "PROJ-2", "PROJ-11"} {
+		store.Record("PROJ", key, cdcerrors.ClassPermanent, errors.New("gone"), 1)
+	}
+	store.Record("OTHER", "OTHER-1", cdcerrors.ClassPermanent, errors.New("gone"), 1)
+
+	entries := store.List("PROJ")
+	if len(entries) != 3 {
+		t.Fatalf("listed %d entries, want 3", len(entries))
+	}
+	if entries[0].IssueKey != "PROJ-11" || entries[1].IssueKey != "PROJ-2" || entries[2].IssueKey != "PROJ-9" {
+		t.Errorf("entries out of key order: %s, %s, %s",
+			entries[0].IssueKey, entries[1].IssueKey, entries[2].IssueKey)
+	}
+	if got := store.List("EMPTY"); len(got) != 0 {
+		t.Errorf("unknown project listed %d entries", len(got))
+	}
+}
+
+// TestDeadLetterRetryFailures verifies retrying clears one project's
+// entries, returns the re-enqueued keys sorted, and is a no-op when
+// nothing is dead.
+func TestDeadLetterRetryFailures(t *testing.T) {
+	store := NewDeadLetterStore()
+	store.Record("PROJ", "PROJ-3", cdcerrors.ClassPermanent, errors.New("gone"), 1)
+	store.Record("PROJ", "PROJ-1", cdcerrors.ClassPermanent, errors.New("gone"), 1)
+	store.Record("OTHER", "OTHER-1", cdcerrors.ClassPermanent, errors.New("gone"), 1)
+
+	keys := store.RetryFailures("PROJ")
+	if len(keys) != 2 || keys[0] != "PROJ-1" || keys[1] != "PROJ-3" {
+		t.Errorf("re-enqueued keys %v, want [PROJ-1 PROJ-3]", keys)
+	}
+	if store.IsDead("PROJ", "PROJ-1") || store.IsDead("PROJ", "PROJ-3") {
+		t.Error("retried issues still report as dead")
+	}
+	if !store.IsDead("OTHER", "OTHER-1") {
+		t.Error("retry cleared another project's entries")
+	}
+	if keys := store.RetryFailures("PROJ"); len(keys) != 0 {
+		t.Errorf("second retry re-enqueued %v", keys)
+	}
+}
+
+// TestDeadLetterConcurrentAccess exercises the store from parallel
+// goroutines; run with -race this verifies the locking.
+func TestDeadLetterConcurrentAccess(t *testing.T) {
+	store := NewDeadLetterStore()
+	var wg gosync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := fmt.Sprintf("PROJ-%d", i)
+			store.Record("PROJ", key, cdcerrors.ClassPermanent, errors.New("gone"), 1)
+			store.IsDead("PROJ", key)
+			store.List("PROJ")
+		}()
+	}
+	wg.Wait()
+	if got := len(store.List("PROJ")); got != 8 {
+		t.Errorf("store holds %d entries after concurrent records, want 8", got)
+	}
+}
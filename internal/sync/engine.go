@@ -0,0 +1,1593 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	syncpkg "sync"
+	"sync/atomic"
+	"time"
+
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+	"github.com/chambridge/atlassian-cdc-git/internal/hooks"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+	"github.com/chambridge/atlassian-cdc-git/internal/storage"
+)
+
+// Options configures a sync Engine.
+type Options struct {
+	// ProjectKey is the single JIRA project being mirrored.
+	//
+	// Deprecated: use ProjectKeys. A value here is treated as the first
+	// entry of ProjectKeys.
+	ProjectKey string
+	// ProjectKeys lists the JIRA projects being mirrored.
+	ProjectKeys []string
+	// BoardID, when non-zero, mirrors every issue on the given agile
+	// board in addition to any listed projects. A board can be the sole
+	// sync target.
+	BoardID int
+	// FilterID, when non-zero, mirrors every issue matched by the given
+	// saved JIRA filter in addition to any listed projects. A filter can
+	// be the sole sync target. The filter's JQL is resolved at sync time
+	// and cached for filterJQLTTL, so edits to the saved filter are
+	// picked up without restarting.
+	FilterID int
+	// BatchSize is the initial page size for JIRA searches. Defaults to
+	// 50. The effective size adapts between MinBatchSize and
+	// MaxBatchSize based on rate limiter feedback.
+	BatchSize int
+	// MinBatchSize bounds adaptive shrinking. Defaults to 10.
+	MinBatchSize int
+	// MaxBatchSize bounds adaptive growth. Defaults to 100.
+	MaxBatchSize int
+	// MaxParallelism bounds concurrent task execution. Defaults to 4.
+	MaxParallelism int
+	// MaxProjectConcurrency bounds how many projects sync concurrently
+	// during a full cycle. Defaults to 1 (serial). Workers share the
+	// clone — issue files are namespaced per project, so writes are
+	// disjoint — and commits are serialized; checkpointing is disabled
+	// above 1 because checkpoints encode a serial scan order.
+	MaxProjectConcurrency int
+	// CheckpointPath is where sync progress checkpoints are persisted.
+	// Empty disables checkpointing.
+	CheckpointPath string
+	// ResumeFromCheckpoint resumes an interrupted bootstrap from the
+	// persisted checkpoint instead of rescanning from the start.
+	ResumeFromCheckpoint bool
+	// Incremental scopes project scans to issues updated since
+	// IncrementalSince minus IncrementalOverlap, instead of rescanning
+	// every project in full. Ignored while IncrementalSince is zero or a
+	// checkpoint resume is in progress, both of which need a full scan.
+	Incremental bool
+	// IncrementalSince is the watermark an incremental cycle starts
+	// from, normally the highest updated timestamp the previous cycle
+	// saw. Zero forces a full scan.
+	IncrementalSince time.Time
+	// IncrementalOverlap is how far each incremental window reaches
+	// back before IncrementalSince, so updates landing while the
+	// previous scan ran are not lost. Defaults to 1 minute.
+	IncrementalOverlap time.Duration
+	// PrioritizeByJiraPriority routes each project scan through a
+	// priority queue keyed on the issues' JIRA priority, so critical
+	// fixes reach the mirror before backlog items; FIFO aging keeps
+	// low-priority issues from being starved. Prioritized scans are not
+	// checkpointed and rescan from the start when interrupted, like
+	// board scans.
+	PrioritizeByJiraPriority bool
+	// Breakers, when set, routes JIRA and git operations through the
+	// shared circuit breakers.
+	Breakers *cdcerrors.CircuitBreakerManager
+	// DeadLetters, when set, records issues whose sync retries are
+	// exhausted by non-transient errors so they are skipped on
+	// subsequent reconciles.
+	DeadLetters *DeadLetterStore
+	// Retry overrides the default retry policy for per-issue writes.
+	Retry cdcerrors.RetryConfig
+	// RemovedProjectPolicy selects what happens to the files of a
+	// project no longer listed in ProjectKeys: git.RemovedProjectKeep
+	// (default) leaves them, git.RemovedProjectArchive moves them under
+	// the archive directory, git.RemovedProjectDelete removes them.
+	// Ignored when a board or filter is configured, since their project
+	// membership is dynamic.
+	RemovedProjectPolicy string
+	// DeletionThresholdPercent aborts deletion cleanup when more than
+	// this percentage of mirrored files would be removed, guarding
+	// against JIRA API hiccups. Defaults to 20.
+	DeletionThresholdPercent int
+	// MaxIssuesPerOperation fails an operation before any issue bodies
+	// are fetched when the discovered issue count exceeds it, guarding
+	// the operator and JIRA against a misconfigured query matching far
+	// more issues than intended. Zero disables the cap.
+	MaxIssuesPerOperation int
+	// AllowLargeSync lets an operation proceed past
+	// MaxIssuesPerOperation; the discovered count is still recorded.
+	AllowLargeSync bool
+	// FieldMapping selects custom fields to mirror into issue
+	// front-matter.
+	FieldMapping []FieldMapping
+	// SyncChangelog mirrors each issue's field transition history into
+	// its file, at the cost of one extra JIRA request per issue.
+	SyncChangelog bool
+	// SyncWorklogs mirrors each issue's worklog entries and aggregated
+	// time tracking into its file, at the cost of one extra JIRA request
+	// per issue.
+	SyncWorklogs bool
+	// SyncRemoteLinks mirrors each issue's remote links into a Remote
+	// Links section of its file, at the cost of one extra JIRA request
+	// per issue.
+	SyncRemoteLinks bool
+	// SyncAttachments mirrors each issue's file attachments into the
+	// repository, at the cost of one download per attachment.
+	SyncAttachments bool
+	// AttachmentConcurrency bounds how many of one issue's attachments
+	// download in parallel. Defaults to 4. Downloads share the JIRA rate
+	// limiter, so raising this trades latency against API budget.
+	AttachmentConcurrency int
+	// LabelFilter restricts sync to issues carrying at least one of the
+	// listed labels.
+	LabelFilter []string
+	// ComponentFilter restricts sync to issues in at least one of the
+	// listed components.
+	ComponentFilter []string
+	// IgnoreFields lists fields excluded from change detection, so
+	// updates touching only these fields produce no commit.
+	IgnoreFields []string
+	// IgnoreStatuses skips issues in the listed statuses entirely; their
+	// files are removed as orphaned.
+	IgnoreStatuses []string
+	// ExtraJQL is an additional user-supplied JQL clause ANDed onto the
+	// generated query.
+	ExtraJQL string
+	// InstanceName labels per-issue latency metrics with the owning
+	// JiraCDC resource.
+	InstanceName string
+	// CommitBatchSize groups this many issue writes into one summarized
+	// commit during sync cycles. Zero leaves the whole cycle as a
+	// single commit.
+	CommitBatchSize int
+	// SquashOnReconcile collapses all commits a sync cycle produced into
+	// one summarized commit before pushing. Only local commits made this
+	// run are ever rewritten.
+	SquashOnReconcile bool
+	// DriftPolicy selects how manually edited issue files are handled:
+	// git.DriftOverwrite (default), git.DriftSkip or git.DriftFail.
+	DriftPolicy string
+	// Hooks, when set, runs post-sync processing hooks after issues are
+	// committed: issue-scoped hooks once per mirrored issue, operation-
+	// scoped hooks once per completed operation. Hook failures only fail
+	// the sync when the runner requires it.
+	Hooks *hooks.Runner
+	// OperationTimeout bounds one full sync operation; when it elapses
+	// the operation's context is cancelled and every in-flight task
+	// stops. Zero uses defaultOperationTimeout.
+	OperationTimeout time.Duration
+	// Target overrides the storage backend issue writes, deletions and
+	// commits go through. Nil targets the git repository. Non-git
+	// targets still use the git working tree for rendering state, diffs
+	// and checkpoints; only the durable store changes.
+	Target storage.SyncTarget
+}
+
+// defaultOperationTimeout bounds a sync operation when no explicit
+// timeout is configured: generous enough for a large bootstrap, but
+// finite so a stuck JIRA or git call cannot hang an operation forever.
+const defaultOperationTimeout = 2 * time.Hour
+
+// Engine drives synchronization of a single JIRA project into a git
+// repository.
+type Engine struct {
+	jira *jira.Client
+	git  *git.Manager
+	// target is the storage backend the write/delete/commit path goes
+	// through; the default wraps the git manager.
+	target      storage.SyncTarget
+	projectKeys []string
+	boardID     int
+	filterID    int
+
+	// filterMu guards the cached JQL resolved from the saved filter.
+	filterMu         syncpkg.Mutex
+	filterJQL        string
+	filterResolvedAt time.Time
+
+	batcher  *adaptiveBatcher
+	executor *TaskExecutor
+	tracker  *ProgressTracker
+
+	// partialErr records a partial multi-project failure from the last
+	// sync so Bootstrap can surface it after the surviving projects have
+	// been committed and pushed.
+	partialErr error
+
+	// bootstrapping guards against overlapping full syncs of the same
+	// resource (1 while a Bootstrap is running).
+	bootstrapping atomic.Bool
+
+	projectConcurrency int
+
+	// commitMu serializes commits across parallel project workers; the
+	// shared worktree tolerates concurrent file writes to disjoint
+	// paths but not concurrent staging.
+	commitMu syncpkg.Mutex
+
+	// Incremental-sync state: incrActive is set per run when the
+	// watermark allows an incremental scan; incrMu guards incrSeen, the
+	// keys already processed this run; watermarkMu guards watermark, the
+	// highest updated timestamp seen this run.
+	incremental bool
+	incrSince   time.Time
+	incrOverlap time.Duration
+	incrActive  bool
+	incrMu      syncpkg.Mutex
+	incrSeen    map[string]struct{}
+	watermarkMu syncpkg.Mutex
+	watermark   time.Time
+
+	// prioritize buffers project scans through a priority queue keyed
+	// on JIRA priority instead of writing issues in fetch order.
+	prioritize bool
+
+	checkpointPath    string
+	resume            bool
+	breakers          *cdcerrors.CircuitBreakerManager
+	deadLetters       *DeadLetterStore
+	retries           *cdcerrors.RetryManager
+	removedPolicy     string
+	deletionPct       int
+	maxIssues         int
+	allowLarge        bool
+	fieldMapping      []FieldMapping
+	syncChangelog     bool
+	syncWorklogs      bool
+	syncRemoteLinks   bool
+	syncAttachments   bool
+	attachConcurrency int
+	labelFilter       []string
+	componentFilter   []string
+	ignoreFields      []string
+	ignoreStatuses    []string
+	extraJQL          string
+	hooks             *hooks.Runner
+	instance          string
+	commitBatch       int
+	squashReconcile   bool
+	driftPolicy       string
+	opTimeout         time.Duration
+
+	// squashBase is the HEAD hash captured at the start of a sync cycle,
+	// marking the last commit that must survive a reconcile squash.
+	squashBase string
+
+	// discovered is the issue count the last operation's pre-flight cap
+	// check found in scope, written before any tasks start and read by
+	// the controller for status reporting.
+	discovered int
+
+	// driftMu guards drifts, recorded by the pre-sync check and read by
+	// the controller and API for reporting.
+	driftMu syncpkg.Mutex
+	drifts  []git.Drift
+
+	// projectMu guards projectResults, the per-project outcomes of the
+	// last sync cycle, read by the controller for status reporting.
+	projectMu      syncpkg.Mutex
+	projectResults map[string]ProjectResult
+
+	// syncState is the per-issue sync state loaded at the start of a
+	// full scan, used to skip issues whose JIRA revision has not moved
+	// since they were last mirrored.
+	syncState map[string]git.IssueSyncResult
+
+	// pendingMu guards pendingSync: revisions written to the tree but
+	// not yet attributed to a commit in the state file.
+	pendingMu   syncpkg.Mutex
+	pendingSync map[string]string
+
+	// Discovered sprint/epic custom field IDs, resolved once per engine.
+	// Empty when the instance has no agile fields or discovery failed.
+	agileOnce     syncpkg.Once
+	sprintFieldID string
+	epicFieldID   string
+}
+
+// ErrTooManyDeletions is returned when deletion cleanup would remove
+// more files than the configured safety threshold allows.
+var ErrTooManyDeletions = errors.New("sync: deletion threshold exceeded, aborting cleanup")
+
+// ErrTooManyIssues is returned when the pre-flight count finds more
+// issues in scope than MaxIssuesPerOperation allows.
+var ErrTooManyIssues = errors.New("sync: issue count exceeds the per-operation cap")
+
+// ErrPartialSync is returned when some projects synced successfully but
+// others failed. The successful projects are committed and pushed; the
+// overall operation should be reported as degraded rather than failed.
+var ErrPartialSync = errors.New("sync: some projects failed")
+
+// NewEngine constructs an Engine from its collaborators.
+func NewEngine(jiraClient *jira.Client, gitManager *git.Manager, opts Options) (*Engine, error) {
+	if len(opts.ProjectKeys) == 0 && opts.ProjectKey != "" {
+		opts.ProjectKeys = []string{opts.ProjectKey}
+	}
+	if len(opts.ProjectKeys) == 0 && opts.BoardID == 0 {
+		return nil, fmt.Errorf("sync: at least one project key or a board ID is required")
+	}
+	seen := make(map[string]struct{}, len(opts.ProjectKeys))
+	for _, key := range opts.ProjectKeys {
+		if _, dup := seen[key]; dup {
+			return nil, fmt.Errorf("sync: duplicate project key %q", key)
+		}
+		seen[key] = struct{}{}
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.MaxParallelism <= 0 {
+		opts.MaxParallelism = 4
+	}
+	if opts.MaxProjectConcurrency <= 0 {
+		opts.MaxProjectConcurrency = 1
+	}
+	if opts.DeletionThresholdPercent <= 0 {
+		opts.DeletionThresholdPercent = 20
+	}
+	if opts.OperationTimeout <= 0 {
+		opts.OperationTimeout = defaultOperationTimeout
+	}
+	if opts.AttachmentConcurrency <= 0 {
+		opts.AttachmentConcurrency = 4
+	}
+	if opts.IncrementalOverlap <= 0 {
+		opts.IncrementalOverlap = defaultIncrementalOverlap
+	}
+	if opts.Target == nil {
+		opts.Target = storage.NewGitTarget(gitManager)
+	}
+	tracker := NewProgressTracker()
+	return &Engine{
+		jira:        jiraClient,
+		git:         gitManager,
+		target:      opts.Target,
+		projectKeys: opts.ProjectKeys,
+		boardID:     opts.BoardID,
+		filterID:    opts.FilterID,
+		batcher:     newAdaptiveBatcher(opts.BatchSize, opts.MinBatchSize, opts.MaxBatchSize),
+		executor:    NewTaskExecutor(opts.MaxParallelism, tracker),
+		tracker:     tracker,
+
+		projectConcurrency: opts.MaxProjectConcurrency,
+
+		incremental: opts.Incremental,
+		incrSince:   opts.IncrementalSince,
+		incrOverlap: opts.IncrementalOverlap,
+		prioritize:  opts.PrioritizeByJiraPriority,
+
+		checkpointPath:    opts.CheckpointPath,
+		resume:            opts.ResumeFromCheckpoint,
+		breakers:          opts.Breakers,
+		deadLetters:       opts.DeadLetters,
+		retries:           cdcerrors.NewRetryManager(opts.Retry),
+		removedPolicy:     opts.RemovedProjectPolicy,
+		deletionPct:       opts.DeletionThresholdPercent,
+		maxIssues:         opts.MaxIssuesPerOperation,
+		allowLarge:        opts.AllowLargeSync,
+		fieldMapping:      opts.FieldMapping,
+		syncChangelog:     opts.SyncChangelog,
+		syncWorklogs:      opts.SyncWorklogs,
+		syncRemoteLinks:   opts.SyncRemoteLinks,
+		syncAttachments:   opts.SyncAttachments,
+		attachConcurrency: opts.AttachmentConcurrency,
+		labelFilter:       opts.LabelFilter,
+		componentFilter:   opts.ComponentFilter,
+		ignoreFields:      opts.IgnoreFields,
+		ignoreStatuses:    opts.IgnoreStatuses,
+		extraJQL:          opts.ExtraJQL,
+		hooks:             opts.Hooks,
+		instance:          opts.InstanceName,
+		commitBatch:       opts.CommitBatchSize,
+		squashReconcile:   opts.SquashOnReconcile,
+		driftPolicy:       opts.DriftPolicy,
+		opTimeout:         opts.OperationTimeout,
+		pendingSync:       make(map[string]string),
+	}, nil
+}
+
+// Progress returns the engine's progress tracker for callback
+// registration.
+func (e *Engine) Progress() *ProgressTracker {
+	return e.tracker
+}
+
+// Git returns the engine's repository manager, e.g. for API read access
+// to mirrored issue files.
+func (e *Engine) Git() *git.Manager {
+	return e.git
+}
+
+// Jira returns the engine's JIRA client, e.g. for API existence checks
+// before submitting targeted sync tasks.
+func (e *Engine) Jira() *jira.Client {
+	return e.jira
+}
+
+// Hooks returns the engine's post-sync hook runner, or nil when no
+// hooks are configured, e.g. for failure reporting after a cycle.
+func (e *Engine) Hooks() *hooks.Runner {
+	return e.hooks
+}
+
+// SyncInProgress reports whether a full sync is currently running.
+// Controller-scheduled reconciles call Bootstrap directly rather than
+// through the task manager, so callers gating work on "no operation in
+// flight" must check this alongside the task list.
+func (e *Engine) SyncInProgress() bool {
+	return e.bootstrapping.Load()
+}
+
+// scope describes what the engine mirrors — project keys, the agile
+// board and/or the saved filter — for task names, commit messages and
+// in-progress errors.
+func (e *Engine) scope() string {
+	parts := append([]string{}, e.projectKeys...)
+	if e.boardID != 0 {
+		parts = append(parts, fmt.Sprintf("board %d", e.boardID))
+	}
+	if e.filterID != 0 {
+		parts = append(parts, fmt.Sprintf("filter %d", e.filterID))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// execJira runs fn through the JIRA circuit breaker when one is
+// configured.
+func (e *Engine) execJira(ctx context.Context, fn func(ctx context.Context) error) error {
+	if e.breakers == nil {
+		return fn(ctx)
+	}
+	return e.breakers.Execute(ctx, cdcerrors.ComponentJira, fn)
+}
+
+// execGit runs fn through the git circuit breaker when one is
+// configured.
+func (e *Engine) execGit(ctx context.Context, fn func(ctx context.Context) error) error {
+	if e.breakers == nil {
+		return fn(ctx)
+	}
+	return e.breakers.Execute(ctx, cdcerrors.ComponentGit, fn)
+}
+
+// Bootstrap performs the initial synchronization of the configured
+// projects into the target repository. When only some projects fail,
+// the surviving ones are still committed and pushed and the error wraps
+// ErrPartialSync.
+func (e *Engine) Bootstrap(ctx context.Context) error {
+	if !e.bootstrapping.CompareAndSwap(false, true) {
+		return &ErrOperationInProgress{Key: e.scope()}
+	}
+	defer e.bootstrapping.Store(false)
+
+	e.partialErr = nil
+	e.hooks.Reset()
+	// Bound the whole operation so a stuck JIRA or git call cannot hang
+	// a sync forever: the deadline cancels every task derived from this
+	// context. Individual HTTP calls carry their own, much shorter
+	// client timeout nested inside this one.
+	ctx, cancel := context.WithTimeout(ctx, e.opTimeout)
+	defer cancel()
+	e.startRateLimitCollector(ctx)
+	if err := e.awaitRateBudget(ctx); err != nil {
+		return err
+	}
+	if err := e.checkIssueCap(ctx); err != nil {
+		return err
+	}
+	tasks := e.createBootstrapTasks(ctx)
+	if err := e.executor.ExecuteTasks(ctx, tasks); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("sync: operation timed out after %s: %w", e.opTimeout, err)
+		}
+		return err
+	}
+	// Announce the completed operation to the operation-scoped hooks.
+	// Partial failures skip the announcement; the next successful cycle
+	// covers the surviving commits.
+	if e.partialErr == nil {
+		if err := e.hooks.OperationComplete(ctx, e.git.HeadHash()); err != nil {
+			return err
+		}
+	}
+	return e.partialErr
+}
+
+// SyncIssue fetches a single issue and mirrors it into the repository,
+// committing and pushing the change. Used for webhook-triggered
+// near-real-time updates.
+func (e *Engine) SyncIssue(ctx context.Context, issueKey string) error {
+	_, err := e.syncIssue(ctx, issueKey, false)
+	return err
+}
+
+// ResyncIssue force-refreshes a single issue from JIRA, rewriting its
+// file even when no tracked field changed. It returns the resulting
+// commit hash, or an empty string when the working tree was already
+// identical.
+func (e *Engine) ResyncIssue(ctx context.Context, issueKey string) (string, error) {
+	return e.syncIssue(ctx, issueKey, true)
+}
+
+func (e *Engine) syncIssue(ctx context.Context, issueKey string, force bool) (string, error) {
+	if err := e.ensureRepository(ctx); err != nil {
+		return "", err
+	}
+	e.resolveAgileFields(ctx)
+	fetchStart := time.Now()
+	var issue *jira.Issue
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var gerr error
+		issue, gerr = e.jira.GetIssue(ctx, issueKey)
+		return gerr
+	})
+	if err != nil {
+		return "", fmt.Errorf("sync: fetch issue %s: %w", issueKey, err)
+	}
+
+	data := e.issueData(issue)
+	data.Description = e.jira.ResolveMentions(ctx, data.Description)
+	if e.statusIgnored(data.Status) {
+		// The issue is excluded from sync; its file, if any, is removed
+		// as orphaned by the next full cycle.
+		return "", nil
+	}
+	e.attachHistory(ctx, issueKey, data)
+	e.attachWorklogs(ctx, issueKey, data)
+	e.attachRemoteLinks(ctx, issueKey, data)
+	e.handleKeyRename(ctx, data)
+	// Diff against the mirrored state so the commit message records what
+	// actually changed, and skip no-op updates where only timestamps or
+	// ignored fields moved. A forced resync rewrites the file regardless.
+	old, _ := e.git.ReadIssueData(issueKey)
+	changes := git.FilterFieldChanges(git.DiffIssueData(old, data), e.ignoreFields)
+	if !force && old != nil && len(changes) == 0 {
+		return "", nil
+	}
+	if err := e.writeData(ctx, projectFromKey(issueKey), issueKey, data); err != nil {
+		return "", err
+	}
+	if err := e.downloadAttachments(ctx, projectFromKey(issueKey), issueKey, issue.Fields.Attachment); err != nil {
+		return "", err
+	}
+	operation := "create"
+	message := fmt.Sprintf("sync: update %s", issueKey)
+	switch {
+	case old != nil && len(changes) > 0:
+		operation = "update"
+		message = fmt.Sprintf("fix(%s): %s", issueKey, git.FormatFieldChanges(changes))
+	case force && old != nil:
+		operation = "force-resync"
+		message = fmt.Sprintf("sync: force resync %s", issueKey)
+	}
+	if e.git.HasCommitTemplate() {
+		message, err = e.git.RenderCommitMessage(git.CommitContext{
+			Key:           issueKey,
+			Project:       projectFromKey(issueKey),
+			Type:          strings.ToLower(issue.Fields.IssueType.Name),
+			Summary:       issue.Fields.Summary,
+			Operation:     operation,
+			Changes:       changes,
+			ChangeSummary: git.FormatFieldChanges(changes),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	message = e.git.AppendTrailers(message, issueKey)
+
+	e.notePending(issueKey, issue.Fields.Updated)
+	authorName, authorEmail := e.commitAuthor(issue)
+	var hash string
+	err = e.execGit(ctx, func(ctx context.Context) error {
+		if cerr := e.git.WriteEpicIndexes(); cerr != nil {
+			return cerr
+		}
+		h, cerr := e.git.CommitAllAs(message, authorName, authorEmail)
+		if cerr != nil {
+			return cerr
+		}
+		hash = h
+		if herr := e.recordSyncResults(ctx, h); herr != nil {
+			return herr
+		}
+		metrics.RecordIssueE2ELatency(e.instance, projectFromKey(issueKey),
+			time.Since(fetchStart), metrics.TraceIDFromContext(ctx))
+		return e.git.PushWithRetry(ctx, e.retries)
+	})
+	return hash, err
+}
+
+// SyncIssueBatch hydrates the given issues through a chunked bulk JIRA
+// search instead of one GET per issue and mirrors them as a single
+// commit. It returns the number of issues synced and any requested keys
+// JIRA no longer returned (deleted or not visible), which are skipped
+// rather than treated as a failure.
+func (e *Engine) SyncIssueBatch(ctx context.Context, keys []string) (int, []string, error) {
+	if len(keys) == 0 {
+		return 0, nil, nil
+	}
+	if err := e.ensureRepository(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	e.resolveAgileFields(ctx)
+	fields := append(jira.DefaultSearchFields(), e.extraSearchFields()...)
+	var issues []jira.Issue
+	var missing []string
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		fetched, ferr := e.jira.BulkFetch(ctx, keys, fields)
+		var missErr *jira.MissingIssuesError
+		if errors.As(ferr, &missErr) {
+			missing = missErr.Keys
+			ferr = nil
+		}
+		issues = fetched
+		return ferr
+	})
+	if err != nil {
+		return 0, missing, fmt.Errorf("sync: bulk fetch issues: %w", err)
+	}
+
+	for i := range issues {
+		issue := &issues[i]
+		if err := e.writeIssue(ctx, projectFromKey(issue.Key), issue); err != nil {
+			return 0, missing, err
+		}
+	}
+	if len(issues) == 0 {
+		return 0, missing, nil
+	}
+	message := fmt.Sprintf("sync: bulk resync %d issues", len(issues))
+	resynced := make([]string, 0, len(issues))
+	for i := range issues {
+		resynced = append(resynced, issues[i].Key)
+	}
+	message = e.git.AppendTrailers(message, resynced...)
+	if err := e.commitAndPush(ctx, message); err != nil {
+		return 0, missing, err
+	}
+	return len(issues), missing, nil
+}
+
+// DeleteIssue removes a deleted issue's file from the repository.
+func (e *Engine) DeleteIssue(ctx context.Context, issueKey string) error {
+	if err := e.ensureRepository(ctx); err != nil {
+		return err
+	}
+	removed, err := e.target.DeleteIssue(ctx, issueKey)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return nil
+	}
+	message := e.git.AppendTrailers(fmt.Sprintf("sync: remove deleted issue %s", issueKey), issueKey)
+	return e.commitAndPush(ctx, message)
+}
+
+// commitAuthor resolves the commit author for a single-issue commit
+// from the repository's author strategy. Issues without the selected
+// user, or users whose email JIRA hides, fall back to the operator
+// identity inside CommitAllAs.
+func (e *Engine) commitAuthor(issue *jira.Issue) (name, email string) {
+	var user *jira.User
+	switch e.git.AuthorStrategy() {
+	case git.AuthorReporter:
+		user = issue.Fields.Reporter
+	case git.AuthorAssignee:
+		user = issue.Fields.Assignee
+	}
+	if user == nil {
+		return "", ""
+	}
+	return user.DisplayName, user.EmailAddress
+}
+
+// ensureRepository makes sure the local clone exists and is open.
+func (e *Engine) ensureRepository(ctx context.Context) error {
+	return e.execGit(ctx, e.git.Clone)
+}
+
+// commitAndPush commits any working tree changes and pushes them,
+// refreshing epic indexes first so child moves land in the same commit.
+func (e *Engine) commitAndPush(ctx context.Context, message string) error {
+	return e.execGit(ctx, func(ctx context.Context) error {
+		if err := e.git.WriteEpicIndexes(); err != nil {
+			return err
+		}
+		hash, err := e.target.Commit(ctx, message)
+		if err != nil {
+			return err
+		}
+		if herr := e.recordSyncResults(ctx, hash); herr != nil {
+			return herr
+		}
+		return e.git.PushWithRetry(ctx, e.retries)
+	})
+}
+
+// syncIssues mirrors every configured project — and every issue on the
+// configured agile board, when one is set — into the working tree,
+// committing the result as a single batch. A project that fails does not
+// abort the others; a partial failure is recorded on the engine as
+// ErrPartialSync so the surviving projects are still pushed.
+func (e *Engine) syncIssues(ctx context.Context) error {
+	cp := e.loadResume()
+	resumed := cp != nil
+	// Incremental scans need a fresh run: a checkpoint resume encodes a
+	// key-ordered serial scan position that an updated-ordered window
+	// cannot honor, and a zero watermark means nothing to window from.
+	e.incrActive = e.incremental && !e.incrSince.IsZero() && !resumed
+	e.incrSeen = make(map[string]struct{})
+	e.watermarkMu.Lock()
+	e.watermark = time.Time{}
+	e.watermarkMu.Unlock()
+	// Mark the last commit that predates this cycle, so a reconcile
+	// squash knows where this run's commits start.
+	e.squashBase = e.git.HeadHash()
+	e.syncState = e.git.LoadSyncState(ctx)
+	if err := e.checkDrift(ctx); err != nil {
+		return err
+	}
+	// Upgrade any issue files still on an older front-matter schema, so
+	// the repo converges without waiting for every issue to be touched.
+	// Already-current files are skipped, keeping repeat cycles churn-free.
+	if _, err := e.git.MigrateRepo(ctx); err != nil {
+		return err
+	}
+	synced := 0
+	var presentKeys []string
+	perProject := make(map[string]int, len(e.projectKeys))
+	failures := make(map[string]error)
+	// A checkpoint means every project before the checkpointed one was
+	// fully synced in the interrupted run.
+	skipping := resumed
+	type projectJob struct {
+		project string
+		startAt int
+	}
+	var pending []projectJob
+	for _, project := range e.projectKeys {
+		if skipping {
+			if project != cp.ProjectKey {
+				continue
+			}
+			skipping = false
+		}
+		startAt := 0
+		if cp != nil && cp.ProjectKey == project {
+			startAt = cp.StartAt
+		}
+		pending = append(pending, projectJob{project: project, startAt: startAt})
+	}
+	// Projects run through a bounded worker pool; at concurrency 1 the
+	// pool degenerates to the serial scan. Workers write disjoint
+	// per-project paths in the shared clone and serialize commits
+	// through commitMu, so no per-worker branch or worktree is needed.
+	var mu syncpkg.Mutex
+	jobs := make([]func(), 0, len(pending))
+	for _, job := range pending {
+		job := job
+		jobs = append(jobs, func() {
+			keys, count, err := e.syncProject(ctx, job.project, job.startAt)
+			mu.Lock()
+			defer mu.Unlock()
+			presentKeys = append(presentKeys, keys...)
+			perProject[job.project] = count
+			synced += count
+			if err != nil && ctx.Err() == nil {
+				failures[job.project] = err
+			}
+		})
+	}
+	runWorkers(e.projectConcurrency, jobs)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if e.boardID != 0 {
+		keys, counts, err := e.syncBoard(ctx)
+		presentKeys = append(presentKeys, keys...)
+		for project, count := range counts {
+			perProject[project] += count
+			synced += count
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			failures[fmt.Sprintf("board %d", e.boardID)] = err
+		}
+	}
+	if e.filterID != 0 {
+		keys, counts, err := e.syncFilter(ctx)
+		presentKeys = append(presentKeys, keys...)
+		for project, count := range counts {
+			perProject[project] += count
+			synced += count
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			failures[fmt.Sprintf("filter %d", e.filterID)] = err
+		}
+	}
+	e.tracker.SetProjectCounts(perProject)
+	e.recordProjectResults(perProject, failures)
+
+	targets := len(e.projectKeys)
+	if e.boardID != 0 {
+		targets++
+	}
+	if e.filterID != 0 {
+		targets++
+	}
+	if len(failures) > 0 && len(failures) == targets {
+		return fmt.Errorf("sync: all projects failed: %v", failures)
+	}
+
+	if err := e.git.WriteEpicIndexes(); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("sync: mirror %d issues from %s", synced, e.scope())
+	hash, err := e.target.Commit(ctx, message)
+	if err != nil {
+		return err
+	}
+	if err := e.recordSyncResults(ctx, hash); err != nil {
+		return err
+	}
+	// Cleanup needs a complete key set: skip it after a resumed partial
+	// scan or when any project failed mid-way, rather than treating
+	// unseen issues as deleted.
+	// Incremental scans return only recently updated keys, so unseen
+	// issues must not be treated as deleted; cleanup waits for the next
+	// full cycle.
+	if !resumed && len(failures) == 0 && !e.incrActive {
+		// Handle whole removed projects before orphan cleanup, so an
+		// archived project's files are moved rather than counted (and
+		// possibly deleted) as orphans.
+		if err := e.cleanupRemovedProjects(ctx); err != nil {
+			return err
+		}
+		if err := e.cleanupDeletedIssues(ctx, presentKeys); err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		e.partialErr = fmt.Errorf("%w: %v", ErrPartialSync, failures)
+		return nil
+	}
+	if e.checkpointPath != "" {
+		return removeCheckpoint(e.checkpointPath)
+	}
+	return nil
+}
+
+// syncProject pages through one project's issues, returning the keys
+// seen and the number of issues written. With prioritization enabled
+// the scan is buffered through a priority queue and written most
+// urgent first; otherwise issues are written in the order JIRA returns
+// them.
+func (e *Engine) syncProject(ctx context.Context, project string, startAt int) ([]string, int, error) {
+	jql := e.searchJQL(project)
+	if e.incrActive {
+		jql = e.incrementalJQL(project)
+	}
+	e.resolveAgileFields(ctx)
+	e.writeProjectMeta(ctx, project)
+	synced := 0
+	var lastKey string
+	var presentKeys []string
+	var commitBatch []string
+	// Commit whatever the batch has accumulated before any return, so a
+	// mid-batch failure never leaves written-but-uncommitted files for
+	// the next reconcile to untangle.
+	defer func() { _ = e.flushCommitBatch(ctx, commitBatch) }()
+	// write mirrors one issue, applying the per-issue skip rules and
+	// maintaining the commit batch. Shared by the in-order path and the
+	// prioritized drain below.
+	write := func(issue *jira.Issue) error {
+		if e.deadLetters != nil && e.deadLetters.IsDead(project, issue.Key) {
+			return nil
+		}
+		if e.unchangedSinceLastSync(issue) {
+			return nil
+		}
+		if err := e.writeIssue(ctx, project, issue); err != nil {
+			return err
+		}
+		lastKey = issue.Key
+		synced++
+		if e.commitBatch > 0 {
+			commitBatch = append(commitBatch, issue.Key)
+			if len(commitBatch) >= e.commitBatch {
+				err := e.flushCommitBatch(ctx, commitBatch)
+				commitBatch = nil
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	var queue *issueQueue
+	if e.prioritize {
+		queue = newIssueQueue()
+	}
+	for {
+		batchSize := e.batcher.current()
+		var page *jira.SearchResult
+		err := e.execJira(ctx, func(ctx context.Context) error {
+			var serr error
+			page, serr = e.jira.SearchIssues(ctx, jql, startAt, batchSize,
+				e.extraSearchFields()...)
+			return serr
+		})
+		if err != nil {
+			return presentKeys, synced, fmt.Errorf("sync: search issues for %s: %w", project, err)
+		}
+		for i := range page.Issues {
+			if err := ctx.Err(); err != nil {
+				return presentKeys, synced, err
+			}
+			issue := &page.Issues[i]
+			e.noteUpdated(issue)
+			presentKeys = append(presentKeys, issue.Key)
+			if e.incrActive && !e.markProcessed(issue.Key) {
+				continue
+			}
+			if queue != nil {
+				queue.push(issue)
+				continue
+			}
+			if err := write(issue); err != nil {
+				return presentKeys, synced, err
+			}
+		}
+		startAt += len(page.Issues)
+		// Prioritized scans are not checkpointed: writes happen after
+		// the fetch, so a fetch-position checkpoint would claim issues
+		// that were never written. An interrupted run rescans from the
+		// start, like a board scan.
+		if queue == nil {
+			e.saveCheckpoint(project, lastKey, startAt)
+		}
+		// Adapt the page size to observed rate limiting before the next
+		// page.
+		size := e.batcher.observe(e.jira.RateLimitStats())
+		e.tracker.SetBatchSize(size)
+		metrics.SetSyncBatchSize(project, size)
+		if startAt >= page.Total || len(page.Issues) == 0 {
+			break
+		}
+	}
+	for queue != nil && queue.len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return presentKeys, synced, err
+		}
+		if err := write(queue.pop()); err != nil {
+			return presentKeys, synced, err
+		}
+	}
+	return presentKeys, synced, nil
+}
+
+// syncBoard pages through every issue on the configured agile board,
+// returning the keys seen and per-project issue counts. Boards select
+// issues by filter and can span several projects, so counts are keyed
+// by each issue's own project rather than a configured one. Board scans
+// are not checkpointed; an interrupted run rescans from the start.
+func (e *Engine) syncBoard(ctx context.Context) ([]string, map[string]int, error) {
+	e.resolveAgileFields(ctx)
+	counts := make(map[string]int)
+	var presentKeys []string
+	var commitBatch []string
+	// Commit whatever the batch has accumulated before any return, so a
+	// mid-batch failure never leaves written-but-uncommitted files for
+	// the next reconcile to untangle.
+	defer func() { _ = e.flushCommitBatch(ctx, commitBatch) }()
+	startAt := 0
+	for {
+		batchSize := e.batcher.current()
+		var page *jira.SearchResult
+		err := e.execJira(ctx, func(ctx context.Context) error {
+			var serr error
+			page, serr = e.jira.GetBoardIssues(ctx, e.boardID, startAt, batchSize,
+				e.extraSearchFields()...)
+			return serr
+		})
+		if err != nil {
+			return presentKeys, counts, fmt.Errorf("sync: list issues for board %d: %w", e.boardID, err)
+		}
+		for i := range page.Issues {
+			if err := ctx.Err(); err != nil {
+				return presentKeys, counts, err
+			}
+			issue := &page.Issues[i]
+			e.noteUpdated(issue)
+			project := projectFromKey(issue.Key)
+			// Board filters cannot express status exclusion, so ignored
+			// statuses are dropped here; leaving them out of presentKeys
+			// removes their files as orphaned.
+			if e.statusIgnored(issue.Fields.Status.Name) {
+				continue
+			}
+			presentKeys = append(presentKeys, issue.Key)
+			if e.incrActive && !e.markProcessed(issue.Key) {
+				continue
+			}
+			if e.deadLetters != nil && e.deadLetters.IsDead(project, issue.Key) {
+				continue
+			}
+			if e.unchangedSinceLastSync(issue) {
+				continue
+			}
+			if err := e.writeIssue(ctx, project, issue); err != nil {
+				return presentKeys, counts, err
+			}
+			counts[project]++
+			if e.commitBatch > 0 {
+				commitBatch = append(commitBatch, issue.Key)
+				if len(commitBatch) >= e.commitBatch {
+					if err := e.flushCommitBatch(ctx, commitBatch); err != nil {
+						commitBatch = nil
+						return presentKeys, counts, err
+					}
+					commitBatch = nil
+				}
+			}
+		}
+		startAt += len(page.Issues)
+		// Adapt the page size to observed rate limiting before the next
+		// page.
+		size := e.batcher.observe(e.jira.RateLimitStats())
+		e.tracker.SetBatchSize(size)
+		if startAt >= page.Total || len(page.Issues) == 0 {
+			return presentKeys, counts, nil
+		}
+	}
+}
+
+// flushCommitBatch commits the accumulated issue writes under one
+// summarized message, e.g. "sync: update 50 issues (TEST-1..TEST-50)".
+func (e *Engine) flushCommitBatch(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("sync: update %d issues (%s..%s)", len(keys), keys[0], keys[len(keys)-1])
+	if len(keys) == 1 {
+		message = fmt.Sprintf("sync: update %s", keys[0])
+	}
+	message = e.git.AppendTrailers(message, keys...)
+	e.commitMu.Lock()
+	defer e.commitMu.Unlock()
+	hash, err := e.target.Commit(ctx, message)
+	if err != nil {
+		return err
+	}
+	return e.recordSyncResults(ctx, hash)
+}
+
+// squashCycleCommits collapses the commits the just-finished sync cycle
+// produced into one summarized commit, when squashing is enabled. A
+// cycle against a still-empty repository has no base commit and is left
+// as-is.
+func (e *Engine) squashCycleCommits(ctx context.Context) error {
+	if !e.squashReconcile || e.squashBase == "" {
+		return nil
+	}
+	message := fmt.Sprintf("sync: reconcile %s", e.scope())
+	return e.git.SquashSince(ctx, e.squashBase, message)
+}
+
+// searchJQL builds the JQL query scoping a full project scan.
+func (e *Engine) searchJQL(project string) string {
+	return strings.Join(e.searchClauses(project), " AND ") + " ORDER BY key ASC"
+}
+
+// searchClauses builds the JQL clauses scoping a project sync. Label
+// and component filters and ignored statuses are ANDed onto the project
+// clause, as is any extra user-supplied query, so filtered-out issues
+// drop out of the result set and are cleaned up as orphaned.
+func (e *Engine) searchClauses(project string) []string {
+	clauses := []string{fmt.Sprintf("project = %s", project)}
+	if len(e.labelFilter) > 0 {
+		clauses = append(clauses, fmt.Sprintf("labels in (%s)", quoteJQLValues(e.labelFilter)))
+	}
+	if len(e.componentFilter) > 0 {
+		clauses = append(clauses, fmt.Sprintf("component in (%s)", quoteJQLValues(e.componentFilter)))
+	}
+	if len(e.ignoreStatuses) > 0 {
+		clauses = append(clauses, fmt.Sprintf("status not in (%s)", quoteJQLValues(e.ignoreStatuses)))
+	}
+	if e.extraJQL != "" {
+		clauses = append(clauses, "("+e.extraJQL+")")
+	}
+	return clauses
+}
+
+// statusIgnored reports whether issues in the given status are excluded
+// from sync via spec.sync.ignoreStatuses. JQL status matching is
+// case-insensitive, so the in-process check is too.
+func (e *Engine) statusIgnored(status string) bool {
+	for _, ignored := range e.ignoreStatuses {
+		if strings.EqualFold(ignored, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteJQLValues renders a quoted, comma-separated JQL value list.
+func quoteJQLValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = `"` + v + `"`
+	}
+	return strings.Join(quoted, ",")
+}
+
+// cleanupDeletedIssues removes files for issues that no longer exist in
+// JIRA. If the deletion would exceed the configured percentage of
+// mirrored files, it aborts with ErrTooManyDeletions instead: a sudden
+// mass deletion is far more likely a JIRA API hiccup than reality.
+func (e *Engine) cleanupDeletedIssues(ctx context.Context, presentKeys []string) error {
+	if len(presentKeys) == 0 {
+		return nil
+	}
+	orphans, err := e.git.DetectOrphanedFiles(ctx, presentKeys)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	total := len(presentKeys) + len(orphans)
+	if pct := len(orphans) * 100 / total; pct > e.deletionPct {
+		return fmt.Errorf("%w: %d of %d files (%d%% > %d%%)",
+			ErrTooManyDeletions, len(orphans), total, pct, e.deletionPct)
+	}
+
+	for _, key := range orphans {
+		if _, err := e.target.DeleteIssue(ctx, key); err != nil {
+			return err
+		}
+	}
+	_, err = e.target.Commit(ctx, "chore: remove deleted issues")
+	return err
+}
+
+// writeIssue writes one issue file with retries. Issues that fail with
+// a classified non-transient error are dead-lettered and skipped rather
+// than failing the whole operation; transient errors propagate so the
+// cycle is retried later.
+func (e *Engine) writeIssue(ctx context.Context, project string, issue *jira.Issue) error {
+	// Project scans exclude ignored statuses in JQL; this guards the
+	// board and bulk-resync paths, which select issues by other means.
+	if e.statusIgnored(issue.Fields.Status.Name) {
+		return nil
+	}
+	data := e.issueData(issue)
+	data.Description = e.jira.ResolveMentions(ctx, data.Description)
+	e.attachHistory(ctx, issue.Key, data)
+	e.attachWorklogs(ctx, issue.Key, data)
+	e.attachRemoteLinks(ctx, issue.Key, data)
+	e.handleKeyRename(ctx, data)
+	// When fields are excluded from change detection, skip the rewrite
+	// if everything that changed is ignored. The new revision is still
+	// recorded so later scans skip the issue outright.
+	if len(e.ignoreFields) > 0 {
+		if old, _ := e.git.ReadIssueData(issue.Key); old != nil {
+			raw := git.DiffIssueData(old, data)
+			if len(raw) > 0 && len(git.FilterFieldChanges(raw, e.ignoreFields)) == 0 {
+				e.notePending(issue.Key, issue.Fields.Updated)
+				return nil
+			}
+		}
+	}
+	if err := e.writeData(ctx, project, issue.Key, data); err != nil {
+		return err
+	}
+	if err := e.downloadAttachments(ctx, project, issue.Key, issue.Fields.Attachment); err != nil {
+		return err
+	}
+	e.notePending(issue.Key, issue.Fields.Updated)
+	return nil
+}
+
+// checkDrift runs the pre-sync drift check, recording manually edited
+// managed files for reporting and applying the configured policy:
+// overwrite drops drifted issues from the loaded sync state so they are
+// re-synced even if JIRA has not changed, skip marks them so the scan
+// leaves them untouched, and fail aborts the cycle.
+func (e *Engine) checkDrift(ctx context.Context) error {
+	drifts := e.git.DetectDrift(ctx)
+	e.driftMu.Lock()
+	e.drifts = drifts
+	e.driftMu.Unlock()
+	if len(drifts) == 0 {
+		return nil
+	}
+	switch e.driftPolicy {
+	case git.DriftFail:
+		keys := make([]string, 0, len(drifts))
+		for _, d := range drifts {
+			keys = append(keys, d.Key)
+		}
+		return cdcerrors.NonRetryable(fmt.Errorf(
+			"sync: manual edits detected in %d managed files (%s); revert them or relax spec.gitRepository.driftPolicy",
+			len(drifts), strings.Join(keys, ", ")))
+	case git.DriftSkip:
+		// Leave the drifted files alone: keep their recorded state so
+		// the unchanged-skip applies, and mark them so scans skip them
+		// even when JIRA has newer revisions.
+		for _, d := range drifts {
+			result := e.syncState[d.Key]
+			result.Revision = driftSkipRevision
+			e.syncState[d.Key] = result
+		}
+	default:
+		// Overwrite: forget the recorded state so the drifted issues
+		// are rewritten from JIRA this cycle.
+		for _, d := range drifts {
+			delete(e.syncState, d.Key)
+		}
+	}
+	return nil
+}
+
+// driftSkipRevision marks a sync-state entry whose file drifted under
+// the skip policy, so scans treat it as unchanged regardless of JIRA's
+// revision.
+const driftSkipRevision = "\x00drift-skip"
+
+// Drifts returns the manual edits found by the last pre-sync drift
+// check.
+func (e *Engine) Drifts() []git.Drift {
+	e.driftMu.Lock()
+	defer e.driftMu.Unlock()
+	return append([]git.Drift(nil), e.drifts...)
+}
+
+// unchangedSinceLastSync reports whether an issue's JIRA revision still
+// matches the recorded sync state and its file is present, in which
+// case a full scan can skip it even though the JQL returned it.
+func (e *Engine) unchangedSinceLastSync(issue *jira.Issue) bool {
+	prev, ok := e.syncState[issue.Key]
+	if !ok {
+		return false
+	}
+	if prev.Revision == driftSkipRevision {
+		return true
+	}
+	return issue.Fields.Updated != "" && prev.Revision == issue.Fields.Updated &&
+		e.git.HasIssueFile(issue.Key)
+}
+
+// notePending records an issue's revision for attribution to the next
+// commit in the sync state file.
+func (e *Engine) notePending(key, revision string) {
+	e.pendingMu.Lock()
+	defer e.pendingMu.Unlock()
+	e.pendingSync[key] = revision
+}
+
+// recordSyncResults attributes every pending issue revision to the
+// given commit in the repository's sync state, then delivers the
+// committed issues to the issue-scoped post-sync hooks. The state is an
+// optimization, so recording failures are dropped rather than failing
+// the sync that produced the commit; a hook failure is only returned
+// when the hook runner requires it.
+func (e *Engine) recordSyncResults(ctx context.Context, hash string) error {
+	e.pendingMu.Lock()
+	pending := e.pendingSync
+	e.pendingSync = make(map[string]string, len(pending))
+	e.pendingMu.Unlock()
+	_ = e.git.RecordSyncResults(hash, pending)
+	if hash == "" {
+		// Nothing was committed: either the tree was already identical
+		// or the backend has no grouping concept worth announcing.
+		return nil
+	}
+	for key := range pending {
+		if err := e.hooks.IssueSynced(ctx, projectFromKey(key), key, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleKeyRename detects issues that were renamed by a project move
+// since the last sync. When no file exists for the issue's current key,
+// its prior keys are checked and any file still under an old key is
+// moved so git records a rename and history follows the issue. Prior
+// keys come from the already fetched changelog when changelog sync is
+// on; otherwise detection costs one changelog fetch per previously
+// unseen issue. An issue with no prior keys, or whose old file was
+// never synced, is simply created fresh.
+func (e *Engine) handleKeyRename(ctx context.Context, data *git.IssueData) {
+	if e.git.HasIssueFile(data.Key) {
+		return
+	}
+	prior := priorKeys(data.History)
+	if !e.syncChangelog {
+		err := e.execJira(ctx, func(ctx context.Context) error {
+			var gerr error
+			prior, gerr = e.jira.GetIssueKeyHistory(ctx, data.Key)
+			return gerr
+		})
+		if err != nil {
+			return
+		}
+	}
+	for i := len(prior) - 1; i >= 0; i-- {
+		if moved, err := e.git.RenameIssue(prior[i], data); err != nil || moved {
+			return
+		}
+	}
+}
+
+// priorKeys extracts an issue's previous keys, oldest first, from its
+// rendered history.
+func priorKeys(history []git.HistoryEntry) []string {
+	var keys []string
+	for _, entry := range history {
+		if entry.Field == "Key" && entry.From != "" {
+			keys = append(keys, entry.From)
+		}
+	}
+	return keys
+}
+
+// attachHistory fetches the issue's changelog when changelog sync is
+// enabled. A changelog the credentials cannot read produces a note in
+// place of the table; other fetch failures omit the section rather than
+// failing the whole issue.
+func (e *Engine) attachHistory(ctx context.Context, issueKey string, data *git.IssueData) {
+	if !e.syncChangelog {
+		return
+	}
+	var entries []jira.ChangelogEntry
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var gerr error
+		entries, gerr = e.jira.GetChangelog(ctx, issueKey)
+		return gerr
+	})
+	if err != nil {
+		var apiErr *jira.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+			data.HistoryNote = "History unavailable: insufficient permissions."
+		}
+		return
+	}
+	for _, entry := range entries {
+		data.History = append(data.History, git.HistoryEntry(entry))
+	}
+}
+
+// attachWorklogs fetches the issue's worklog entries when worklog sync
+// is enabled. Fetch failures, including projects with time tracking
+// disabled, omit the section rather than failing the whole issue.
+func (e *Engine) attachWorklogs(ctx context.Context, issueKey string, data *git.IssueData) {
+	if !e.syncWorklogs {
+		return
+	}
+	var worklogs []jira.Worklog
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var gerr error
+		worklogs, gerr = e.jira.GetWorklogs(ctx, issueKey)
+		return gerr
+	})
+	if err != nil {
+		return
+	}
+	for _, wl := range worklogs {
+		data.Worklogs = append(data.Worklogs, git.WorklogEntry{
+			Author:    wl.Author,
+			TimeSpent: wl.TimeSpent,
+			Started:   wl.Started,
+		})
+	}
+}
+
+// attachRemoteLinks fetches the issue's remote links when remote link
+// sync is enabled. Fetch failures, including instances with remote
+// linking disabled or links the credentials cannot read, omit the
+// section rather than failing the whole issue.
+func (e *Engine) attachRemoteLinks(ctx context.Context, issueKey string, data *git.IssueData) {
+	if !e.syncRemoteLinks {
+		return
+	}
+	var links []jira.RemoteLink
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var gerr error
+		links, gerr = e.jira.GetRemoteLinks(ctx, issueKey)
+		return gerr
+	})
+	if err != nil {
+		return
+	}
+	for _, link := range links {
+		data.RemoteLinks = append(data.RemoteLinks, git.RemoteLinkEntry(link))
+	}
+}
+
+// issueData maps an issue onto its git representation, including any
+// mapped custom fields and sprint/epic context.
+func (e *Engine) issueData(issue *jira.Issue) *git.IssueData {
+	data := issueToData(issue)
+	data.Custom = e.customFields(issue)
+	if e.sprintFieldID != "" {
+		data.Sprint = jira.SprintNameFromField(issue.Fields.Custom[e.sprintFieldID])
+	}
+	if e.epicFieldID != "" {
+		data.Epic = jira.EpicKeyFromField(issue.Fields.Custom[e.epicFieldID])
+	}
+	return data
+}
+
+// resolveAgileFields discovers the instance's sprint and epic link
+// custom field IDs once per engine. Discovery failures leave sprint and
+// epic context off rather than failing the sync; issues without a
+// sprint or epic simply omit those front-matter keys.
+func (e *Engine) resolveAgileFields(ctx context.Context) {
+	e.agileOnce.Do(func() {
+		sprintID, epicID, err := e.jira.AgileFieldIDs(ctx)
+		if err != nil {
+			return
+		}
+		e.sprintFieldID = sprintID
+		e.epicFieldID = epicID
+	})
+}
+
+// agileFieldIDs lists the discovered sprint/epic field IDs to request
+// from JIRA alongside the default field set.
+func (e *Engine) agileFieldIDs() []string {
+	var ids []string
+	if e.sprintFieldID != "" {
+		ids = append(ids, e.sprintFieldID)
+	}
+	if e.epicFieldID != "" {
+		ids = append(ids, e.epicFieldID)
+	}
+	return ids
+}
+
+// extraSearchFields lists the field IDs to request beyond the default
+// search set: mapped custom fields, discovered agile fields, and time
+// tracking when worklog sync is enabled.
+func (e *Engine) extraSearchFields() []string {
+	fields := append(e.mappedFieldIDs(), e.agileFieldIDs()...)
+	if e.syncWorklogs {
+		fields = append(fields, "timetracking")
+	}
+	if e.syncAttachments {
+		fields = append(fields, "attachment")
+	}
+	return fields
+}
+
+func (e *Engine) writeData(ctx context.Context, project, issueKey string, data *git.IssueData) error {
+	err := e.retries.Do(ctx, func(ctx context.Context) error {
+		return e.target.WriteIssue(ctx, data)
+	})
+	if err == nil {
+		return nil
+	}
+	if class := cdcerrors.Classify(err); !class.IsRetryable() && e.deadLetters != nil {
+		e.deadLetters.Record(project, issueKey, class, err, e.retries.Attempts())
+		return nil
+	}
+	return fmt.Errorf("sync: write issue %s: %w", issueKey, err)
+}
+
+// loadResume returns the checkpoint to resume from, or nil for a full
+// scan. The checkpoint is validated against the configured projects and
+// the working tree: if the last checkpointed issue is not actually
+// present in git (for example because result ordering changed between
+// runs), the checkpoint is discarded and a full scan is performed.
+func (e *Engine) loadResume() *Checkpoint {
+	if !e.resume || e.checkpointPath == "" {
+		return nil
+	}
+	cp, err := loadCheckpoint(e.checkpointPath)
+	if err != nil || cp == nil {
+		return nil
+	}
+	known := false
+	for _, key := range e.projectKeys {
+		if key == cp.ProjectKey {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil
+	}
+	if cp.LastIssueKey == "" || !e.git.HasIssueFile(cp.LastIssueKey) {
+		// Inconsistent with the repository contents; fall back to a
+		// full scan rather than trusting a stale offset.
+		_ = removeCheckpoint(e.checkpointPath)
+		return nil
+	}
+	return cp
+}
+
+// saveCheckpoint persists progress if checkpointing is configured.
+// Parallel project workers complete in no particular order, so above
+// concurrency 1 there is no serial scan position worth persisting.
+func (e *Engine) saveCheckpoint(project, lastKey string, startAt int) {
+	if e.checkpointPath == "" || lastKey == "" || e.projectConcurrency > 1 {
+		return
+	}
+	cp := &Checkpoint{
+		ProjectKey:   project,
+		LastIssueKey: lastKey,
+		StartAt:      startAt,
+	}
+	// Checkpoint persistence is best-effort; a failed write only costs
+	// resume granularity.
+	_ = cp.save(e.checkpointPath)
+}
+
+// issueToData maps a JIRA issue onto its git file representation.
+func issueToData(issue *jira.Issue) *git.IssueData {
+	data := &git.IssueData{
+		Key:            issue.Key,
+		Project:        projectFromKey(issue.Key),
+		Summary:        issue.Fields.Summary,
+		Description:    issue.Fields.Description,
+		IssueType:      issue.Fields.IssueType.Name,
+		Status:         issue.Fields.Status.Name,
+		StatusCategory: issue.Fields.Status.StatusCategory.Key,
+		Labels:         issue.Fields.Labels,
+		Created:        issue.Fields.Created,
+		Updated:        issue.Fields.Updated,
+	}
+	if issue.Fields.Priority != nil {
+		data.Priority = issue.Fields.Priority.Name
+	}
+	if issue.Fields.Assignee != nil {
+		data.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	if issue.Fields.Reporter != nil {
+		data.Reporter = issue.Fields.Reporter.DisplayName
+	}
+	for _, c := range issue.Fields.Components {
+		data.Components = append(data.Components, c.Name)
+	}
+	for _, link := range jira.FlattenIssueLinks(issue.Fields.IssueLinks) {
+		data.Links = append(data.Links, git.IssueLink{Type: link.Type, Key: link.Key})
+	}
+	for _, sub := range issue.Fields.Subtasks {
+		data.Subtasks = append(data.Subtasks, git.SubtaskRef{Key: sub.Key})
+	}
+	if tt := issue.Fields.TimeTracking; tt != nil {
+		data.TimeSpent = tt.TimeSpent
+		data.RemainingEstimate = tt.RemainingEstimate
+	}
+	return data
+}
+
+// projectFromKey derives the project key from an issue key like
+// "PROJ-123".
+func projectFromKey(issueKey string) string {
+	if idx := strings.LastIndex(issueKey, "-"); idx > 0 {
+		return issueKey[:idx]
+	}
+	return issueKey
+}
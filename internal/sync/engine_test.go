@@ -0,0 +1,24 @@
+package sync
+
+import "testing"
+
+// TestSyncInProgress verifies the in-flight flag Bootstrap maintains is
+// visible through the exported accessor, which gates maintenance and
+// deletion on syncs the controller runs outside the task manager.
+func TestSyncInProgress(t *testing.T) {
+	engine, err := NewEngine(nil, nil, Options{ProjectKeys: []string{"TEST"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engine.SyncInProgress() {
+		t.Error("idle engine reports a sync in progress")
+	}
+	engine.bootstrapping.Store(true)
+	if !engine.SyncInProgress() {
+		t.Error("mid-sync engine reports idle")
+	}
+	engine.bootstrapping.Store(false)
+	if engine.SyncInProgress() {
+		t.Error("finished engine still reports a sync in progress")
+	}
+}
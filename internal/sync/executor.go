@@ -0,0 +1,172 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TaskExecutor runs a task DAG, executing independent tasks concurrently
+// while honouring Dependencies ordering.
+type TaskExecutor struct {
+	// MaxParallelism bounds the number of tasks running at once.
+	// Values <= 0 mean unbounded.
+	MaxParallelism int
+
+	tracker *ProgressTracker
+}
+
+// NewTaskExecutor returns an executor reporting progress to tracker.
+func NewTaskExecutor(maxParallelism int, tracker *ProgressTracker) *TaskExecutor {
+	if tracker == nil {
+		tracker = NewProgressTracker()
+	}
+	return &TaskExecutor{MaxParallelism: maxParallelism, tracker: tracker}
+}
+
+// ExecuteTasks runs tasks respecting their dependency DAG. Tasks with no
+// unfinished prerequisites run concurrently, up to MaxParallelism at a
+// time. When a prerequisite fails (or is itself skipped), every
+// transitive dependent is marked skipped with a reason instead of
+// running. ExecuteTasks returns an error if the graph references unknown
+// task IDs or contains a cycle, or if any task failed.
+func (e *TaskExecutor) ExecuteTasks(ctx context.Context, tasks []*Task) error {
+	byID := make(map[string]*Task, len(tasks))
+	for _, t := range tasks {
+		if _, dup := byID[t.ID]; dup {
+			return fmt.Errorf("sync: duplicate task ID %q", t.ID)
+		}
+		byID[t.ID] = t
+	}
+	for _, t := range tasks {
+		for _, dep := range t.Dependencies {
+			if _, ok := byID[dep]; !ok {
+				return fmt.Errorf("sync: task %q depends on unknown task %q", t.ID, dep)
+			}
+		}
+	}
+	if err := detectCycles(tasks); err != nil {
+		return err
+	}
+
+	e.tracker.SetTotal(len(tasks))
+
+	// done is closed per-task once the task reaches a terminal state, so
+	// dependents can wait without polling.
+	done := make(map[string]chan struct{}, len(tasks))
+	for _, t := range tasks {
+		done[t.ID] = make(chan struct{})
+	}
+
+	var sem chan struct{}
+	if e.MaxParallelism > 0 {
+		sem = make(chan struct{}, e.MaxParallelism)
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range tasks {
+		t := t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[t.ID])
+
+			// Wait for every prerequisite to settle.
+			for _, dep := range t.Dependencies {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					t.setSkipped(fmt.Sprintf("context cancelled: %v", ctx.Err()))
+					e.tracker.Update(t.ID, TaskSkipped, t.SkipReason())
+					return
+				}
+			}
+			for _, dep := range t.Dependencies {
+				if status := byID[dep].Status(); status != TaskCompleted {
+					reason := fmt.Sprintf("prerequisite %q %s", dep, status)
+					if err := byID[dep].Err(); err != nil {
+						reason = fmt.Sprintf("prerequisite %q failed: %v", dep, err)
+					}
+					t.setSkipped(reason)
+					e.tracker.Update(t.ID, TaskSkipped, reason)
+					return
+				}
+			}
+
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					t.setSkipped(fmt.Sprintf("context cancelled: %v", ctx.Err()))
+					e.tracker.Update(t.ID, TaskSkipped, t.SkipReason())
+					return
+				}
+			}
+
+			t.setStatus(TaskRunning)
+			e.tracker.Update(t.ID, TaskRunning, t.Name)
+			if err := t.Run(ctx); err != nil {
+				t.setFailed(err)
+				e.tracker.Update(t.ID, TaskFailed, err.Error())
+				return
+			}
+			t.setStatus(TaskCompleted)
+			e.tracker.Update(t.ID, TaskCompleted, t.Name)
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, t := range tasks {
+		if t.Status() == TaskFailed {
+			failed = append(failed, fmt.Sprintf("%s: %v", t.ID, t.Err()))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("sync: %d task(s) failed: %v", len(failed), failed)
+	}
+	return ctx.Err()
+}
+
+// detectCycles returns an error naming a task involved in a dependency
+// cycle, using depth-first search with three-colour marking.
+func detectCycles(tasks []*Task) error {
+	deps := make(map[string][]string, len(tasks))
+	for _, t := range tasks {
+		deps[t.ID] = t.Dependencies
+	}
+
+	const (
+		white = 0 // unvisited
+		grey  = 1 // on the current path
+		black = 2 // fully explored
+	)
+	colour := make(map[string]int, len(tasks))
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		colour[id] = grey
+		for _, dep := range deps[id] {
+			switch colour[dep] {
+			case grey:
+				return fmt.Errorf("sync: dependency cycle detected involving task %q", dep)
+			case white:
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		colour[id] = black
+		return nil
+	}
+
+	for _, t := range tasks {
+		if colour[t.ID] == white {
+			if err := visit(t.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	gosync "sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestExecuteTasksRejectsBadGraphs verifies duplicate IDs, unknown
+// dependencies, and cycles fail before anything runs.
+func TestExecuteTasksRejectsBadGraphs(t *testing.T) {
+	var ran atomic.Int32
+	run := func(ctx context.Context) error {
+		ran.Add(1)
+		return nil
+	}
+	cases := []struct {
+		name  string
+		tasks []*Task
+		want  string
+	}{
+		{
+			"duplicate id",
+			[]*Task{{ID: "a", Run: run}, {ID: "a", Run: run}},
+			"duplicate task ID",
+		},
+		{
+			"unknown dependency",
+			[]*Task{{ID: "a", Dependencies: []string{"ghost"}, Run: run}},
+			"unknown task",
+		},
+		{
+			"two-task cycle",
+			[]*Task{
+				{ID: "a", Dependencies: []string{"b"}, Run: run},
+				{ID: "b", Dependencies: []string{"a"}, Run: run},
+			},
+			"cycle",
+		},
+		{
+			"self cycle",
+			[]*Task{{ID: "a", Dependencies: []string{"a"}, Run: run}},
+			"cycle",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewTaskExecutor(2, nil).ExecuteTasks(context.Background(), tc.tasks)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %v, want one containing %q", err, tc.want)
+			}
+		})
+	}
+	if n := ran.Load(); n != 0 {
+		t.Errorf("%d tasks ran despite a rejected graph", n)
+	}
+}
+
+// TestExecuteTasksOrdersDependencies verifies a task never starts
+// before its prerequisites complete.
+func TestExecuteTasksOrdersDependencies(t *testing.T) {
+	var mu gosync.Mutex
+	var order []string
+	record := func(id string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return nil
+		}
+	}
+	tasks := []*Task{
+		{ID: "fetch", Run: record("fetch")},
+		{ID: "write", Dependencies: []string{"fetch"}, Run: record("write")},
+		{ID: "push", Dependencies: []string{"write"}, Run: record("push")},
+	}
+	if err := NewTaskExecutor(4, nil).ExecuteTasks(context.Background(), tasks); err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 3 || order[0] != "fetch" || order[1] != "write" || order[2] != "push" {
+		t.Errorf("tasks ran in order %v, want [fetch write push]", order)
+	}
+}
+
+// TestExecuteTasksSkipsTransitively verifies a failure skips every
+// transitive dependent, the skip reasons name the failed prerequisite,
+// and unrelated tasks still run.
+func TestExecuteTasksSkipsTransitively(t *testing.T) {
+	boom := errors.New("boom")
+	var unrelated atomic.Bool
+	tasks := []*Task{
+		{ID: "a", Run: func(ctx context.Context) error { return boom }},
+		{ID: "b", Dependencies: []string{"a"}, Run: func(ctx context.Context) error { return nil }},
+		{ID: "c", Dependencies: []string{"b"}, Run: func(ctx context.Context) error { return nil }},
+		{ID: "d", Run: func(ctx context.Context) error { unrelated.Store(true); return nil }},
+	}
+	err := NewTaskExecutor(4, nil).ExecuteTasks(context.Background(), tasks)
+	if err == nil || !strings.Contains(err.Error(), "a: boom") {
+		t.Fatalf("got error %v, want one naming the failed task", err)
+	}
+	if got := tasks[1].Status(); got != TaskSkipped {
+		t.Errorf("direct dependent status %s, want %s", got, TaskSkipped)
+	}
+	if got := tasks[2].Status(); got != TaskSkipped {
+		t.Errorf("transitive dependent status %s, want %s", got, TaskSkipped)
+	}
+	if !strings.Contains(tasks[1].SkipReason(), `"a"`) {
+		t.Errorf("skip reason %q does not name the failed prerequisite", tasks[1].SkipReason())
+	}
+	if !unrelated.Load() {
+		t.Error("unrelated task did not run")
+	}
+	if got := tasks[3].Status(); got != TaskCompleted {
+		t.Errorf("unrelated task status %s, want %s", got, TaskCompleted)
+	}
+}
+
+// TestExecuteTasksBoundsParallelism verifies no more than
+// MaxParallelism tasks run at once.
+func TestExecuteTasksBoundsParallelism(t *testing.T) {
+	const limit = 2
+	var running, peak atomic.Int32
+	gate := make(chan struct{})
+	var tasks []*Task
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		tasks = append(tasks, &Task{ID: id, Run: func(ctx context.Context) error {
+			n := running.Add(1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			<-gate
+			running.Add(-1)
+			return nil
+		}})
+	}
+	done := make(chan error, 1)
+	go func() { done <- NewTaskExecutor(limit, nil).ExecuteTasks(context.Background(), tasks) }()
+	close(gate)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := peak.Load(); got > limit {
+		t.Errorf("%d tasks ran concurrently, want at most %d", got, limit)
+	}
+}
+
+// TestExecuteTasksCancellation verifies cancellation skips tasks still
+// waiting on prerequisites rather than running them.
+func TestExecuteTasksCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var depRan atomic.Bool
+	tasks := []*Task{
+		{ID: "slow", Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{ID: "after", Dependencies: []string{"slow"}, Run: func(ctx context.Context) error {
+			depRan.Store(true)
+			return nil
+		}},
+	}
+	done := make(chan error, 1)
+	go func() { done <- NewTaskExecutor(1, nil).ExecuteTasks(ctx, tasks) }()
+	<-started
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatal("cancelled execution returned no error")
+	}
+	if depRan.Load() {
+		t.Error("dependent ran despite cancellation")
+	}
+	if got := tasks[1].Status(); got != TaskSkipped {
+		t.Errorf("dependent status %s, want %s", got, TaskSkipped)
+	}
+}
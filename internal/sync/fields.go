@@ -0,0 +1,125 @@
+package sync
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// FieldMapping maps a JIRA custom field ID onto a front-matter key with
+// a value type. It mirrors the CRD's FieldMap without depending on the
+// API package.
+type FieldMapping struct {
+	// ID is the JIRA field ID, e.g. "customfield_10016".
+	ID string
+	// Name is the front-matter key the value is emitted under.
+	Name string
+	// Type is the value type: "string", "number", "array" or "date".
+	Type string
+}
+
+// jiraDateLayouts are the timestamp formats JIRA emits for date and
+// datetime custom fields.
+var jiraDateLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02",
+}
+
+// mappedFieldIDs lists the custom field IDs to request from JIRA in
+// addition to the default field set.
+func (e *Engine) mappedFieldIDs() []string {
+	ids := make([]string, 0, len(e.fieldMapping))
+	for _, fm := range e.fieldMapping {
+		ids = append(ids, fm.ID)
+	}
+	return ids
+}
+
+// customFields coerces an issue's mapped custom field values into their
+// front-matter representation. Unmapped or absent fields are omitted.
+func (e *Engine) customFields(issue *jira.Issue) map[string]interface{} {
+	var out map[string]interface{}
+	for _, fm := range e.fieldMapping {
+		raw, ok := issue.Fields.Custom[fm.ID]
+		if !ok {
+			continue
+		}
+		value, ok := coerceFieldValue(raw, fm.Type)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{})
+		}
+		out[fm.Name] = value
+	}
+	return out
+}
+
+// coerceFieldValue converts a raw JSON-decoded field value into the
+// mapped type. It reports false when the value cannot be represented as
+// that type, in which case the field is omitted from the front-matter.
+func coerceFieldValue(raw interface{}, fieldType string) (interface{}, bool) {
+	switch fieldType {
+	case "number":
+		switch v := raw.(type) {
+		case float64:
+			return v, true
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			return f, err == nil
+		}
+		return nil, false
+	case "string":
+		return stringifyFieldValue(raw)
+	case "array":
+		items, ok := raw.([]interface{})
+		if !ok {
+			items = []interface{}{raw}
+		}
+		var values []string
+		for _, item := range items {
+			s, ok := stringifyFieldValue(item)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	case "date":
+		s, ok := raw.(string)
+		if !ok {
+			return nil, false
+		}
+		for _, layout := range jiraDateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.Format("2006-01-02"), true
+			}
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+// stringifyFieldValue renders a scalar or JIRA option object as a
+// string. Option objects (e.g. select fields) carry their display text
+// under "value" or "name".
+func stringifyFieldValue(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case map[string]interface{}:
+		for _, key := range []string{"value", "name"} {
+			if s, ok := v[key].(string); ok {
+				return s, true
+			}
+		}
+	}
+	return fmt.Sprintf("%v", raw), true
+}
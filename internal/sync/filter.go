@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// filterJQLTTL is how long a saved filter's resolved JQL is cached
+// before the next sync re-resolves it. Saved filters are edited in
+// JIRA, so the cache is deliberately short relative to reconcile
+// intervals.
+const filterJQLTTL = 15 * time.Minute
+
+// resolveFilterJQL returns the JQL of the configured saved filter, resolving
+// it through the JIRA API and caching the result for filterJQLTTL.
+// Resolution failures with a cached value fall back to the stale JQL
+// rather than failing the sync.
+func (e *Engine) resolveFilterJQL(ctx context.Context) (string, error) {
+	e.filterMu.Lock()
+	cached := e.filterJQL
+	fresh := cached != "" && time.Since(e.filterResolvedAt) < filterJQLTTL
+	e.filterMu.Unlock()
+	if fresh {
+		return cached, nil
+	}
+
+	var filter *jira.Filter
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var ferr error
+		filter, ferr = e.jira.GetFilter(ctx, e.filterID)
+		return ferr
+	})
+	if err != nil {
+		if cached != "" {
+			return cached, nil
+		}
+		return "", err
+	}
+
+	e.filterMu.Lock()
+	e.filterJQL = filter.JQL
+	e.filterResolvedAt = time.Now()
+	e.filterMu.Unlock()
+	return filter.JQL, nil
+}
+
+// syncFilter pages through every issue matched by the configured saved
+// filter, returning the keys seen and per-project issue counts. Like
+// boards, filters can span projects, so counts are keyed by each
+// issue's own project. Filter scans are not checkpointed; an
+// interrupted run rescans from the start.
+func (e *Engine) syncFilter(ctx context.Context) ([]string, map[string]int, error) {
+	jql, err := e.resolveFilterJQL(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sync: resolve filter %d: %w", e.filterID, err)
+	}
+	// Pagination needs a stable order; leave any ORDER BY the filter
+	// author wrote in place.
+	if !strings.Contains(strings.ToUpper(jql), "ORDER BY") {
+		jql += " ORDER BY key ASC"
+	}
+	e.resolveAgileFields(ctx)
+	counts := make(map[string]int)
+	var presentKeys []string
+	var commitBatch []string
+	// Commit whatever the batch has accumulated before any return, so a
+	// mid-batch failure never leaves written-but-uncommitted files for
+	// the next reconcile to untangle.
+	defer func() { _ = e.flushCommitBatch(ctx, commitBatch) }()
+	startAt := 0
+	for {
+		batchSize := e.batcher.current()
+		var page *jira.SearchResult
+		err := e.execJira(ctx, func(ctx context.Context) error {
+			var serr error
+			page, serr = e.jira.SearchIssues(ctx, jql, startAt, batchSize,
+				e.extraSearchFields()...)
+			return serr
+		})
+		if err != nil {
+			return presentKeys, counts, fmt.Errorf("sync: list issues for filter %d: %w", e.filterID, err)
+		}
+		for i := range page.Issues {
+			if err := ctx.Err(); err != nil {
+				return presentKeys, counts, err
+			}
+			issue := &page.Issues[i]
+			e.noteUpdated(issue)
+			project := projectFromKey(issue.Key)
+			// Saved filters rarely exclude ignored statuses themselves,
+			// so they are dropped here; leaving them out of presentKeys
+			// removes their files as orphaned.
+			if e.statusIgnored(issue.Fields.Status.Name) {
+				continue
+			}
+			presentKeys = append(presentKeys, issue.Key)
+			if e.incrActive && !e.markProcessed(issue.Key) {
+				continue
+			}
+			if e.deadLetters != nil && e.deadLetters.IsDead(project, issue.Key) {
+				continue
+			}
+			if e.unchangedSinceLastSync(issue) {
+				continue
+			}
+			if err := e.writeIssue(ctx, project, issue); err != nil {
+				return presentKeys, counts, err
+			}
+			counts[project]++
+			if e.commitBatch > 0 {
+				commitBatch = append(commitBatch, issue.Key)
+				if len(commitBatch) >= e.commitBatch {
+					if err := e.flushCommitBatch(ctx, commitBatch); err != nil {
+						commitBatch = nil
+						return presentKeys, counts, err
+					}
+					commitBatch = nil
+				}
+			}
+		}
+		startAt += len(page.Issues)
+		// Adapt the page size to observed rate limiting before the next
+		// page.
+		size := e.batcher.observe(e.jira.RateLimitStats())
+		e.tracker.SetBatchSize(size)
+		if startAt >= page.Total || len(page.Issues) == 0 {
+			return presentKeys, counts, nil
+		}
+	}
+}
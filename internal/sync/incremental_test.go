@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// TestIncrementalJQLWindow verifies the incremental query reaches back
+// the overlap before the watermark, truncated down to JQL's minute
+// precision, and orders by updated so late modifications are paged last.
+func TestIncrementalJQLWindow(t *testing.T) {
+	engine, err := NewEngine(nil, nil, Options{
+		ProjectKeys:        []string{"TEST"},
+		Incremental:        true,
+		IncrementalSince:   time.Date(2026, 1, 2, 15, 4, 30, 0, time.UTC),
+		IncrementalOverlap: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jql := engine.incrementalJQL("TEST")
+
+	if !strings.HasPrefix(jql, "project = TEST") {
+		t.Fatalf("jql %q does not scope the project", jql)
+	}
+	// 15:04:30 minus the 1m overlap is 15:03:30, truncated to 15:03.
+	if !strings.Contains(jql, `updated >= "2026/01/02 15:03"`) {
+		t.Fatalf("jql %q has the wrong window", jql)
+	}
+	if !strings.HasSuffix(jql, "ORDER BY updated ASC") {
+		t.Fatalf("jql %q is not ordered by updated", jql)
+	}
+}
+
+// TestWatermarkMaxUpdated verifies the watermark tracks the highest
+// updated timestamp JIRA reported — including out-of-order pages and
+// issues straddling the previous boundary — rather than wall-clock
+// time, and that re-fetched boundary issues dedup within a run.
+func TestWatermarkMaxUpdated(t *testing.T) {
+	engine, err := NewEngine(nil, nil, Options{
+		ProjectKeys:      []string{"TEST"},
+		Incremental:      true,
+		IncrementalSince: time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.incrSeen = make(map[string]struct{})
+
+	issues := []jira.Issue{
+		// Inside the overlap window, before the previous watermark.
+		{Key: "TEST-1", Fields: jira.IssueFields{Updated: "2026-01-02T14:59:40.000+0000"}},
+		// Straddling the boundary just after the watermark.
+		{Key: "TEST-2", Fields: jira.IssueFields{Updated: "2026-01-02T15:00:10.000+0000"}},
+		// The newest update, returned out of order.
+		{Key: "TEST-3", Fields: jira.IssueFields{Updated: "2026-01-02T15:07:00.000+0000"}},
+		{Key: "TEST-4", Fields: jira.IssueFields{Updated: "2026-01-02T15:02:00.000+0000"}},
+		// Unparseable timestamps must not corrupt the watermark.
+		{Key: "TEST-5", Fields: jira.IssueFields{Updated: "not-a-time"}},
+	}
+	for i := range issues {
+		engine.noteUpdated(&issues[i])
+		if !engine.markProcessed(issues[i].Key) {
+			t.Fatalf("first sighting of %s reported as duplicate", issues[i].Key)
+		}
+	}
+
+	want := time.Date(2026, 1, 2, 15, 7, 0, 0, time.UTC)
+	if got := engine.Watermark(); !got.Equal(want) {
+		t.Fatalf("watermark %v, want %v", got, want)
+	}
+	// A second target re-returning a boundary issue is skipped.
+	if engine.markProcessed("TEST-2") {
+		t.Fatal("re-fetched boundary issue was not deduplicated")
+	}
+}
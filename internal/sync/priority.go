@@ -0,0 +1,137 @@
+package sync
+
+import (
+	"container/heap"
+	"strings"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// TaskPriorityFromJira maps a JIRA priority name onto a TaskPriority.
+// Both the modern scheme (Highest..Lowest) and the classic one
+// (Blocker..Trivial) are recognized, along with P1..P5 shorthand.
+// Unknown or missing priorities land in the middle, so instances with
+// custom schemes are neither favored nor starved.
+func TaskPriorityFromJira(name string) TaskPriority {
+	switch strings.ToLower(name) {
+	case "highest", "blocker", "critical", "p1":
+		return PriorityCritical
+	case "high", "major", "p2":
+		return PriorityHigh
+	case "low", "minor", "p4":
+		return PriorityLow
+	case "lowest", "trivial", "p5":
+		return PriorityLowest
+	default:
+		return PriorityMedium
+	}
+}
+
+// agingEvery is how often the issue queue pops its oldest entry instead
+// of its most urgent one. Popping FIFO every agingEvery-th time bounds
+// how long a backlog item can wait behind a stream of urgent issues,
+// without letting it preempt critical ones more than occasionally.
+const agingEvery = 4
+
+// issueEntry is one queued issue with its scheduling metadata.
+type issueEntry struct {
+	issue    *jira.Issue
+	priority TaskPriority
+	// seq is the enqueue order, breaking priority ties FIFO and
+	// selecting the oldest entry on an aging pop.
+	seq int
+	// popped marks entries already delivered through the other index,
+	// so each entry comes out exactly once.
+	popped bool
+}
+
+// issueQueue orders issues by their mapped JIRA priority, most urgent
+// first, with periodic FIFO aging for fairness. It indexes entries
+// twice — a heap by priority and a slice by age — and marks an entry
+// popped in one index when the other delivers it, skipping marked
+// entries lazily. Not safe for concurrent use; each project scan drains
+// its own queue.
+type issueQueue struct {
+	heap entryHeap
+	fifo []*issueEntry
+	seq  int
+	pops int
+	live int
+}
+
+func newIssueQueue() *issueQueue {
+	return &issueQueue{}
+}
+
+// push queues an issue under its mapped JIRA priority.
+func (q *issueQueue) push(issue *jira.Issue) {
+	var name string
+	if issue.Fields.Priority != nil {
+		name = issue.Fields.Priority.Name
+	}
+	entry := &issueEntry{issue: issue, priority: TaskPriorityFromJira(name), seq: q.seq}
+	q.seq++
+	q.live++
+	heap.Push(&q.heap, entry)
+	q.fifo = append(q.fifo, entry)
+}
+
+// len returns how many issues are still queued.
+func (q *issueQueue) len() int {
+	return q.live
+}
+
+// pop returns the next issue to sync: usually the most urgent one, and
+// every agingEvery-th call the oldest one. Nil when the queue is empty.
+func (q *issueQueue) pop() *jira.Issue {
+	if q.live == 0 {
+		return nil
+	}
+	q.pops++
+	q.live--
+	if q.pops%agingEvery == 0 {
+		for {
+			entry := q.fifo[0]
+			q.fifo = q.fifo[1:]
+			if entry.popped {
+				continue
+			}
+			entry.popped = true
+			return entry.issue
+		}
+	}
+	for {
+		entry := heap.Pop(&q.heap).(*issueEntry)
+		if entry.popped {
+			continue
+		}
+		entry.popped = true
+		return entry.issue
+	}
+}
+
+// entryHeap implements heap.Interface over issue entries, ordered by
+// priority then enqueue order.
+type entryHeap []*issueEntry
+
+func (h entryHeap) Len() int { return len(h) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h entryHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *entryHeap) Push(x interface{}) { *h = append(*h, x.(*issueEntry)) }
+
+func (h *entryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
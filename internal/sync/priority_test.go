@@ -0,0 +1,92 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// priorityIssue builds a minimal issue carrying a JIRA priority name.
+func priorityIssue(key, priority string) *jira.Issue {
+	issue := &jira.Issue{Key: key}
+	if priority != "" {
+		issue.Fields.Priority = &jira.Priority{Name: priority}
+	}
+	return issue
+}
+
+// TestIssueQueueMixedPriorities verifies the queue delivers urgent
+// issues first, with the aging pop folding the oldest waiting entry in
+// every agingEvery-th turn.
+func TestIssueQueueMixedPriorities(t *testing.T) {
+	q := newIssueQueue()
+	for _, issue := range []*jira.Issue{
+		priorityIssue("LOW-1", "Low"),
+		priorityIssue("CRIT-1", "Highest"),
+		priorityIssue("MED-1", "Medium"),
+		priorityIssue("CRIT-2", "Blocker"),
+		priorityIssue("LOW-2", "Lowest"),
+		priorityIssue("HIGH-1", "High"),
+	} {
+		q.push(issue)
+	}
+
+	// The fourth pop is an aging pop and returns the oldest waiting
+	// entry, LOW-1; every other pop takes the most urgent.
+	want := []string{"CRIT-1", "CRIT-2", "HIGH-1", "LOW-1", "MED-1", "LOW-2"}
+	for i, key := range want {
+		issue := q.pop()
+		if issue == nil {
+			t.Fatalf("pop %d: queue empty, want %s", i+1, key)
+		}
+		if issue.Key != key {
+			t.Errorf("pop %d = %s, want %s", i+1, issue.Key, key)
+		}
+	}
+	if q.len() != 0 {
+		t.Errorf("queue reports %d issues after draining", q.len())
+	}
+	if q.pop() != nil {
+		t.Error("pop on an empty queue did not return nil")
+	}
+}
+
+// TestIssueQueueAging verifies a low-priority issue behind a stream of
+// critical ones is still delivered within agingEvery pops.
+func TestIssueQueueAging(t *testing.T) {
+	q := newIssueQueue()
+	q.push(priorityIssue("LOW-1", "Lowest"))
+	for i := 0; i < 20; i++ {
+		q.push(priorityIssue("CRIT-1", "Highest"))
+	}
+	for i := 1; i <= agingEvery; i++ {
+		if q.pop().Key == "LOW-1" {
+			return
+		}
+	}
+	t.Fatalf("low-priority issue not delivered within %d pops", agingEvery)
+}
+
+// TestTaskPriorityFromJira covers the modern, classic and shorthand
+// priority schemes plus the unknown-name default.
+func TestTaskPriorityFromJira(t *testing.T) {
+	cases := map[string]TaskPriority{
+		"Highest":     PriorityCritical,
+		"Blocker":     PriorityCritical,
+		"P1":          PriorityCritical,
+		"High":        PriorityHigh,
+		"Major":       PriorityHigh,
+		"Medium":      PriorityMedium,
+		"Low":         PriorityLow,
+		"Minor":       PriorityLow,
+		"Lowest":      PriorityLowest,
+		"Trivial":     PriorityLowest,
+		"":            PriorityMedium,
+		"Showstopper": PriorityMedium,
+	}
+	for name, want := range cases {
+		if got := TaskPriorityFromJira(name); got != want {
+			t.Errorf("TaskPriorityFromJira(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
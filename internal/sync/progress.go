@@ -0,0 +1,150 @@
+package sync
+
+import "sync"
+
+// ProgressUpdate is a snapshot of overall task progress delivered to
+// registered callbacks.
+type ProgressUpdate struct {
+	TaskID    string
+	Status    TaskStatus
+	Message   string
+	Total     int
+	Completed int
+	Failed    int
+	Skipped   int
+	// PerProject holds issue counts per project key for multi-project
+	// operations.
+	PerProject map[string]int
+	// BatchSize is the current effective JIRA search page size.
+	BatchSize int
+	// Attachments counts per-issue sub-items processed so far, so
+	// long-running issues still show movement.
+	Attachments int
+}
+
+// ProgressDetail carries per-issue sub-item counts for a single update,
+// added to the tracker's running totals.
+type ProgressDetail struct {
+	Attachments int
+}
+
+// ProgressCallback receives progress updates. Callbacks may be invoked
+// from multiple goroutines and must be fast; slow consumers should
+// buffer internally.
+type ProgressCallback func(ProgressUpdate)
+
+// ProgressTracker aggregates task progress for an operation and fans it
+// out to callbacks. It is safe for concurrent use.
+type ProgressTracker struct {
+	mu          sync.Mutex
+	total       int
+	completed   int
+	failed      int
+	skipped     int
+	perProject  map[string]int
+	batchSize   int
+	attachments int
+	callbacks   []ProgressCallback
+}
+
+// NewProgressTracker returns an empty tracker.
+func NewProgressTracker() *ProgressTracker {
+	return &ProgressTracker{}
+}
+
+// AddCallback registers cb for future updates.
+func (p *ProgressTracker) AddCallback(cb ProgressCallback) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.callbacks = append(p.callbacks, cb)
+}
+
+// SetTotal records the number of tasks in the operation.
+func (p *ProgressTracker) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+}
+
+// SetProjectCounts records per-project issue counts for the operation.
+func (p *ProgressTracker) SetProjectCounts(counts map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.perProject = make(map[string]int, len(counts))
+	for key, n := range counts {
+		p.perProject[key] = n
+	}
+}
+
+// SetBatchSize records the current effective search page size.
+func (p *ProgressTracker) SetBatchSize(size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.batchSize = size
+}
+
+// Update records a task transition and notifies callbacks.
+func (p *ProgressTracker) Update(taskID string, status TaskStatus, message string) {
+	p.UpdateDetail(taskID, status, message, ProgressDetail{})
+}
+
+// UpdateDetail records a task transition along with per-issue sub-item
+// counts and notifies callbacks.
+func (p *ProgressTracker) UpdateDetail(taskID string, status TaskStatus, message string, detail ProgressDetail) {
+	p.mu.Lock()
+	switch status {
+	case TaskCompleted:
+		p.completed++
+	case TaskFailed:
+		p.failed++
+	case TaskSkipped:
+		p.skipped++
+	}
+	p.attachments += detail.Attachments
+	update := ProgressUpdate{
+		TaskID:      taskID,
+		Status:      status,
+		Message:     message,
+		Total:       p.total,
+		Completed:   p.completed,
+		Failed:      p.failed,
+		Skipped:     p.skipped,
+		BatchSize:   p.batchSize,
+		Attachments: p.attachments,
+	}
+	callbacks := make([]ProgressCallback, len(p.callbacks))
+	copy(callbacks, p.callbacks)
+	p.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(update)
+	}
+}
+
+// AddDetail adds per-issue sub-item counts outside a task transition,
+// e.g. attachments downloaded mid-task. The new totals surface with the
+// next task update rather than notifying callbacks immediately.
+func (p *ProgressTracker) AddDetail(detail ProgressDetail) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.attachments += detail.Attachments
+}
+
+// Snapshot returns the current counters.
+func (p *ProgressTracker) Snapshot() ProgressUpdate {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	perProject := make(map[string]int, len(p.perProject))
+	for key, n := range p.perProject {
+		perProject[key] = n
+	}
+	return ProgressUpdate{
+		Total:       p.total,
+		Completed:   p.completed,
+		Failed:      p.failed,
+		Skipped:     p.skipped,
+		PerProject:  perProject,
+		BatchSize:   p.batchSize,
+		Attachments: p.attachments,
+	}
+}
@@ -0,0 +1,34 @@
+package sync
+
+import (
+	"context"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// writeProjectMeta mirrors a project's JIRA schema (statuses, issue
+// types, priorities, components) into <project>/.project-meta.yaml as a
+// stable reference for consumers. Sections the credentials cannot read
+// arrive as notes inside the metadata itself; like changelog and
+// worklog enrichment, any other fetch failure skips the refresh rather
+// than failing the cycle — the previous file keeps serving and the next
+// cycle retries. The file is only rewritten when the metadata actually
+// changed, so steady-state cycles add nothing to the commit.
+func (e *Engine) writeProjectMeta(ctx context.Context, project string) {
+	var meta jira.ProjectMeta
+	err := e.execJira(ctx, func(ctx context.Context) error {
+		var gerr error
+		meta, gerr = e.jira.GetProjectMetadata(ctx, project)
+		return gerr
+	})
+	if err != nil {
+		return
+	}
+	content, err := yaml.Marshal(meta)
+	if err != nil {
+		return
+	}
+	_, _ = e.git.WriteProjectMeta(project, content)
+}
@@ -0,0 +1,50 @@
+package sync
+
+import "time"
+
+// ProjectResult records one project's outcome from the last sync cycle,
+// read by the controller for per-project status reporting.
+type ProjectResult struct {
+	// SyncedIssues is the number of issues the scan wrote.
+	SyncedIssues int
+	// SyncedAt is when the scan finished.
+	SyncedAt time.Time
+	// Err is the scan's failure, nil when it completed.
+	Err error
+}
+
+// recordProjectResults replaces the per-project outcomes after a cycle's
+// scans finish. Rebuilding the map every cycle prunes projects no
+// longer in scope. Board and filter scan failures are keyed by their
+// "board N"/"filter N" scope name since their issues span projects.
+func (e *Engine) recordProjectResults(counts map[string]int, failures map[string]error) {
+	now := time.Now()
+	results := make(map[string]ProjectResult, len(counts))
+	for project, count := range counts {
+		results[project] = ProjectResult{
+			SyncedIssues: count,
+			SyncedAt:     now,
+			Err:          failures[project],
+		}
+	}
+	for scope, err := range failures {
+		if _, seen := results[scope]; !seen {
+			results[scope] = ProjectResult{SyncedAt: now, Err: err}
+		}
+	}
+	e.projectMu.Lock()
+	e.projectResults = results
+	e.projectMu.Unlock()
+}
+
+// ProjectResults returns a copy of the per-project outcomes of the last
+// sync cycle, keyed by project key. Empty until a full cycle has run.
+func (e *Engine) ProjectResults() map[string]ProjectResult {
+	e.projectMu.Lock()
+	defer e.projectMu.Unlock()
+	results := make(map[string]ProjectResult, len(e.projectResults))
+	for project, res := range e.projectResults {
+		results[project] = res
+	}
+	return results
+}
@@ -0,0 +1,45 @@
+package sync
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestProjectResultsRebuild verifies each cycle's results replace the
+// previous map — pruning projects no longer in scope — and that scope
+// failures without issue counts (a failed board scan) still surface.
+func TestProjectResultsRebuild(t *testing.T) {
+	engine, err := NewEngine(nil, nil, Options{ProjectKeys: []string{"ALPHA", "BETA"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scanErr := errors.New("search failed")
+	engine.recordProjectResults(
+		map[string]int{"ALPHA": 3, "BETA": 1},
+		map[string]error{"BETA": scanErr, "board 7": errors.New("board gone")},
+	)
+	results := engine.ProjectResults()
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %v", len(results), results)
+	}
+	if res := results["ALPHA"]; res.SyncedIssues != 3 || res.Err != nil || res.SyncedAt.IsZero() {
+		t.Errorf("ALPHA result = %+v", res)
+	}
+	if res := results["BETA"]; res.Err != scanErr {
+		t.Errorf("BETA error = %v, want %v", res.Err, scanErr)
+	}
+	if res, ok := results["board 7"]; !ok || res.Err == nil {
+		t.Errorf("board failure not surfaced: %+v", res)
+	}
+
+	// The next cycle's results replace the map entirely.
+	engine.recordProjectResults(map[string]int{"ALPHA": 4}, nil)
+	results = engine.ProjectResults()
+	if len(results) != 1 {
+		t.Fatalf("stale results survived the rebuild: %v", results)
+	}
+	if _, ok := results["BETA"]; ok {
+		t.Error("removed project BETA was not pruned")
+	}
+}
@@ -0,0 +1,47 @@
+package sync
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// rateLimitCollectInterval is how often the collector samples limiter
+// statistics while an operation is running.
+const rateLimitCollectInterval = 15 * time.Second
+
+// startRateLimitCollector launches a goroutine that periodically
+// publishes the JIRA client's rate limiter statistics as Prometheus
+// gauges, labelled by the owning resource and the synced projects. The
+// goroutine stops when ctx is cancelled, publishing one final sample so
+// the operation's closing totals are exported.
+func (e *Engine) startRateLimitCollector(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(rateLimitCollectInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.publishRateLimitStats()
+			case <-ctx.Done():
+				e.publishRateLimitStats()
+				return
+			}
+		}
+	}()
+}
+
+// publishRateLimitStats exports one limiter snapshot to the rate
+// limiter gauges.
+func (e *Engine) publishRateLimitStats() {
+	stats := e.jira.RateLimitStats()
+	var averageWait float64
+	if stats.Throttled > 0 {
+		averageWait = stats.TotalWait.Seconds() / float64(stats.Throttled)
+	}
+	metrics.SetRateLimiterStats(e.instance, strings.Join(e.projectKeys, ","),
+		stats.Requests, stats.Throttled, stats.ServerLimitHits,
+		stats.BurstTokens, averageWait, stats.EffectiveLimit)
+}
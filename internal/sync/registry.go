@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"sort"
+	syncpkg "sync"
+)
+
+// EngineRegistry maps project keys to the engine most recently built
+// for them by the controller, so out-of-band triggers (webhooks, admin
+// APIs) can run targeted operations.
+type EngineRegistry struct {
+	mu        syncpkg.Mutex
+	byProject map[string]*Engine
+}
+
+// NewEngineRegistry returns an empty registry.
+func NewEngineRegistry() *EngineRegistry {
+	return &EngineRegistry{byProject: map[string]*Engine{}}
+}
+
+// Register records the current engine for a project key.
+func (r *EngineRegistry) Register(projectKey string, engine *Engine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byProject[projectKey] = engine
+}
+
+// Projects returns the registered project keys in sorted order.
+func (r *EngineRegistry) Projects() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.byProject))
+	for key := range r.byProject {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the engine for a project key, if one is registered.
+func (r *EngineRegistry) Get(projectKey string) (*Engine, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	engine, ok := r.byProject[projectKey]
+	return engine, ok
+}
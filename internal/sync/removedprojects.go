@@ -0,0 +1,57 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+)
+
+// cleanupRemovedProjects applies the removed-project policy to mirrored
+// projects no longer listed in the sync configuration: their files are
+// archived or deleted with a final commit, or left in place under the
+// default keep policy. Boards and filters mirror issues from projects
+// outside ProjectKeys, so cleanup is skipped entirely when either is
+// configured.
+func (e *Engine) cleanupRemovedProjects(ctx context.Context) error {
+	switch e.removedPolicy {
+	case git.RemovedProjectArchive, git.RemovedProjectDelete:
+	default:
+		return nil
+	}
+	if e.boardID != 0 || e.filterID != 0 {
+		return nil
+	}
+	mirrored, err := e.git.ListMirroredProjects(ctx)
+	if err != nil {
+		return err
+	}
+	configured := make(map[string]struct{}, len(e.projectKeys))
+	for _, project := range e.projectKeys {
+		configured[project] = struct{}{}
+	}
+	for _, project := range mirrored {
+		if _, ok := configured[project]; ok {
+			continue
+		}
+		var count int
+		var message string
+		if e.removedPolicy == git.RemovedProjectArchive {
+			count, err = e.git.ArchiveProject(ctx, project)
+			message = fmt.Sprintf("chore: archive removed project %s (%d files)", project, count)
+		} else {
+			count, err = e.git.RemoveProject(ctx, project)
+			message = fmt.Sprintf("chore: remove project %s (%d files)", project, count)
+		}
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			continue
+		}
+		if _, err := e.target.Commit(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
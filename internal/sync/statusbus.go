@@ -0,0 +1,91 @@
+package sync
+
+import (
+	syncpkg "sync"
+	"time"
+)
+
+// StatusEvent is one push update delivered to status subscribers: a
+// project phase change or a task lifecycle transition.
+type StatusEvent struct {
+	// Type is "project" for phase changes or "task" for task lifecycle
+	// transitions.
+	Type string `json:"type"`
+	// Project is the project key the event concerns, empty for tasks
+	// that were never tagged with one.
+	Project string `json:"project,omitempty"`
+	// Phase is the project's new sync phase for project events.
+	Phase string `json:"phase,omitempty"`
+	// Message carries phase detail or failure text.
+	Message string `json:"message,omitempty"`
+	// Task is a copy of the task record for task events.
+	Task *ManagedTask `json:"task,omitempty"`
+	// Time is when the event was published.
+	Time time.Time `json:"time"`
+}
+
+// StatusBus fans status events out to subscribers. Publishing never
+// blocks: a subscriber whose buffer is full is dropped and its channel
+// closed, so slow consumers disconnect rather than stall producers.
+type StatusBus struct {
+	mu   syncpkg.Mutex
+	subs map[chan StatusEvent]struct{}
+}
+
+// NewStatusBus returns an empty bus.
+func NewStatusBus() *StatusBus {
+	return &StatusBus{subs: map[chan StatusEvent]struct{}{}}
+}
+
+// Subscribe registers a subscriber with the given buffer size and
+// returns its channel plus an unsubscribe function. The channel is
+// closed when the subscriber is dropped for falling behind, when it
+// unsubscribes, or when the bus shuts down.
+func (b *StatusBus) Subscribe(buffer int) (<-chan StatusEvent, func()) {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	ch := make(chan StatusEvent, buffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch, func() { b.drop(ch) }
+}
+
+// Publish delivers event to every subscriber, dropping any whose
+// buffer is full.
+func (b *StatusBus) Publish(event StatusEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Shutdown drops every subscriber, closing their channels.
+func (b *StatusBus) Shutdown() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// drop removes one subscriber if it is still registered.
+func (b *StatusBus) drop(ch chan StatusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[ch]; ok {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
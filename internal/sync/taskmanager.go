@@ -0,0 +1,561 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	syncpkg "sync"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/metrics"
+)
+
+// ErrOperationInProgress reports that an exclusive operation with the
+// same key is already pending or running. TaskID identifies the
+// in-flight operation so callers can poll it instead.
+type ErrOperationInProgress struct {
+	Key    string
+	TaskID string
+}
+
+func (e *ErrOperationInProgress) Error() string {
+	if e.TaskID == "" {
+		return fmt.Sprintf("sync: operation already in progress for %s", e.Key)
+	}
+	return fmt.Sprintf("sync: operation already in progress for %s (task %s)", e.Key, e.TaskID)
+}
+
+// ManagedTask is an asynchronous operation tracked by the TaskManager,
+// such as a webhook-triggered single-issue sync.
+type ManagedTask struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	Status      TaskStatus `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	// Result carries an operation-specific outcome, such as the commit
+	// hash of a targeted resync.
+	Result string `json:"result,omitempty"`
+	// Project is the project key the task operates on, when the
+	// submitter tagged one. Used to resolve the owning resource for
+	// events on cancellation.
+	Project string `json:"project,omitempty"`
+	// RequestID is the correlation ID of the API request that submitted
+	// the task, carried into the task's context so logs and metric
+	// exemplars for the operation can be tied back to the request.
+	RequestID string `json:"requestId,omitempty"`
+
+	run func(ctx context.Context) error
+	// runResult is set instead of run for operations that report a
+	// result string on completion.
+	runResult func(ctx context.Context) (string, error)
+	// exclusiveKey is the lock key held by this task, empty for
+	// non-exclusive submissions.
+	exclusiveKey string
+	// cancel stops the task's context while it is running; nil before
+	// the task starts.
+	cancel context.CancelFunc
+	// cancelRequested marks that Cancel was called, so a run aborted by
+	// the cancelled context reports cancelled rather than failed.
+	cancelRequested bool
+	// queueProject is the project label the queue-depth gauge was
+	// incremented with, captured at enqueue so the decrement on dequeue
+	// always matches even if the task is tagged afterwards.
+	queueProject string
+}
+
+// ErrTaskNotFound reports a task ID the manager has no record of.
+var ErrTaskNotFound = errors.New("sync: task not found")
+
+// ErrTaskTerminal reports a cancellation of a task that already
+// finished.
+var ErrTaskTerminal = errors.New("sync: task already finished")
+
+// TaskManager queues and executes asynchronous operations on a bounded
+// worker pool, keeping task records for API inspection. It implements
+// manager.Runnable.
+type TaskManager struct {
+	workers int
+	queue   chan *ManagedTask
+	seq     atomic.Int64
+
+	mu    syncpkg.Mutex
+	tasks map[string]*ManagedTask
+	// inFlight maps exclusive lock keys to the ID of the pending or
+	// running task that holds them.
+	inFlight map[string]string
+	// idempotency maps caller-scoped idempotency keys to the task that
+	// first carried them, until the record expires.
+	idempotency       map[string]idempotencyRecord
+	idempotencyWindow time.Duration
+
+	// bus, when set, receives a task event for every lifecycle
+	// transition so connected UIs see updates in real time.
+	bus *StatusBus
+}
+
+// idempotencyRecord remembers which task an idempotency key produced.
+type idempotencyRecord struct {
+	taskID  string
+	expires time.Time
+}
+
+// defaultIdempotencyWindow is how long a replayed idempotency key keeps
+// returning its original task.
+const defaultIdempotencyWindow = 15 * time.Minute
+
+// NewTaskManager returns a TaskManager with the given worker count and
+// queue capacity.
+func NewTaskManager(workers, queueSize int) *TaskManager {
+	if workers <= 0 {
+		workers = 2
+	}
+	if queueSize <= 0 {
+		queueSize = 128
+	}
+	return &TaskManager{
+		workers:           workers,
+		queue:             make(chan *ManagedTask, queueSize),
+		tasks:             map[string]*ManagedTask{},
+		inFlight:          map[string]string{},
+		idempotency:       map[string]idempotencyRecord{},
+		idempotencyWindow: defaultIdempotencyWindow,
+	}
+}
+
+// SetIdempotencyWindow overrides how long idempotency keys are
+// remembered.
+func (m *TaskManager) SetIdempotencyWindow(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d > 0 {
+		m.idempotencyWindow = d
+	}
+}
+
+// Submit enqueues an operation and returns its task record immediately.
+// It fails fast when the queue is full so callers can apply backpressure.
+func (m *TaskManager) Submit(taskType, description string, run func(ctx context.Context) error) (*ManagedTask, error) {
+	return m.submit("", taskType, description, run)
+}
+
+// SubmitExclusive enqueues an operation that holds the lock for key
+// until it finishes. While a task for key is pending or running, further
+// submissions fail with *ErrOperationInProgress carrying the in-flight
+// task ID.
+func (m *TaskManager) SubmitExclusive(key, taskType, description string, run func(ctx context.Context) error) (*ManagedTask, error) {
+	return m.submit(key, taskType, description, run)
+}
+
+// SubmitExclusiveResult is SubmitExclusive for operations that report a
+// result string, stored on the task record for API polling.
+func (m *TaskManager) SubmitExclusiveResult(key, taskType, description string, run func(ctx context.Context) (string, error)) (*ManagedTask, error) {
+	task := m.newTask(key, taskType, description)
+	task.runResult = run
+	return m.enqueue(task)
+}
+
+// SubmitExclusiveIdempotent is SubmitExclusive with replay protection:
+// when idemKey was already used within the idempotency window, the
+// original task is returned with replayed=true and nothing new is
+// enqueued. Callers scope keys themselves, e.g. "PROJ:<client-key>", so
+// the same client key against different projects stays independent. An
+// empty idemKey disables replay detection.
+func (m *TaskManager) SubmitExclusiveIdempotent(idemKey, key, taskType, description string, run func(ctx context.Context) error) (*ManagedTask, bool, error) {
+	if idemKey != "" {
+		if original, ok := m.replayedTask(idemKey); ok {
+			return original, true, nil
+		}
+	}
+	task, err := m.submit(key, taskType, description, run)
+	if err != nil {
+		return nil, false, err
+	}
+	if idemKey != "" {
+		m.mu.Lock()
+		m.idempotency[idemKey] = idempotencyRecord{
+			taskID:  task.ID,
+			expires: time.Now().Add(m.idempotencyWindow),
+		}
+		m.mu.Unlock()
+	}
+	return task, false, nil
+}
+
+// replayedTask returns a copy of the task an unexpired idempotency key
+// previously produced, pruning expired records along the way.
+func (m *TaskManager) replayedTask(idemKey string) (*ManagedTask, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for key, rec := range m.idempotency {
+		if now.After(rec.expires) {
+			delete(m.idempotency, key)
+		}
+	}
+	rec, ok := m.idempotency[idemKey]
+	if !ok {
+		return nil, false
+	}
+	task, ok := m.tasks[rec.taskID]
+	if !ok {
+		return nil, false
+	}
+	replay := *task
+	return &replay, true
+}
+
+func (m *TaskManager) submit(key, taskType, description string, run func(ctx context.Context) error) (*ManagedTask, error) {
+	task := m.newTask(key, taskType, description)
+	task.run = run
+	return m.enqueue(task)
+}
+
+func (m *TaskManager) newTask(key, taskType, description string) *ManagedTask {
+	return &ManagedTask{
+		ID:           fmt.Sprintf("%s-%d", taskType, m.seq.Add(1)),
+		Type:         taskType,
+		Description:  description,
+		Status:       TaskPending,
+		CreatedAt:    time.Now(),
+		exclusiveKey: key,
+	}
+}
+
+func (m *TaskManager) enqueue(task *ManagedTask) (*ManagedTask, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if task.exclusiveKey != "" {
+		if holder, ok := m.inFlight[task.exclusiveKey]; ok {
+			return nil, &ErrOperationInProgress{Key: task.exclusiveKey, TaskID: holder}
+		}
+		m.inFlight[task.exclusiveKey] = task.ID
+	}
+	m.tasks[task.ID] = task
+
+	select {
+	case m.queue <- task:
+		task.queueProject = task.Project
+		metrics.TaskEnqueued(task.Type, task.queueProject)
+		m.publishTask(*task)
+		return task, nil
+	default:
+		now := time.Now()
+		task.Status = TaskFailed
+		task.Error = "task queue full"
+		task.FinishedAt = &now
+		if task.exclusiveKey != "" && m.inFlight[task.exclusiveKey] == task.ID {
+			delete(m.inFlight, task.exclusiveKey)
+		}
+		return nil, fmt.Errorf("sync: task queue full, rejected %s", task.ID)
+	}
+}
+
+// releaseExclusive frees the task's lock key, if it holds one and still
+// owns it.
+func (m *TaskManager) releaseExclusive(task *ManagedTask) {
+	if task.exclusiveKey == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inFlight[task.exclusiveKey] == task.ID {
+		delete(m.inFlight, task.exclusiveKey)
+	}
+}
+
+// Tag records the project key a task operates on, for event and audit
+// attribution and metric labelling. Unknown IDs are ignored.
+func (m *TaskManager) Tag(id, project string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	task, ok := m.tasks[id]
+	if !ok || task.Project == project {
+		return
+	}
+	task.Project = project
+	if task.Status == TaskPending {
+		metrics.RetagQueuedTask(task.Type, task.queueProject, project)
+		task.queueProject = project
+	}
+}
+
+// SetRequestID attaches the submitting request's correlation ID to a
+// task, like Tag for the project key. Only meaningful before the task
+// starts running.
+func (m *TaskManager) SetRequestID(id, requestID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if task, ok := m.tasks[id]; ok {
+		task.RequestID = requestID
+	}
+}
+
+// Cancel requests cancellation of a task. Pending tasks are cancelled
+// immediately; running tasks have their context cancelled, which
+// propagates into in-flight JIRA and git calls, and transition to
+// cancelled once the operation unwinds. Already-finished tasks return
+// ErrTaskTerminal, unknown IDs ErrTaskNotFound.
+func (m *TaskManager) Cancel(id string) error {
+	m.mu.Lock()
+	task, ok := m.tasks[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrTaskNotFound
+	}
+	switch task.Status {
+	case TaskCompleted, TaskFailed, TaskSkipped, TaskCancelled:
+		m.mu.Unlock()
+		return ErrTaskTerminal
+	case TaskPending:
+		now := time.Now()
+		task.Status = TaskCancelled
+		task.Error = "cancelled before start"
+		task.FinishedAt = &now
+		m.mu.Unlock()
+		m.releaseExclusive(task)
+		return nil
+	default:
+		task.cancelRequested = true
+		cancel := task.cancel
+		m.mu.Unlock()
+		if cancel != nil {
+			cancel()
+		}
+		return nil
+	}
+}
+
+// wasCancelled reports whether Cancel was called on a running task.
+func (m *TaskManager) wasCancelled(task *ManagedTask) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return task.cancelRequested
+}
+
+// Get returns a copy of the task record with the given ID.
+func (m *TaskManager) Get(id string) (ManagedTask, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	task, ok := m.tasks[id]
+	if !ok {
+		return ManagedTask{}, false
+	}
+	return *task, true
+}
+
+// ActiveForProject returns a pending or running task tagged with the
+// given project, if any. Used to guard operations that must not overlap
+// an in-flight sync, such as resource deletion.
+func (m *TaskManager) ActiveForProject(project string) (ManagedTask, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, task := range m.tasks {
+		if task.Project == project && (task.Status == TaskPending || task.Status == TaskRunning) {
+			return *task, true
+		}
+	}
+	return ManagedTask{}, false
+}
+
+// List returns copies of all task records, newest first.
+func (m *TaskManager) List() []ManagedTask {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tasks := make([]ManagedTask, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].CreatedAt.After(tasks[j].CreatedAt) })
+	return tasks
+}
+
+// CleanupOldOperations drops finished task records that finished more
+// than retention ago, returning how many were removed. Pending and
+// running tasks are never touched, and recently finished records stay
+// available for API polling. Removal is idempotent: a second call over
+// the same window removes nothing further.
+func (m *TaskManager) CleanupOldOperations(retention time.Duration) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cutoff := time.Now().Add(-retention)
+	removed := 0
+	for id, task := range m.tasks {
+		switch task.Status {
+		case TaskCompleted, TaskFailed, TaskSkipped, TaskCancelled:
+		default:
+			continue
+		}
+		if task.FinishedAt == nil || task.FinishedAt.After(cutoff) {
+			continue
+		}
+		delete(m.tasks, id)
+		removed++
+	}
+	return removed
+}
+
+func (m *TaskManager) setStatus(task *ManagedTask, status TaskStatus, errMsg string) {
+	m.mu.Lock()
+	now := time.Now()
+	task.Status = status
+	task.Error = errMsg
+	switch status {
+	case TaskRunning:
+		task.StartedAt = &now
+	case TaskCompleted, TaskFailed, TaskSkipped, TaskCancelled:
+		task.FinishedAt = &now
+	}
+	record := *task
+	m.mu.Unlock()
+	m.publishTask(record)
+}
+
+// SetStatusBus routes task lifecycle events onto bus. Set before Start.
+func (m *TaskManager) SetStatusBus(bus *StatusBus) {
+	m.bus = bus
+}
+
+// publishTask pushes one task lifecycle event to the status bus, if
+// one is configured.
+func (m *TaskManager) publishTask(record ManagedTask) {
+	if m.bus == nil {
+		return
+	}
+	m.bus.Publish(StatusEvent{
+		Type:    "task",
+		Project: record.Project,
+		Message: record.Description,
+		Task:    &record,
+	})
+}
+
+// runTask executes one task, releasing its exclusive lock on
+// completion, failure, cancellation, or panic. Each task runs under its
+// own cancellable context so Cancel can stop it without affecting the
+// rest of the pool.
+func (m *TaskManager) runTask(ctx context.Context, task *ManagedTask) {
+	taskCtx, cancelTask := context.WithCancel(ctx)
+	defer cancelTask()
+
+	m.mu.Lock()
+	metrics.TaskDequeued(task.Type, task.queueProject, time.Since(task.CreatedAt))
+	if task.Status == TaskCancelled {
+		// Cancelled while still queued; nothing to run.
+		m.mu.Unlock()
+		m.releaseExclusive(task)
+		return
+	}
+	task.cancel = cancelTask
+	requestID := task.RequestID
+	m.mu.Unlock()
+
+	// Carry the submitting request's correlation ID through the
+	// operation: into the logger for every log line the run emits, and
+	// as the trace ID so latency observations carry it as an exemplar.
+	if requestID != "" {
+		taskCtx = log.IntoContext(taskCtx,
+			log.FromContext(taskCtx).WithValues("requestID", requestID))
+		taskCtx = metrics.ContextWithTraceID(taskCtx, requestID)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.setStatus(task, TaskFailed, fmt.Sprintf("panic: %v", r))
+		}
+		m.releaseExclusive(task)
+	}()
+	m.setStatus(task, TaskRunning, "")
+	run := task.run
+	if run == nil {
+		run = func(ctx context.Context) error {
+			result, err := task.runResult(ctx)
+			if err != nil {
+				return err
+			}
+			m.mu.Lock()
+			task.Result = result
+			m.mu.Unlock()
+			return nil
+		}
+	}
+	if err := run(taskCtx); err != nil {
+		if m.wasCancelled(task) {
+			m.setStatus(task, TaskCancelled, "cancelled by request")
+			return
+		}
+		m.setStatus(task, TaskFailed, err.Error())
+		return
+	}
+	m.setStatus(task, TaskCompleted, "")
+}
+
+// NeedLeaderElection marks the task manager as a non-leader runnable:
+// it executes API-triggered operations, which must work on whichever
+// replica receives the request.
+func (m *TaskManager) NeedLeaderElection() bool {
+	return false
+}
+
+// defaultShutdownTimeout is how long in-flight tasks get to reach a
+// safe point (commit, push, checkpoint) after shutdown is requested
+// before their context is cancelled.
+const defaultShutdownTimeout = 30 * time.Second
+
+// Start runs the worker pool until the context is cancelled, then
+// drains gracefully: no new tasks are started, in-flight tasks keep
+// running on a detached context for up to defaultShutdownTimeout, and
+// still-queued tasks are marked skipped. Operations interrupted by the
+// drain deadline rely on their persisted checkpoints to resume on the
+// next start.
+func (m *TaskManager) Start(ctx context.Context) error {
+	runCtx, cancelRun := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRun()
+	go func() {
+		<-ctx.Done()
+		time.AfterFunc(defaultShutdownTimeout, cancelRun)
+	}()
+
+	var wg syncpkg.WaitGroup
+	for i := 0; i < m.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case task := <-m.queue:
+					m.runTask(runCtx, task)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	m.drainPending()
+	return nil
+}
+
+// drainPending marks tasks still queued at shutdown as skipped so their
+// records explain why they never ran, and frees their exclusive locks.
+func (m *TaskManager) drainPending() {
+	for {
+		select {
+		case task := <-m.queue:
+			m.mu.Lock()
+			metrics.TaskDequeued(task.Type, task.queueProject, time.Since(task.CreatedAt))
+			m.mu.Unlock()
+			m.setStatus(task, TaskSkipped, "operator shutting down")
+			m.releaseExclusive(task)
+		default:
+			return
+		}
+	}
+}
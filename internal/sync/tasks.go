@@ -0,0 +1,145 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TaskStatus is the lifecycle state of a single task.
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskRunning   TaskStatus = "running"
+	TaskCompleted TaskStatus = "completed"
+	TaskFailed    TaskStatus = "failed"
+	TaskSkipped   TaskStatus = "skipped"
+	TaskCancelled TaskStatus = "cancelled"
+)
+
+// TaskPriority orders units of sync work; lower values are more urgent.
+type TaskPriority int
+
+const (
+	PriorityCritical TaskPriority = iota
+	PriorityHigh
+	PriorityMedium
+	PriorityLow
+	PriorityLowest
+)
+
+// Task is one unit of work in a sync or bootstrap operation. Tasks form
+// a DAG via Dependencies, which reference other task IDs.
+type Task struct {
+	ID           string
+	Name         string
+	Dependencies []string
+	Run          func(ctx context.Context) error
+
+	mu         sync.Mutex
+	status     TaskStatus
+	err        error
+	skipReason string
+}
+
+// Status returns the task's current status.
+func (t *Task) Status() TaskStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status == "" {
+		return TaskPending
+	}
+	return t.status
+}
+
+// Err returns the error that failed the task, if any.
+func (t *Task) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// SkipReason explains why a skipped task did not run.
+func (t *Task) SkipReason() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.skipReason
+}
+
+func (t *Task) setStatus(status TaskStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = status
+}
+
+func (t *Task) setFailed(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = TaskFailed
+	t.err = err
+}
+
+func (t *Task) setSkipped(reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = TaskSkipped
+	t.skipReason = reason
+}
+
+// createBootstrapTasks builds the task graph for an initial bootstrap of
+// a JIRA project into an empty repository.
+func (e *Engine) createBootstrapTasks(ctx context.Context) []*Task {
+	return []*Task{
+		{
+			ID:   "validate-jira",
+			Name: "Validate JIRA connectivity",
+			Run: func(ctx context.Context) error {
+				return e.execJira(ctx, func(ctx context.Context) error {
+					for _, project := range e.projectKeys {
+						if _, err := e.jira.GetProject(ctx, project); err != nil {
+							return err
+						}
+					}
+					if e.boardID != 0 {
+						if _, err := e.jira.GetBoardIssues(ctx, e.boardID, 0, 1); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+		},
+		{
+			ID:   "clone-repository",
+			Name: "Clone target git repository",
+			Run: func(ctx context.Context) error {
+				return e.execGit(ctx, func(ctx context.Context) error {
+					if err := e.git.Clone(ctx); err != nil {
+						return err
+					}
+					return e.git.EnsureRepoConfig(ctx)
+				})
+			},
+		},
+		{
+			ID:           "sync-issues",
+			Name:         fmt.Sprintf("Sync issues for %s", e.scope()),
+			Dependencies: []string{"validate-jira", "clone-repository"},
+			Run:          e.syncIssues,
+		},
+		{
+			ID:           "push-repository",
+			Name:         "Push mirrored issues",
+			Dependencies: []string{"sync-issues"},
+			Run: func(ctx context.Context) error {
+				return e.execGit(ctx, func(ctx context.Context) error {
+					if err := e.squashCycleCommits(ctx); err != nil {
+						return err
+					}
+					return e.git.PushWithRetry(ctx, e.retries)
+				})
+			},
+		},
+	}
+}
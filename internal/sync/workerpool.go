@@ -0,0 +1,30 @@
+package sync
+
+import syncpkg "sync"
+
+// runWorkers executes jobs concurrently, at most limit at a time, and
+// returns once every job has finished. A limit <= 1 runs the jobs
+// serially in order, so callers need no separate serial code path.
+func runWorkers(limit int, jobs []func()) {
+	if limit <= 1 {
+		for _, job := range jobs {
+			job()
+		}
+		return
+	}
+	sem := make(chan struct{}, limit)
+	var wg syncpkg.WaitGroup
+	for _, job := range jobs {
+		job := job
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			job()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package sync
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunWorkersBound verifies the pool never runs more jobs at once
+// than the configured limit, and that every job runs exactly once.
+func TestRunWorkersBound(t *testing.T) {
+	const limit = 3
+	const jobCount = 20
+
+	var running, peak, done int32
+	jobs := make([]func(), jobCount)
+	for i := range jobs {
+		jobs[i] = func() {
+			now := atomic.AddInt32(&running, 1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&done, 1)
+		}
+	}
+
+	runWorkers(limit, jobs)
+
+	if done != jobCount {
+		t.Fatalf("ran %d of %d jobs", done, jobCount)
+	}
+	if peak > limit {
+		t.Fatalf("pool ran %d jobs concurrently, limit is %d", peak, limit)
+	}
+	if peak < 2 {
+		t.Fatalf("pool never ran jobs concurrently (peak %d)", peak)
+	}
+}
+
+// TestRunWorkersSerial verifies a limit of 1 runs jobs in order without
+// overlap.
+func TestRunWorkersSerial(t *testing.T) {
+	var order []int
+	jobs := make([]func(), 5)
+	for i := range jobs {
+		i := i
+		jobs[i] = func() { order = append(order, i) }
+	}
+
+	runWorkers(1, jobs)
+
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("job order %v, want sequential", order)
+		}
+	}
+	if len(order) != len(jobs) {
+		t.Fatalf("ran %d of %d jobs", len(order), len(jobs))
+	}
+}
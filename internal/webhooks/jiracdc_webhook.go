@@ -0,0 +1,257 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	cdcerrors "github.com/chambridge/atlassian-cdc-git/internal/errors"
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// JiraCDCWebhook implements defaulting and validation for JiraCDC
+// resources.
+type JiraCDCWebhook struct {
+	// Client reads referenced credential secrets for pre-flight
+	// connectivity checks. Nil disables them.
+	Client client.Client
+	// PreflightEnabled turns on synchronous JIRA and git connectivity
+	// validation during admission.
+	PreflightEnabled bool
+	// PreflightTimeout bounds the combined pre-flight calls so admission
+	// is never blocked on a slow backend. Defaults to 5s.
+	PreflightTimeout time.Duration
+	// Tasks is consulted on deletion to reject deletes that would
+	// interrupt a running operation. Nil skips the check.
+	Tasks *sync.TaskManager
+	// Engines is consulted on deletion for syncs the controller runs
+	// directly, which never appear in the task manager. Nil skips the
+	// check.
+	Engines *sync.EngineRegistry
+}
+
+// ForceDeleteAnnotation bypasses the running-operation check on
+// deletion. It must always win so a resource can never become
+// undeletable and the finalizer path cannot deadlock.
+const ForceDeleteAnnotation = "jiracdc.io/force-delete"
+
+var (
+	_ webhook.CustomDefaulter = &JiraCDCWebhook{}
+	_ webhook.CustomValidator = &JiraCDCWebhook{}
+)
+
+// SetupWithManager registers the webhook with the manager.
+func (w *JiraCDCWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&cdcv1.JiraCDC{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-cdc-atlassian-io-v1-jiracdc,mutating=true,failurePolicy=fail,groups=cdc.atlassian.io,resources=jiracdcs,verbs=create;update,versions=v1,name=mjiracdc.kb.io,sideEffects=None,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-cdc-atlassian-io-v1-jiracdc,mutating=false,failurePolicy=fail,groups=cdc.atlassian.io,resources=jiracdcs,verbs=create;update;delete,versions=v1,name=vjiracdc.kb.io,sideEffects=None,admissionReviewVersions=v1
+
+// Default applies spec defaults.
+func (w *JiraCDCWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	cdc, ok := obj.(*cdcv1.JiraCDC)
+	if !ok {
+		return fmt.Errorf("expected a JiraCDC but got %T", obj)
+	}
+	ApplyDefaults(&cdc.Spec)
+	return nil
+}
+
+// ValidateCreate validates a new JiraCDC.
+func (w *JiraCDCWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cdc, ok := obj.(*cdcv1.JiraCDC)
+	if !ok {
+		return nil, fmt.Errorf("expected a JiraCDC but got %T", obj)
+	}
+	warnings, errs := Validate(&cdc.Spec)
+	if len(errs) > 0 {
+		return warnings, errs[0]
+	}
+	preflightWarnings, err := w.validateConnection(ctx, cdc)
+	return append(warnings, preflightWarnings...), err
+}
+
+// ValidateUpdate validates an update to a JiraCDC.
+func (w *JiraCDCWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cdc, ok := newObj.(*cdcv1.JiraCDC)
+	if !ok {
+		return nil, fmt.Errorf("expected a JiraCDC but got %T", newObj)
+	}
+	warnings, errs := Validate(&cdc.Spec)
+	if len(errs) > 0 {
+		return warnings, errs[0]
+	}
+	if old, ok := oldObj.(*cdcv1.JiraCDC); ok {
+		if err := validateProjectRemoval(&old.Spec, &cdc.Spec); err != nil {
+			return warnings, err
+		}
+	}
+	preflightWarnings, err := w.validateConnection(ctx, cdc)
+	return append(warnings, preflightWarnings...), err
+}
+
+// ValidateDelete rejects deletion while an operation is still running
+// for the resource, since tearing down a mid-sync clone can corrupt the
+// mirrored repository. The force-delete annotation bypasses the check
+// unconditionally.
+func (w *JiraCDCWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cdc, ok := obj.(*cdcv1.JiraCDC)
+	if !ok {
+		return nil, fmt.Errorf("expected a JiraCDC but got %T", obj)
+	}
+	if _, forced := cdc.Annotations[ForceDeleteAnnotation]; forced {
+		return nil, nil
+	}
+	for _, project := range cdc.Spec.Jira.Projects() {
+		if w.Tasks != nil {
+			if task, active := w.Tasks.ActiveForProject(project); active {
+				return nil, fmt.Errorf("a %s operation is %s for project %s (task %s); cancel it first, or annotate the resource with %s to delete anyway",
+					task.Type, task.Status, project, task.ID, ForceDeleteAnnotation)
+			}
+		}
+		// Controller-scheduled reconcile syncs run on the engine directly
+		// and never appear in the task manager.
+		if w.Engines != nil {
+			if engine, ok := w.Engines.Get(project); ok && engine.SyncInProgress() {
+				return nil, fmt.Errorf("a sync is running for project %s; wait for it to finish, or annotate the resource with %s to delete anyway",
+					project, ForceDeleteAnnotation)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// defaultPreflightTimeout bounds pre-flight connectivity checks.
+const defaultPreflightTimeout = 5 * time.Second
+
+// validateConnection runs optional pre-flight connectivity checks
+// against JIRA and the git remote using the referenced secrets, so
+// misconfigured URLs and credentials are rejected at admission instead
+// of discovered at sync time. Definite failures (bad credentials,
+// unknown host) reject the resource; transient errors and timeouts
+// degrade to a warning so a backend hiccup cannot block admission.
+func (w *JiraCDCWebhook) validateConnection(ctx context.Context, cdc *cdcv1.JiraCDC) (admission.Warnings, error) {
+	if !w.PreflightEnabled || w.Client == nil {
+		return nil, nil
+	}
+	timeout := w.PreflightTimeout
+	if timeout == 0 {
+		timeout = defaultPreflightTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := w.checkJira(ctx, cdc, timeout); err != nil {
+		return preflightOutcome("spec.jira", err)
+	}
+	if err := w.checkGitRemote(ctx, cdc); err != nil {
+		return preflightOutcome("spec.gitRepository", err)
+	}
+	return nil, nil
+}
+
+func (w *JiraCDCWebhook) checkJira(ctx context.Context, cdc *cdcv1.JiraCDC, timeout time.Duration) error {
+	creds, err := w.readSecret(ctx, cdc.Namespace, cdc.Spec.Jira.CredentialsSecret)
+	if err != nil {
+		return err
+	}
+	caBundle, err := w.caBundle(ctx, cdc.Namespace, cdc.Spec.Jira.TLS)
+	if err != nil {
+		return err
+	}
+	client, err := jira.NewClient(jira.Config{
+		BaseURL:    cdc.Spec.Jira.BaseURL,
+		AuthMethod: cdc.Spec.Jira.AuthMethod,
+		APIVersion: cdc.Spec.Jira.APIVersion,
+		Email:      string(creds["email"]),
+		APIToken:   string(creds["apiToken"]),
+		Token:      string(creds["token"]),
+		Timeout:    timeout,
+		TLS: jira.TLSConfig{
+			CABundle:           caBundle,
+			InsecureSkipVerify: cdc.Spec.Jira.TLS.InsecureSkipVerify,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.Myself(ctx)
+	return err
+}
+
+func (w *JiraCDCWebhook) checkGitRemote(ctx context.Context, cdc *cdcv1.JiraCDC) error {
+	creds, err := w.readSecret(ctx, cdc.Namespace, cdc.Spec.GitRepository.CredentialsSecret)
+	if err != nil {
+		return err
+	}
+	caBundle, err := w.caBundle(ctx, cdc.Namespace, cdc.Spec.GitRepository.TLS)
+	if err != nil {
+		return err
+	}
+	manager, err := git.NewManager(git.Config{
+		URL:      cdc.Spec.GitRepository.URL,
+		Branch:   cdc.Spec.GitRepository.Branch,
+		Username: string(creds["username"]),
+		Token:    string(creds["token"]),
+		TLS: git.TLSConfig{
+			CABundle:           caBundle,
+			InsecureSkipVerify: cdc.Spec.GitRepository.TLS.InsecureSkipVerify,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return manager.CheckRemote(ctx)
+}
+
+// caBundle resolves the CA bundle for a TLS config from its referenced
+// secret, or nil when no CA secret is configured.
+func (w *JiraCDCWebhook) caBundle(ctx context.Context, namespace string, tls cdcv1.TLSConfig) ([]byte, error) {
+	if tls.CASecret == "" {
+		return nil, nil
+	}
+	data, err := w.readSecret(ctx, namespace, tls.CASecret)
+	if err != nil {
+		return nil, err
+	}
+	if len(data["ca.crt"]) == 0 {
+		return nil, fmt.Errorf("CA secret %q is missing the ca.crt key", tls.CASecret)
+	}
+	return data["ca.crt"], nil
+}
+
+func (w *JiraCDCWebhook) readSecret(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := w.Client.Get(ctx, key, &secret); err != nil {
+		return nil, fmt.Errorf("read credentials secret %q: %w", name, err)
+	}
+	return secret.Data, nil
+}
+
+// preflightOutcome maps a pre-flight failure to an admission result:
+// timeouts and transient errors become a warning, anything definite
+// rejects the resource.
+func preflightOutcome(field string, err error) (admission.Warnings, error) {
+	if errors.Is(err, context.DeadlineExceeded) || cdcerrors.Classify(err).IsRetryable() {
+		return admission.Warnings{fmt.Sprintf("%s connectivity pre-check inconclusive: %v", field, err)}, nil
+	}
+	return nil, fmt.Errorf("%s connectivity pre-check failed: %w", field, err)
+}
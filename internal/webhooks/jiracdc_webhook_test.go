@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/sync"
+)
+
+// TestValidateDeleteRejectsRunningOperation verifies deletion is
+// rejected while a task is active for one of the resource's projects,
+// and that the force-delete annotation bypasses the check.
+func TestValidateDeleteRejectsRunningOperation(t *testing.T) {
+	tasks := sync.NewTaskManager(1, 4)
+	task, err := tasks.Submit("bulk-resync", "test operation", func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("submit task: %v", err)
+	}
+	tasks.Tag(task.ID, "PROJ")
+
+	w := &JiraCDCWebhook{Tasks: tasks}
+	cdc := &cdcv1.JiraCDC{
+		Spec: cdcv1.JiraCDCSpec{
+			Jira: cdcv1.JiraConfig{ProjectKeys: []string{"PROJ"}},
+		},
+	}
+
+	_, err = w.ValidateDelete(context.Background(), cdc)
+	if err == nil {
+		t.Fatal("deletion allowed while a task is active")
+	}
+	if !strings.Contains(err.Error(), task.ID) || !strings.Contains(err.Error(), ForceDeleteAnnotation) {
+		t.Fatalf("rejection %q does not name the task and the force-delete annotation", err)
+	}
+
+	forced := cdc.DeepCopy()
+	forced.Annotations = map[string]string{ForceDeleteAnnotation: "true"}
+	if _, err := w.ValidateDelete(context.Background(), forced); err != nil {
+		t.Fatalf("force-delete rejected: %v", err)
+	}
+}
+
+// TestValidateDeleteAllowsIdleResource verifies deletion passes when no
+// task is active for the resource's projects.
+func TestValidateDeleteAllowsIdleResource(t *testing.T) {
+	w := &JiraCDCWebhook{Tasks: sync.NewTaskManager(1, 4)}
+	cdc := &cdcv1.JiraCDC{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle"},
+		Spec: cdcv1.JiraCDCSpec{
+			Jira: cdcv1.JiraConfig{ProjectKeys: []string{"PROJ"}},
+		},
+	}
+	if _, err := w.ValidateDelete(context.Background(), cdc); err != nil {
+		t.Fatalf("deletion of idle resource rejected: %v", err)
+	}
+}
@@ -0,0 +1,449 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	cdcv1 "github.com/chambridge/atlassian-cdc-git/api/v1"
+	"github.com/chambridge/atlassian-cdc-git/internal/cron"
+	"github.com/chambridge/atlassian-cdc-git/internal/git"
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+	"github.com/chambridge/atlassian-cdc-git/internal/notify"
+)
+
+// ApplyDefaults fills in the spec defaults the mutating webhook would
+// apply, so callers previewing a configuration see the effective
+// values.
+func ApplyDefaults(spec *cdcv1.JiraCDCSpec) {
+	if spec.GitRepository.Branch == "" {
+		spec.GitRepository.Branch = "main"
+	}
+	if spec.GitRepository.ConflictResolutionStrategy == "" {
+		spec.GitRepository.ConflictResolutionStrategy = git.StrategyPreferJira
+	}
+	if spec.Sync.Interval == "" {
+		spec.Sync.Interval = "5m"
+	}
+	if spec.Sync.BatchSize == 0 {
+		spec.Sync.BatchSize = 50
+	}
+}
+
+// Validate runs the admission webhook's spec validation without any
+// cluster access: every section is checked independently so callers see
+// all configuration problems at once, alongside the non-fatal warnings.
+// Pre-flight connectivity checks are not part of Validate; they need
+// the referenced secrets and stay in the webhook.
+func Validate(spec *cdcv1.JiraCDCSpec) (admission.Warnings, []error) {
+	var errs []error
+	if err := validateJira(&spec.Jira); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateGitRepository(&spec.GitRepository); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateStorage(&spec.Storage); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateSync(&spec.Sync); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateNotifications(spec.Notifications); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateHooks(spec.Hooks); err != nil {
+		errs = append(errs, err)
+	}
+	return specWarnings(spec), errs
+}
+
+func validateNotifications(configs []cdcv1.NotificationConfig) error {
+	for i, nc := range configs {
+		switch nc.Type {
+		case cdcv1.NotifierSlack, cdcv1.NotifierWebhook:
+		default:
+			return fmt.Errorf("spec.notifications[%d].type %q is not one of slack, webhook", i, nc.Type)
+		}
+		if nc.URLSecret == "" {
+			return fmt.Errorf("spec.notifications[%d].urlSecret is required", i)
+		}
+		for _, event := range nc.Events {
+			if event != notify.StatusCompleted && event != notify.StatusFailed {
+				return fmt.Errorf("spec.notifications[%d].events contains unknown event %q", i, event)
+			}
+		}
+		if _, err := notify.ParseTemplate(nc.MessageTemplate); err != nil {
+			return fmt.Errorf("spec.notifications[%d].messageTemplate: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateHooks(configs []cdcv1.HookConfig) error {
+	for i, hc := range configs {
+		switch hc.Type {
+		case cdcv1.HookWebhook:
+			if hc.URLSecret == "" {
+				return fmt.Errorf("spec.hooks[%d].urlSecret is required for the webhook type", i)
+			}
+		case cdcv1.HookCommand:
+			if len(hc.Command) == 0 {
+				return fmt.Errorf("spec.hooks[%d].command is required for the command type", i)
+			}
+		default:
+			return fmt.Errorf("spec.hooks[%d].type %q is not one of webhook, command", i, hc.Type)
+		}
+		switch hc.Scope {
+		case "", cdcv1.HookScopeIssue, cdcv1.HookScopeOperation:
+		default:
+			return fmt.Errorf("spec.hooks[%d].scope %q is not one of issue, operation", i, hc.Scope)
+		}
+	}
+	return nil
+}
+
+func validateJira(cfg *cdcv1.JiraConfig) error {
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("spec.jira.baseURL %q is not a valid URL", cfg.BaseURL)
+	}
+	if cfg.BoardID < 0 {
+		return fmt.Errorf("spec.jira.boardID must be a positive board ID")
+	}
+	if cfg.FilterID < 0 {
+		return fmt.Errorf("spec.jira.filterID must be a positive filter ID")
+	}
+	projects := cfg.Projects()
+	if len(projects) == 0 && cfg.BoardID == 0 && cfg.FilterID == 0 {
+		return fmt.Errorf("spec.jira.projectKeys must list at least one project, or spec.jira.boardID/filterID must select a board or saved filter")
+	}
+	seen := make(map[string]struct{}, len(projects))
+	for _, key := range projects {
+		if key == "" {
+			return fmt.Errorf("spec.jira.projectKeys must not contain empty keys")
+		}
+		if _, dup := seen[key]; dup {
+			return fmt.Errorf("spec.jira.projectKeys contains duplicate key %q", key)
+		}
+		seen[key] = struct{}{}
+	}
+	switch cfg.AuthMethod {
+	case "", jira.AuthMethodBasic, jira.AuthMethodPAT:
+	default:
+		return fmt.Errorf("spec.jira.authMethod %q is not one of basic, pat", cfg.AuthMethod)
+	}
+	switch cfg.APIVersion {
+	case 0, 2, 3:
+	default:
+		return fmt.Errorf("spec.jira.apiVersion %d is not one of 2, 3", cfg.APIVersion)
+	}
+	if cfg.CredentialsSecret == "" {
+		return fmt.Errorf("spec.jira.credentialsSecret is required")
+	}
+	return nil
+}
+
+func validateStorage(cfg *cdcv1.StorageConfig) error {
+	switch cfg.Backend {
+	case "", cdcv1.StorageBackendGit:
+		return nil
+	case cdcv1.StorageBackendS3:
+	default:
+		return fmt.Errorf("spec.storage.backend %q is not one of git, s3", cfg.Backend)
+	}
+	if cfg.S3.Bucket == "" {
+		return fmt.Errorf("spec.storage.s3.bucket is required for the s3 backend")
+	}
+	if cfg.S3.CredentialsSecret == "" {
+		return fmt.Errorf("spec.storage.s3.credentialsSecret is required for the s3 backend")
+	}
+	if cfg.S3.Endpoint != "" {
+		u, err := url.Parse(cfg.S3.Endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("spec.storage.s3.endpoint %q is not a valid URL", cfg.S3.Endpoint)
+		}
+	}
+	if cfg.S3.Endpoint == "" && cfg.S3.Region == "" {
+		return fmt.Errorf("spec.storage.s3.region is required when no endpoint override is set")
+	}
+	return nil
+}
+
+func validateGitRepository(cfg *cdcv1.GitRepositoryConfig) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("spec.gitRepository.url is required")
+	}
+	if cfg.CredentialsSecret == "" {
+		return fmt.Errorf("spec.gitRepository.credentialsSecret is required")
+	}
+	if err := git.ValidateFileLayout(cfg.FileLayout); err != nil {
+		return fmt.Errorf("spec.gitRepository.fileLayout: %w", err)
+	}
+	switch cfg.ConflictResolutionStrategy {
+	case "", git.StrategyPreferJira, git.StrategyPreferGit, git.StrategyMerge:
+	default:
+		return fmt.Errorf("spec.gitRepository.conflictResolutionStrategy %q is not one of prefer-jira, prefer-git, merge",
+			cfg.ConflictResolutionStrategy)
+	}
+	switch cfg.DriftPolicy {
+	case "", git.DriftOverwrite, git.DriftSkip, git.DriftFail:
+	default:
+		return fmt.Errorf("spec.gitRepository.driftPolicy %q is not one of overwrite, skip, fail",
+			cfg.DriftPolicy)
+	}
+	switch cfg.AuthorStrategy {
+	case "", git.AuthorOperator, git.AuthorReporter, git.AuthorAssignee:
+	default:
+		return fmt.Errorf("spec.gitRepository.authorStrategy %q is not one of operator, reporter, assignee",
+			cfg.AuthorStrategy)
+	}
+	if cfg.CommitBatchSize < 0 {
+		return fmt.Errorf("spec.gitRepository.commitBatchSize must not be negative")
+	}
+	if cfg.CommitMessageTemplate != "" {
+		if _, err := git.ParseCommitMessageTemplate(cfg.CommitMessageTemplate); err != nil {
+			return fmt.Errorf("spec.gitRepository.commitMessageTemplate: %w", err)
+		}
+	}
+	if cfg.CommitMessagePattern != "" {
+		if _, err := regexp.Compile(cfg.CommitMessagePattern); err != nil {
+			return fmt.Errorf("spec.gitRepository.commitMessagePattern: %w", err)
+		}
+	}
+	if cfg.IssueTemplate != "" {
+		if _, err := git.ParseIssueTemplate(cfg.IssueTemplate); err != nil {
+			return fmt.Errorf("spec.gitRepository.issueTemplate: %w", err)
+		}
+	}
+	for typeName, tmpl := range cfg.TypeTemplates {
+		if _, err := git.ParseIssueTemplate(tmpl); err != nil {
+			return fmt.Errorf("spec.gitRepository.typeTemplates[%s]: %w", typeName, err)
+		}
+	}
+	for typeName, layout := range cfg.TypeLayouts {
+		if err := git.ValidateFileLayout(layout); err != nil {
+			return fmt.Errorf("spec.gitRepository.typeLayouts[%s]: %w", typeName, err)
+		}
+	}
+	seen := map[string]bool{cfg.URL: true}
+	for i, url := range cfg.MirrorRemotes {
+		if url == "" {
+			return fmt.Errorf("spec.gitRepository.mirrorRemotes[%d] must not be empty", i)
+		}
+		if seen[url] {
+			return fmt.Errorf("spec.gitRepository.mirrorRemotes[%d] %q duplicates another remote", i, url)
+		}
+		seen[url] = true
+	}
+	for url := range cfg.MirrorCredentialsSecrets {
+		if !seen[url] || url == cfg.URL {
+			return fmt.Errorf("spec.gitRepository.mirrorCredentialsSecrets references %q, which is not in mirrorRemotes", url)
+		}
+	}
+	for i, rule := range cfg.RedactionRules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("spec.gitRepository.redactionRules[%d].pattern: %v", i, err)
+		}
+		if err := git.CheckRedactionPattern(re); err != nil {
+			return fmt.Errorf("spec.gitRepository.redactionRules[%d].pattern %q %v", i, rule.Pattern, err)
+		}
+	}
+	if cfg.RequireMirrors && len(cfg.MirrorRemotes) == 0 {
+		return fmt.Errorf("spec.gitRepository.requireMirrors is set but mirrorRemotes is empty")
+	}
+	return nil
+}
+
+func validateSync(cfg *cdcv1.SyncConfig) error {
+	if cfg.Interval != "" {
+		d, err := time.ParseDuration(cfg.Interval)
+		if err != nil {
+			return fmt.Errorf("spec.sync.interval %q is not a valid duration", cfg.Interval)
+		}
+		if d < time.Minute {
+			return fmt.Errorf("spec.sync.interval must be at least 1m to respect JIRA rate limits")
+		}
+	}
+	if cfg.Schedule != "" {
+		if _, err := cron.Parse(cfg.Schedule); err != nil {
+			return fmt.Errorf("spec.sync.schedule %q is not a valid cron expression: %w", cfg.Schedule, err)
+		}
+	}
+	if cfg.OperationTimeout != "" {
+		d, err := time.ParseDuration(cfg.OperationTimeout)
+		if err != nil {
+			return fmt.Errorf("spec.sync.operationTimeout %q is not a valid duration", cfg.OperationTimeout)
+		}
+		if d < time.Minute {
+			return fmt.Errorf("spec.sync.operationTimeout must be at least 1m")
+		}
+	}
+	if cfg.IncrementalOverlap != "" {
+		d, err := time.ParseDuration(cfg.IncrementalOverlap)
+		if err != nil {
+			return fmt.Errorf("spec.sync.incrementalOverlap %q is not a valid duration", cfg.IncrementalOverlap)
+		}
+		if d <= 0 {
+			return fmt.Errorf("spec.sync.incrementalOverlap must be positive")
+		}
+	}
+	if cfg.BatchSize < 0 || cfg.BatchSize > 100 {
+		return fmt.Errorf("spec.sync.batchSize must be between 1 and 100")
+	}
+	if cfg.MaxProjectConcurrency < 0 {
+		return fmt.Errorf("spec.sync.maxProjectConcurrency must not be negative")
+	}
+	if cfg.AttachmentConcurrency < 0 {
+		return fmt.Errorf("spec.sync.attachmentConcurrency must not be negative")
+	}
+	switch cfg.RemovedProjectPolicy {
+	case "", git.RemovedProjectKeep, git.RemovedProjectArchive, git.RemovedProjectDelete:
+	default:
+		return fmt.Errorf("spec.sync.removedProjectPolicy %q is not one of keep, archive, delete",
+			cfg.RemovedProjectPolicy)
+	}
+	seen := make(map[string]struct{}, len(cfg.FieldMapping))
+	for i, fm := range cfg.FieldMapping {
+		if !jiraFieldIDPattern.MatchString(fm.ID) {
+			return fmt.Errorf("spec.sync.fieldMapping[%d].id %q is not a valid JIRA field ID", i, fm.ID)
+		}
+		if fm.Name == "" {
+			return fmt.Errorf("spec.sync.fieldMapping[%d].name is required", i)
+		}
+		switch fm.Type {
+		case cdcv1.FieldTypeString, cdcv1.FieldTypeNumber, cdcv1.FieldTypeArray, cdcv1.FieldTypeDate:
+		default:
+			return fmt.Errorf("spec.sync.fieldMapping[%d].type %q is not one of string, number, array, date", i, fm.Type)
+		}
+		if _, dup := seen[fm.Name]; dup {
+			return fmt.Errorf("spec.sync.fieldMapping[%d].name %q is mapped more than once", i, fm.Name)
+		}
+		seen[fm.Name] = struct{}{}
+	}
+	if err := validateFilterValues("spec.sync.labelFilter", cfg.LabelFilter); err != nil {
+		return err
+	}
+	if err := validateFilterValues("spec.sync.componentFilter", cfg.ComponentFilter); err != nil {
+		return err
+	}
+	if err := validateFilterValues("spec.sync.ignoreStatuses", cfg.IgnoreStatuses); err != nil {
+		return err
+	}
+	for i, field := range cfg.IgnoreFields {
+		if diffFieldNames[field] {
+			continue
+		}
+		mapped := false
+		for _, fm := range cfg.FieldMapping {
+			if fm.Name == field {
+				mapped = true
+				break
+			}
+		}
+		if !mapped {
+			return fmt.Errorf("spec.sync.ignoreFields[%d] %q is neither a standard field nor a fieldMapping name", i, field)
+		}
+	}
+	return nil
+}
+
+// diffFieldNames are the standard fields change detection compares;
+// spec.sync.ignoreFields entries must name one of these or a mapped
+// custom field.
+var diffFieldNames = map[string]bool{
+	"summary": true, "type": true, "status": true, "priority": true,
+	"assignee": true, "reporter": true, "labels": true, "components": true,
+	"sprint": true, "epic": true, "timeSpent": true,
+	"remainingEstimate": true, "description": true,
+}
+
+// validateFilterValues rejects filter entries that are empty or would
+// break out of their quoted JQL value.
+func validateFilterValues(field string, values []string) error {
+	for i, v := range values {
+		if v == "" {
+			return fmt.Errorf("%s[%d] must not be empty", field, i)
+		}
+		if strings.ContainsAny(v, `"\`) {
+			return fmt.Errorf("%s[%d] %q must not contain quotes or backslashes", field, i, v)
+		}
+	}
+	return nil
+}
+
+// validateProjectRemoval rejects an update that drops project keys from
+// the spec while no removed-project policy is set, since the operator
+// has not said whether the already-mirrored files should be kept,
+// archived, or deleted.
+func validateProjectRemoval(old, updated *cdcv1.JiraCDCSpec) error {
+	if updated.Sync.RemovedProjectPolicy != "" {
+		return nil
+	}
+	kept := make(map[string]struct{})
+	for _, project := range updated.Jira.Projects() {
+		kept[project] = struct{}{}
+	}
+	for _, project := range old.Jira.Projects() {
+		if _, ok := kept[project]; !ok {
+			return fmt.Errorf("project %s was removed but spec.sync.removedProjectPolicy is not set; choose keep, archive, or delete before removing project keys", project)
+		}
+	}
+	return nil
+}
+
+// jiraFieldIDPattern matches JIRA custom field IDs like "customfield_10016".
+var jiraFieldIDPattern = regexp.MustCompile(`^customfield_\d+$`)
+
+// specWarnings surfaces non-fatal configuration concerns.
+func specWarnings(spec *cdcv1.JiraCDCSpec) admission.Warnings {
+	var warnings admission.Warnings
+	if spec.Jira.AuthMethod == jira.AuthMethodPAT &&
+		strings.Contains(spec.Jira.BaseURL, ".atlassian.net") {
+		warnings = append(warnings,
+			"spec.jira.baseURL looks like a JIRA Cloud instance, which does not support pat auth; use basic auth with an email and API token")
+	}
+	if spec.Jira.APIVersion != 3 &&
+		strings.Contains(spec.Jira.BaseURL, ".atlassian.net") {
+		warnings = append(warnings,
+			"spec.jira.baseURL looks like a JIRA Cloud instance; set spec.jira.apiVersion: 3 to use the v3 API with full-fidelity rich text (ADF) bodies")
+	}
+	if spec.Jira.TLS.InsecureSkipVerify {
+		warnings = append(warnings,
+			"spec.jira.tls.insecureSkipVerify disables certificate verification; prefer a CA bundle via spec.jira.tls.caSecret")
+	}
+	if spec.GitRepository.TLS.InsecureSkipVerify {
+		warnings = append(warnings,
+			"spec.gitRepository.tls.insecureSkipVerify disables certificate verification; prefer a CA bundle via spec.gitRepository.tls.caSecret")
+	}
+	warnings = append(warnings, proxyWarnings("spec.jira.proxy", spec.Jira.Proxy)...)
+	warnings = append(warnings, proxyWarnings("spec.gitRepository.proxy", spec.GitRepository.Proxy)...)
+	return warnings
+}
+
+// proxyWarnings flags malformed proxy URLs without rejecting the
+// resource, since egress settings are often copied verbatim from
+// environment configs and a broken proxy only degrades connectivity.
+// Credentials embedded in the URLs are redacted from the warning text.
+func proxyWarnings(field string, cfg cdcv1.ProxyConfig) admission.Warnings {
+	var warnings admission.Warnings
+	for _, p := range []struct{ name, value string }{
+		{"httpProxy", cfg.HTTPProxy},
+		{"httpsProxy", cfg.HTTPSProxy},
+	} {
+		if p.value == "" {
+			continue
+		}
+		u, err := url.Parse(p.value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			warnings = append(warnings, fmt.Sprintf("%s.%s %q does not look like a valid proxy URL",
+				field, p.name, jira.RedactURL(p.value)))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,339 @@
+// Package fakejira provides a configurable in-process fake of the JIRA
+// REST API covering the endpoints the CDC pipeline uses, so integration
+// tests can exercise pagination, rate limiting and error handling
+// deterministically instead of reinventing inline mock servers.
+package fakejira
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/chambridge/atlassian-cdc-git/internal/jira"
+)
+
+// Issue is the fixture type served by the fake, aliased to the real
+// wire type so fixtures and assertions share one definition.
+type Issue = jira.Issue
+
+// User aliases the real wire type for fixture construction.
+type User = jira.User
+
+// ChangelogItem is one field transition within a changelog history.
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FromString string `json:"fromString"`
+	ToString   string `json:"toString"`
+}
+
+// ChangelogHistory is one changelog event in JIRA's wire shape.
+type ChangelogHistory struct {
+	Author  User            `json:"author"`
+	Created string          `json:"created"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// Comment is one issue comment in JIRA's wire shape.
+type Comment struct {
+	Author  User   `json:"author"`
+	Body    string `json:"body"`
+	Created string `json:"created"`
+}
+
+// FakeJira is a fake JIRA instance backed by issue fixtures. It serves
+// /myself, /project/{key}, /search (with pagination), single issues
+// (including expand=changelog), the paginated changelog endpoint and
+// issue comments. Responses can carry rate-limit headers and queued
+// errors (429, 500, 401, ...) for failure-path testing.
+type FakeJira struct {
+	// Server is the underlying test server; its URL is the base URL to
+	// hand to jira.NewClient.
+	Server *httptest.Server
+
+	mu         sync.Mutex
+	issues     []Issue
+	changelogs map[string][]ChangelogHistory
+	comments   map[string][]Comment
+	errorQueue []int
+	remaining  int64
+	hasLimit   bool
+	requests   int
+}
+
+// NewFakeJira starts a fake JIRA serving the given issue fixtures. The
+// caller owns the returned server and must Close it.
+func NewFakeJira(fixtures []Issue) *FakeJira {
+	f := &FakeJira{
+		issues:     append([]Issue(nil), fixtures...),
+		changelogs: make(map[string][]ChangelogHistory),
+		comments:   make(map[string][]Comment),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// URL returns the base URL for jira.Config.BaseURL.
+func (f *FakeJira) URL() string {
+	return f.Server.URL
+}
+
+// Close shuts the fake server down.
+func (f *FakeJira) Close() {
+	f.Server.Close()
+}
+
+// AddIssue appends or replaces a fixture by key.
+func (f *FakeJira) AddIssue(issue Issue) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.issues {
+		if f.issues[i].Key == issue.Key {
+			f.issues[i] = issue
+			return
+		}
+	}
+	f.issues = append(f.issues, issue)
+}
+
+// RemoveIssue deletes a fixture by key; subsequent fetches return 404.
+func (f *FakeJira) RemoveIssue(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.issues {
+		if f.issues[i].Key == key {
+			f.issues = append(f.issues[:i], f.issues[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetChangelog sets the changelog histories served for an issue.
+func (f *FakeJira) SetChangelog(key string, histories []ChangelogHistory) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changelogs[key] = append([]ChangelogHistory(nil), histories...)
+}
+
+// SetComments sets the comments served for an issue.
+func (f *FakeJira) SetComments(key string, comments []Comment) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.comments[key] = append([]Comment(nil), comments...)
+}
+
+// SetRateLimitRemaining makes every response carry an
+// X-RateLimit-Remaining header starting at n, decrementing per request.
+func (f *FakeJira) SetRateLimitRemaining(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.remaining = int64(n)
+	f.hasLimit = true
+}
+
+// InjectErrors queues the given status for the next times requests,
+// which fail before any endpoint handling. A 429 carries Retry-After.
+func (f *FakeJira) InjectErrors(status, times int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := 0; i < times; i++ {
+		f.errorQueue = append(f.errorQueue, status)
+	}
+}
+
+// Requests returns how many requests the fake has served, including
+// injected failures.
+func (f *FakeJira) Requests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+var (
+	issuePathPattern   = regexp.MustCompile(`^/rest/api/2/issue/([^/]+)$`)
+	changelogPattern   = regexp.MustCompile(`^/rest/api/2/issue/([^/]+)/changelog$`)
+	commentPattern     = regexp.MustCompile(`^/rest/api/2/issue/([^/]+)/comment$`)
+	projectPathPattern = regexp.MustCompile(`^/rest/api/2/project/([^/]+)$`)
+	jqlProjectPattern  = regexp.MustCompile(`project\s*=\s*"?(\w+)"?`)
+)
+
+func (f *FakeJira) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests++
+	if f.hasLimit {
+		w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(f.remaining, 10))
+		if f.remaining > 0 {
+			f.remaining--
+		}
+	}
+	if len(f.errorQueue) > 0 {
+		status := f.errorQueue[0]
+		f.errorQueue = f.errorQueue[1:]
+		f.mu.Unlock()
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	f.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/rest/api/2/myself":
+		writeJSON(w, User{AccountID: "fake-user", DisplayName: "Fake User"})
+	case projectPathPattern.MatchString(path):
+		f.handleProject(w, projectPathPattern.FindStringSubmatch(path)[1])
+	case path == "/rest/api/2/search":
+		f.handleSearch(w, r)
+	case changelogPattern.MatchString(path):
+		f.handleChangelog(w, r, changelogPattern.FindStringSubmatch(path)[1])
+	case commentPattern.MatchString(path):
+		f.handleComments(w, commentPattern.FindStringSubmatch(path)[1])
+	case issuePathPattern.MatchString(path):
+		f.handleIssue(w, r, issuePathPattern.FindStringSubmatch(path)[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *FakeJira) handleProject(w http.ResponseWriter, key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.issues {
+		if strings.HasPrefix(f.issues[i].Key, key+"-") {
+			writeJSON(w, jira.Project{ID: "10000", Key: key, Name: key})
+			return
+		}
+	}
+	http.Error(w, "project not found", http.StatusNotFound)
+}
+
+// handleSearch serves one page of fixtures. Only the project clause of
+// the JQL is interpreted; everything else is ignored, so tests control
+// result sets through fixtures rather than query semantics.
+func (f *FakeJira) handleSearch(w http.ResponseWriter, r *http.Request) {
+	project := ""
+	if m := jqlProjectPattern.FindStringSubmatch(r.URL.Query().Get("jql")); m != nil {
+		project = m[1]
+	}
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", 50)
+
+	f.mu.Lock()
+	var matched []Issue
+	for i := range f.issues {
+		if project == "" || strings.HasPrefix(f.issues[i].Key, project+"-") {
+			matched = append(matched, f.issues[i])
+		}
+	}
+	f.mu.Unlock()
+
+	end := startAt + maxResults
+	if startAt > len(matched) {
+		startAt = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+	writeJSON(w, jira.SearchResult{
+		StartAt:    startAt,
+		MaxResults: maxResults,
+		Total:      len(matched),
+		Issues:     matched[startAt:end],
+	})
+}
+
+func (f *FakeJira) handleIssue(w http.ResponseWriter, r *http.Request, key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	issue := f.findIssue(key)
+	if issue == nil {
+		http.Error(w, "issue not found", http.StatusNotFound)
+		return
+	}
+	if strings.Contains(r.URL.Query().Get("expand"), "changelog") {
+		histories := f.changelogs[key]
+		writeJSON(w, struct {
+			Issue
+			Changelog map[string]interface{} `json:"changelog"`
+		}{
+			Issue: *issue,
+			Changelog: map[string]interface{}{
+				"startAt":    0,
+				"maxResults": len(histories),
+				"total":      len(histories),
+				"histories":  histories,
+			},
+		})
+		return
+	}
+	writeJSON(w, issue)
+}
+
+func (f *FakeJira) handleChangelog(w http.ResponseWriter, r *http.Request, key string) {
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", 100)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.findIssue(key) == nil {
+		http.Error(w, "issue not found", http.StatusNotFound)
+		return
+	}
+	histories := f.changelogs[key]
+	end := startAt + maxResults
+	if startAt > len(histories) {
+		startAt = len(histories)
+	}
+	if end > len(histories) {
+		end = len(histories)
+	}
+	writeJSON(w, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(histories),
+		"values":     histories[startAt:end],
+	})
+}
+
+func (f *FakeJira) handleComments(w http.ResponseWriter, key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.findIssue(key) == nil {
+		http.Error(w, "issue not found", http.StatusNotFound)
+		return
+	}
+	comments := f.comments[key]
+	writeJSON(w, map[string]interface{}{
+		"startAt":    0,
+		"maxResults": len(comments),
+		"total":      len(comments),
+		"comments":   comments,
+	})
+}
+
+// findIssue must be called with f.mu held.
+func (f *FakeJira) findIssue(key string) *Issue {
+	for i := range f.issues {
+		if f.issues[i].Key == key {
+			return &f.issues[i]
+		}
+	}
+	return nil
+}
+
+func intParam(r *http.Request, name string, fallback int) int {
+	if v, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil && v >= 0 {
+		return v
+	}
+	return fallback
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}